@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"workouts_bot/src/config"
+	"workouts_bot/src/database"
 	"workouts_bot/src/logger"
 )
 
@@ -52,18 +53,24 @@ func main() {
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
+	botDone := make(chan struct{})
 	go func() {
+		defer close(botDone)
 		if err := app.Bot.Start(botContext); err != nil {
 			logger.Error("Bot error:", err)
-			cancel()
 		}
 	}()
 
-	sig := <-signalChan
-	logger.Info("Received signal:", sig.String())
+	select {
+	case sig := <-signalChan:
+		logger.Info("Received signal:", sig.String())
+	case <-botDone:
+		logger.Warn("Bot stopped unexpectedly")
+	}
 	logger.Info("Shutting down gracefully...")
 
 	cancel()
+	<-botDone
 
 	shutdownTimeout := 10 * time.Second
 	shutdownContext, shutdownCancel := context.WithTimeout(
@@ -72,12 +79,15 @@ func main() {
 	)
 	defer shutdownCancel()
 
-	select {
-	case <-shutdownContext.Done():
-		logger.Warn("Shutdown timeout exceeded, forcing exit")
-	case <-time.After(0 * time.Second):
-		logger.Info("Shutdown completed")
+	if err := app.Bot.Shutdown(shutdownContext); err != nil {
+		logger.Warn("Shutdown timeout exceeded:", err)
 	}
+
+	if err := database.Close(app.DB); err != nil {
+		logger.Warn("Failed to close database:", err)
+	}
+
+	logger.Info("Shutdown completed")
 }
 
 func healthCheck() {
@@ -5,8 +5,11 @@ package main
 
 import (
 	"workouts_bot/src/bot"
+	"workouts_bot/src/clients"
 	"workouts_bot/src/config"
 	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/services/media"
 
 	"github.com/google/wire"
 	"gorm.io/gorm"
@@ -16,12 +19,22 @@ func provideDatabaseConfig(cfg *config.Config) *config.DatabaseConfig {
 	return &cfg.Database
 }
 
-func provideBotToken(cfg *config.Config) string {
-	return cfg.BotToken
+func provideS3Config(cfg *config.Config) *config.S3Config {
+	return &cfg.S3
 }
 
-func provideWebhookConfig(cfg *config.Config) *config.WebhookConfig {
-	return &cfg.Webhook
+// provideMediaStorage returns the S3 client, or nil when S3 is not
+// configured so the bot still starts without media uploads.
+func provideMediaStorage(cfg *config.S3Config) (media.Storage, error) {
+	if !cfg.Enabled() {
+		logger.Warn("S3 is not configured, exercise media uploads are disabled")
+		return nil, nil
+	}
+	client, err := clients.NewS3Client(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
 }
 
 type BotApp struct {
@@ -34,8 +47,8 @@ func InitializeBot() (*BotApp, error) {
 		config.Load,
 		provideDatabaseConfig,
 		database.Connect,
-		provideBotToken,
-		provideWebhookConfig,
+		provideS3Config,
+		provideMediaStorage,
 		bot.New,
 		wire.Struct(new(BotApp), "Bot", "DB"),
 	)
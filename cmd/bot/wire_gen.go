@@ -9,8 +9,11 @@ package main
 import (
 	"gorm.io/gorm"
 	"workouts_bot/src/bot"
+	"workouts_bot/src/clients"
 	"workouts_bot/src/config"
 	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/services/media"
 )
 
 // Injectors from wire.go:
@@ -20,14 +23,17 @@ func InitializeBot() (*BotApp, error) {
 	if err != nil {
 		return nil, err
 	}
-	string2 := provideBotToken(configConfig)
 	databaseConfig := provideDatabaseConfig(configConfig)
 	db, err := database.Connect(databaseConfig)
 	if err != nil {
 		return nil, err
 	}
-	webhookConfig := provideWebhookConfig(configConfig)
-	botBot, err := bot.New(string2, db, webhookConfig)
+	s3Config := provideS3Config(configConfig)
+	storage, err := provideMediaStorage(s3Config)
+	if err != nil {
+		return nil, err
+	}
+	botBot, err := bot.New(configConfig, db, storage)
 	if err != nil {
 		return nil, err
 	}
@@ -44,12 +50,22 @@ func provideDatabaseConfig(cfg *config.Config) *config.DatabaseConfig {
 	return &cfg.Database
 }
 
-func provideBotToken(cfg *config.Config) string {
-	return cfg.BotToken
+func provideS3Config(cfg *config.Config) *config.S3Config {
+	return &cfg.S3
 }
 
-func provideWebhookConfig(cfg *config.Config) *config.WebhookConfig {
-	return &cfg.Webhook
+// provideMediaStorage returns the S3 client, or nil when S3 is not
+// configured so the bot still starts without media uploads.
+func provideMediaStorage(cfg *config.S3Config) (media.Storage, error) {
+	if !cfg.Enabled() {
+		logger.Warn("S3 is not configured, exercise media uploads are disabled")
+		return nil, nil
+	}
+	client, err := clients.NewS3Client(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
 }
 
 type BotApp struct {
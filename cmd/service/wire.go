@@ -5,10 +5,12 @@ package main
 
 import (
 	"workouts_bot/src/config"
+	"workouts_bot/src/database"
 	"workouts_bot/src/router"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	"gorm.io/gorm"
 )
 
 type ServiceApp struct {
@@ -16,16 +18,22 @@ type ServiceApp struct {
 	Cfg    *config.Config
 }
 
-func NewServiceApp(cfg *config.Config) *ServiceApp {
+func provideDatabaseConfig(cfg *config.Config) *config.DatabaseConfig {
+	return &cfg.Database
+}
+
+func NewServiceApp(cfg *config.Config, db *gorm.DB) *ServiceApp {
 	return &ServiceApp{
 		Cfg:    cfg,
-		Engine: router.NewRouter(cfg),
+		Engine: router.NewRouter(cfg, db),
 	}
 }
 
 func InitializeService() (*ServiceApp, error) {
 	wire.Build(
 		config.Load,
+		provideDatabaseConfig,
+		database.Connect,
 		NewServiceApp,
 	)
 	return nil, nil
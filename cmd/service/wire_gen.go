@@ -8,7 +8,9 @@ package main
 
 import (
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 	"workouts_bot/src/config"
+	"workouts_bot/src/database"
 	"workouts_bot/src/router"
 )
 
@@ -19,7 +21,12 @@ func InitializeService() (*ServiceApp, error) {
 	if err != nil {
 		return nil, err
 	}
-	serviceApp := NewServiceApp(configConfig)
+	databaseConfig := provideDatabaseConfig(configConfig)
+	db, err := database.Connect(databaseConfig)
+	if err != nil {
+		return nil, err
+	}
+	serviceApp := NewServiceApp(configConfig, db)
 	return serviceApp, nil
 }
 
@@ -30,9 +37,13 @@ type ServiceApp struct {
 	Cfg    *config.Config
 }
 
-func NewServiceApp(cfg *config.Config) *ServiceApp {
+func provideDatabaseConfig(cfg *config.Config) *config.DatabaseConfig {
+	return &cfg.Database
+}
+
+func NewServiceApp(cfg *config.Config, db *gorm.DB) *ServiceApp {
 	return &ServiceApp{
 		Cfg:    cfg,
-		Engine: router.NewRouter(cfg),
+		Engine: router.NewRouter(cfg, db),
 	}
 }
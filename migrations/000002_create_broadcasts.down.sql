@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS workouts.broadcast_deliveries;
+DROP TABLE IF EXISTS workouts.broadcasts;
+
+ALTER TABLE workouts.users DROP COLUMN IF EXISTS blocked_at;
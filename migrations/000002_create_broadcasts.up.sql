@@ -0,0 +1,33 @@
+ALTER TABLE workouts.users ADD COLUMN IF NOT EXISTS blocked_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS workouts.broadcasts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_telegram_id BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'draft',
+    progress_chat_id BIGINT,
+    progress_message_id INTEGER,
+    total INTEGER NOT NULL DEFAULT 0,
+    sent INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    blocked INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS workouts.broadcast_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    broadcast_id UUID NOT NULL REFERENCES workouts.broadcasts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    telegram_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'pending',
+    error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (broadcast_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcast_deliveries_broadcast_status
+    ON workouts.broadcast_deliveries (broadcast_id, status);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS workouts.pending_actions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    telegram_id BIGINT NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    payload TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_actions_telegram_id
+    ON workouts.pending_actions (telegram_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS workouts.callback_payloads (
+    key VARCHAR(16) PRIMARY KEY,
+    payload TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_callback_payloads_expires_at
+    ON workouts.callback_payloads (expires_at);
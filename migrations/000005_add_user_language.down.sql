@@ -0,0 +1,2 @@
+ALTER TABLE workouts.users DROP COLUMN IF EXISTS language;
+ALTER TABLE workouts.users DROP COLUMN IF EXISTS language_code;
@@ -0,0 +1,2 @@
+ALTER TABLE workouts.users ADD COLUMN IF NOT EXISTS language_code VARCHAR(16);
+ALTER TABLE workouts.users ADD COLUMN IF NOT EXISTS language VARCHAR(8);
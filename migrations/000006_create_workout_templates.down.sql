@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS workouts.template_exercises;
+DROP TABLE IF EXISTS workouts.workout_templates;
+DROP TABLE IF EXISTS workouts.exercises;
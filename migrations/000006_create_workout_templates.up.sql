@@ -0,0 +1,77 @@
+CREATE TABLE IF NOT EXISTS workouts.exercises (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    name_ru VARCHAR(255) NOT NULL,
+    name_en VARCHAR(255) NOT NULL,
+    category VARCHAR(32) NOT NULL,
+    muscle_group VARCHAR(32) NOT NULL,
+    equipment VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS workouts.workout_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(32) NOT NULL DEFAULT 'custom',
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_templates_user_id
+    ON workouts.workout_templates (user_id);
+
+CREATE TABLE IF NOT EXISTS workouts.template_exercises (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    template_id UUID NOT NULL REFERENCES workouts.workout_templates(id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES workouts.exercises(id),
+    position INTEGER NOT NULL,
+    target_sets INTEGER NOT NULL DEFAULT 3,
+    target_reps INTEGER NOT NULL DEFAULT 10,
+    target_weight NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    rest_seconds INTEGER NOT NULL DEFAULT 90,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_exercises_template_id
+    ON workouts.template_exercises (template_id, position);
+
+INSERT INTO workouts.exercises (slug, name_ru, name_en, category, muscle_group, equipment) VALUES
+    ('bench_press', 'Жим штанги лёжа', 'Barbell bench press', 'compound', 'chest', 'barbell'),
+    ('incline_dumbbell_press', 'Жим гантелей на наклонной скамье', 'Incline dumbbell press', 'compound', 'chest', 'dumbbell'),
+    ('dumbbell_fly', 'Разводка гантелей лёжа', 'Dumbbell fly', 'isolation', 'chest', 'dumbbell'),
+    ('push_up', 'Отжимания', 'Push-up', 'bodyweight', 'chest', 'bodyweight'),
+    ('dips', 'Отжимания на брусьях', 'Dips', 'bodyweight', 'triceps', 'bodyweight'),
+    ('deadlift', 'Становая тяга', 'Deadlift', 'compound', 'back', 'barbell'),
+    ('pull_up', 'Подтягивания', 'Pull-up', 'bodyweight', 'back', 'bodyweight'),
+    ('barbell_row', 'Тяга штанги в наклоне', 'Barbell row', 'compound', 'back', 'barbell'),
+    ('lat_pulldown', 'Тяга верхнего блока', 'Lat pulldown', 'compound', 'back', 'cable'),
+    ('seated_cable_row', 'Тяга нижнего блока', 'Seated cable row', 'compound', 'back', 'cable'),
+    ('overhead_press', 'Армейский жим', 'Overhead press', 'compound', 'shoulders', 'barbell'),
+    ('lateral_raise', 'Махи гантелями в стороны', 'Lateral raise', 'isolation', 'shoulders', 'dumbbell'),
+    ('face_pull', 'Тяга каната к лицу', 'Face pull', 'isolation', 'shoulders', 'cable'),
+    ('barbell_curl', 'Подъём штанги на бицепс', 'Barbell curl', 'isolation', 'biceps', 'barbell'),
+    ('hammer_curl', 'Молотковые сгибания', 'Hammer curl', 'isolation', 'biceps', 'dumbbell'),
+    ('triceps_pushdown', 'Разгибания рук на блоке', 'Triceps pushdown', 'isolation', 'triceps', 'cable'),
+    ('skull_crusher', 'Французский жим', 'Skull crusher', 'isolation', 'triceps', 'barbell'),
+    ('squat', 'Приседания со штангой', 'Barbell squat', 'compound', 'legs', 'barbell'),
+    ('front_squat', 'Фронтальные приседания', 'Front squat', 'strength', 'legs', 'barbell'),
+    ('leg_press', 'Жим ногами', 'Leg press', 'compound', 'legs', 'machine'),
+    ('romanian_deadlift', 'Румынская тяга', 'Romanian deadlift', 'compound', 'legs', 'barbell'),
+    ('lunge', 'Выпады с гантелями', 'Dumbbell lunge', 'compound', 'legs', 'dumbbell'),
+    ('leg_curl', 'Сгибания ног в тренажёре', 'Leg curl', 'isolation', 'legs', 'machine'),
+    ('leg_extension', 'Разгибания ног в тренажёре', 'Leg extension', 'isolation', 'legs', 'machine'),
+    ('calf_raise', 'Подъём на носки', 'Calf raise', 'isolation', 'legs', 'machine'),
+    ('hip_thrust', 'Ягодичный мост со штангой', 'Barbell hip thrust', 'compound', 'glutes', 'barbell'),
+    ('farmers_walk', 'Прогулка фермера', 'Farmer''s walk', 'strength', 'back', 'dumbbell'),
+    ('plank', 'Планка', 'Plank', 'bodyweight', 'abs', 'bodyweight'),
+    ('crunch', 'Скручивания', 'Crunch', 'bodyweight', 'abs', 'bodyweight'),
+    ('hanging_leg_raise', 'Подъём ног в висе', 'Hanging leg raise', 'bodyweight', 'abs', 'bodyweight'),
+    ('running', 'Бег', 'Running', 'cardio', 'legs', 'none'),
+    ('rowing', 'Гребля', 'Rowing', 'cardio', 'back', 'cardio_machine'),
+    ('cycling', 'Велосипед', 'Cycling', 'endurance', 'legs', 'cardio_machine'),
+    ('jump_rope', 'Скакалка', 'Jump rope', 'hiit', 'legs', 'none'),
+    ('burpee', 'Бёрпи', 'Burpee', 'hiit', 'legs', 'bodyweight'),
+    ('kettlebell_swing', 'Махи гирей', 'Kettlebell swing', 'hiit', 'glutes', 'kettlebell')
+ON CONFLICT (slug) DO NOTHING;
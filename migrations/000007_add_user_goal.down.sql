@@ -0,0 +1 @@
+ALTER TABLE workouts.users DROP COLUMN IF EXISTS goal;
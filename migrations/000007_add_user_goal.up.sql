@@ -0,0 +1 @@
+ALTER TABLE workouts.users ADD COLUMN IF NOT EXISTS goal VARCHAR(32);
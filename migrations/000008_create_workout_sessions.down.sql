@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS workouts.session_sets;
+DROP TABLE IF EXISTS workouts.session_exercises;
+DROP TABLE IF EXISTS workouts.workout_sessions;
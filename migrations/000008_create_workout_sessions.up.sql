@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS workouts.workout_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    template_id UUID REFERENCES workouts.workout_templates(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_sessions_user_id
+    ON workouts.workout_sessions (user_id, started_at);
+
+-- At most one unfinished session per user.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workout_sessions_active
+    ON workouts.workout_sessions (user_id)
+    WHERE finished_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS workouts.session_exercises (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES workouts.workout_sessions(id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES workouts.exercises(id),
+    position INTEGER NOT NULL,
+    target_sets INTEGER NOT NULL DEFAULT 3,
+    target_reps INTEGER NOT NULL DEFAULT 10,
+    target_weight NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    rest_seconds INTEGER NOT NULL DEFAULT 90,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_exercises_session_id
+    ON workouts.session_exercises (session_id, position);
+
+CREATE TABLE IF NOT EXISTS workouts.session_sets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_exercise_id UUID NOT NULL REFERENCES workouts.session_exercises(id) ON DELETE CASCADE,
+    number INTEGER NOT NULL,
+    reps INTEGER NOT NULL DEFAULT 0,
+    weight NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    skipped BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_sets_session_exercise_id
+    ON workouts.session_sets (session_exercise_id, number);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS workouts.exercise_media (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    exercise_id UUID NOT NULL REFERENCES workouts.exercises(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    object_key VARCHAR(512) NOT NULL UNIQUE,
+    content_type VARCHAR(64) NOT NULL,
+    telegram_file_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_media_exercise_id
+    ON workouts.exercise_media (exercise_id, kind);
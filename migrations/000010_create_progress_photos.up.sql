@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS workouts.progress_photos (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    pose VARCHAR(16) NOT NULL,
+    object_key VARCHAR(512) NOT NULL UNIQUE,
+    telegram_file_id VARCHAR(255),
+    taken_on DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_progress_photos_user_taken_on
+    ON workouts.progress_photos (user_id, taken_on);
+
+CREATE INDEX IF NOT EXISTS idx_progress_photos_taken_on
+    ON workouts.progress_photos (taken_on);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS workouts.body_measurements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    measured_on DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (user_id, kind, measured_on)
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS workouts.idx_session_exercises_exercise_id;
+
+ALTER TABLE workouts.session_sets
+    DROP COLUMN IF EXISTS rpe;
+
+ALTER TABLE workouts.users
+    DROP COLUMN IF EXISTS progression;
@@ -0,0 +1,8 @@
+ALTER TABLE workouts.users
+    ADD COLUMN IF NOT EXISTS progression VARCHAR(16) NOT NULL DEFAULT 'double';
+
+ALTER TABLE workouts.session_sets
+    ADD COLUMN IF NOT EXISTS rpe NUMERIC(3, 1) NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_session_exercises_exercise_id
+    ON workouts.session_exercises (exercise_id);
@@ -0,0 +1,4 @@
+ALTER TABLE workouts.session_exercises DROP COLUMN IF EXISTS plan;
+ALTER TABLE workouts.workout_sessions DROP COLUMN IF EXISTS enrollment_id;
+DROP TABLE IF EXISTS workouts.program_enrollments;
+DROP TABLE IF EXISTS workouts.training_maxes;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS workouts.training_maxes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES workouts.exercises(id) ON DELETE CASCADE,
+    weight NUMERIC(7, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (user_id, exercise_id)
+);
+
+-- One program at a time; enrolling again replaces the row.
+CREATE TABLE IF NOT EXISTS workouts.program_enrollments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL UNIQUE REFERENCES workouts.users(id) ON DELETE CASCADE,
+    program VARCHAR(32) NOT NULL,
+    cycle INTEGER NOT NULL DEFAULT 1,
+    week INTEGER NOT NULL DEFAULT 0,
+    day INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE workouts.workout_sessions
+    ADD COLUMN IF NOT EXISTS enrollment_id UUID
+        REFERENCES workouts.program_enrollments(id) ON DELETE SET NULL;
+
+-- Per-set targets of program sessions.
+ALTER TABLE workouts.session_exercises
+    ADD COLUMN IF NOT EXISTS plan JSONB;
@@ -0,0 +1,5 @@
+ALTER TABLE workouts.training_maxes
+    DROP COLUMN IF EXISTS source;
+
+ALTER TABLE workouts.training_maxes
+    DROP COLUMN IF EXISTS one_rep_max;
@@ -0,0 +1,5 @@
+ALTER TABLE workouts.training_maxes
+    ADD COLUMN IF NOT EXISTS one_rep_max NUMERIC(7, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE workouts.training_maxes
+    ADD COLUMN IF NOT EXISTS source VARCHAR(16) NOT NULL DEFAULT 'manual';
@@ -0,0 +1,10 @@
+ALTER TABLE workouts.session_sets
+    DROP COLUMN IF EXISTS warmup;
+
+ALTER TABLE workouts.session_exercises
+    DROP COLUMN IF EXISTS warmup;
+
+ALTER TABLE workouts.users
+    DROP COLUMN IF EXISTS warmups,
+    DROP COLUMN IF EXISTS plates,
+    DROP COLUMN IF EXISTS plate_unit;
@@ -0,0 +1,11 @@
+ALTER TABLE workouts.users
+    ADD COLUMN IF NOT EXISTS plate_unit VARCHAR(2) NOT NULL DEFAULT 'kg',
+    ADD COLUMN IF NOT EXISTS plates VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS warmups BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Warm-up sets planned before the working sets of an exercise.
+ALTER TABLE workouts.session_exercises
+    ADD COLUMN IF NOT EXISTS warmup JSONB;
+
+ALTER TABLE workouts.session_sets
+    ADD COLUMN IF NOT EXISTS warmup BOOLEAN NOT NULL DEFAULT FALSE;
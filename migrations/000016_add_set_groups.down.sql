@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS workouts.idx_session_sets_parent_id;
+
+ALTER TABLE workouts.session_sets
+    DROP COLUMN IF EXISTS seconds,
+    DROP COLUMN IF EXISTS parent_id,
+    DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE workouts.session_exercises
+    DROP COLUMN IF EXISTS work_seconds,
+    DROP COLUMN IF EXISTS linked;
+
+ALTER TABLE workouts.template_exercises
+    DROP COLUMN IF EXISTS work_seconds,
+    DROP COLUMN IF EXISTS linked;
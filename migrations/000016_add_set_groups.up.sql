@@ -0,0 +1,17 @@
+-- Linked exercises run as a superset or circuit with the next exercise;
+-- work_seconds turns the sets into timed intervals.
+ALTER TABLE workouts.template_exercises
+    ADD COLUMN IF NOT EXISTS linked BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS work_seconds INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE workouts.session_exercises
+    ADD COLUMN IF NOT EXISTS linked BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS work_seconds INTEGER NOT NULL DEFAULT 0;
+
+-- Drop sets and rest-pause sets hang off the set they extend.
+ALTER TABLE workouts.session_sets
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES workouts.session_sets(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS seconds INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_session_sets_parent_id ON workouts.session_sets(parent_id);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS workouts.cardio_entries;
+
+DELETE FROM workouts.exercises
+WHERE slug IN ('walking', 'hiking', 'swimming', 'elliptical')
+    AND NOT EXISTS (
+        SELECT 1 FROM workouts.session_exercises WHERE session_exercises.exercise_id = exercises.id
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM workouts.template_exercises WHERE template_exercises.exercise_id = exercises.id
+    );
@@ -0,0 +1,23 @@
+-- Cardio and endurance activities, logged in a session or on their own.
+CREATE TABLE IF NOT EXISTS workouts.cardio_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES workouts.exercises(id) ON DELETE CASCADE,
+    session_id UUID REFERENCES workouts.workout_sessions(id) ON DELETE SET NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    duration_seconds INTEGER NOT NULL,
+    distance_meters DOUBLE PRECISION NOT NULL DEFAULT 0,
+    heart_rate INTEGER NOT NULL DEFAULT 0,
+    effort INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_cardio_entries_user_started
+    ON workouts.cardio_entries(user_id, started_at);
+
+INSERT INTO workouts.exercises (slug, name_ru, name_en, category, muscle_group, equipment) VALUES
+    ('walking', 'Ходьба', 'Walking', 'cardio', 'legs', 'none'),
+    ('hiking', 'Поход', 'Hiking', 'endurance', 'legs', 'none'),
+    ('swimming', 'Плавание', 'Swimming', 'endurance', 'back', 'none'),
+    ('elliptical', 'Эллипсоид', 'Elliptical', 'cardio', 'legs', 'cardio_machine')
+ON CONFLICT (slug) DO NOTHING;
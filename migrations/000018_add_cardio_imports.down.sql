@@ -0,0 +1,5 @@
+ALTER TABLE workouts.cardio_entries
+    DROP COLUMN IF EXISTS heart_rates,
+    DROP COLUMN IF EXISTS max_heart_rate,
+    DROP COLUMN IF EXISTS elevation_gain,
+    DROP COLUMN IF EXISTS source;
@@ -0,0 +1,7 @@
+-- Activities imported from GPX, TCX and FIT files keep where they came
+-- from, the climb and the heart rate series.
+ALTER TABLE workouts.cardio_entries
+    ADD COLUMN IF NOT EXISTS source VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS elevation_gain DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_heart_rate INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS heart_rates JSONB;
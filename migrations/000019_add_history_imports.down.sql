@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS workouts.import_aliases;
+
+DROP INDEX IF EXISTS workouts.idx_workout_sessions_import_key;
+
+ALTER TABLE workouts.workout_sessions
+    DROP COLUMN IF EXISTS import_key;
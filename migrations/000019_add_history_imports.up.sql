@@ -0,0 +1,20 @@
+-- Workouts imported from other apps keep a key identifying them in the
+-- export, so importing the same file again skips them.
+ALTER TABLE workouts.workout_sessions
+    ADD COLUMN IF NOT EXISTS import_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workout_sessions_import_key
+    ON workouts.workout_sessions(user_id, import_key)
+    WHERE import_key IS NOT NULL;
+
+-- How the user matched exercise names from other apps to the catalog. A
+-- NULL exercise leaves the name out of imports.
+CREATE TABLE IF NOT EXISTS workouts.import_aliases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    exercise_id UUID REFERENCES workouts.exercises(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (user_id, name)
+);
@@ -0,0 +1,12 @@
+-- Accounts deleted at the user's request. The Telegram ID is kept only as
+-- a hash, so a deletion can be confirmed later without keeping who it was.
+CREATE TABLE IF NOT EXISTS workouts.account_deletions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subject VARCHAR(64) NOT NULL,
+    deleted JSONB NOT NULL DEFAULT '{}',
+    photos INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_deletions_subject
+    ON workouts.account_deletions (subject);
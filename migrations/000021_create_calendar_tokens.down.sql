@@ -0,0 +1 @@
+DROP TABLE IF EXISTS workouts.calendar_tokens;
@@ -0,0 +1,9 @@
+-- The secret of the user's calendar feed URL. Revoking deletes the row, and
+-- a new link replaces the token.
+CREATE TABLE IF NOT EXISTS workouts.calendar_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL UNIQUE REFERENCES workouts.users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
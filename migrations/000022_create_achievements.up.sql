@@ -0,0 +1,8 @@
+-- Achievements the user has earned, by the slug of the rule in code.
+CREATE TABLE IF NOT EXISTS workouts.achievements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    slug VARCHAR(64) NOT NULL,
+    earned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (user_id, slug)
+);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS workouts.challenge_participants;
+DROP TABLE IF EXISTS workouts.challenges;
+DROP TABLE IF EXISTS workouts.group_members;
+DROP TABLE IF EXISTS workouts.groups;
+
+ALTER TABLE workouts.users
+    DROP COLUMN IF EXISTS group_privacy;
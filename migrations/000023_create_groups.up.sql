@@ -0,0 +1,45 @@
+-- How the user appears in group leaderboards and challenge posts.
+ALTER TABLE workouts.users
+    ADD COLUMN IF NOT EXISTS group_privacy VARCHAR(16) NOT NULL DEFAULT 'public';
+
+-- Group chats the bot was added to; language is the one posts are in.
+CREATE TABLE IF NOT EXISTS workouts.groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id BIGINT NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    language VARCHAR(8) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Users who opted in to a group's leaderboards and challenges.
+CREATE TABLE IF NOT EXISTS workouts.group_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    group_id UUID NOT NULL REFERENCES workouts.groups(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (group_id, user_id)
+);
+
+-- Time-boxed group challenges. kind is the challenge in code; posted_on
+-- is the last day whose progress was posted.
+CREATE TABLE IF NOT EXISTS workouts.challenges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    group_id UUID NOT NULL REFERENCES workouts.groups(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    posted_on DATE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenges_group_id
+    ON workouts.challenges (group_id, ends_on);
+
+CREATE TABLE IF NOT EXISTS workouts.challenge_participants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    challenge_id UUID NOT NULL REFERENCES workouts.challenges(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (challenge_id, user_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS workouts.session_comments;
+DROP TABLE IF EXISTS workouts.coach_clients;
+DROP TABLE IF EXISTS workouts.coach_invites;
@@ -0,0 +1,32 @@
+-- Invite link of a coach: whoever opens it is asked to become a client.
+CREATE TABLE IF NOT EXISTS workouts.coach_invites (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    coach_id UUID NOT NULL UNIQUE REFERENCES workouts.users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A client shares their training with one coach. checked_on is the last
+-- day checked for missed program workouts.
+CREATE TABLE IF NOT EXISTS workouts.coach_clients (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    coach_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    client_id UUID NOT NULL UNIQUE REFERENCES workouts.users(id) ON DELETE CASCADE,
+    checked_on DATE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_coach_clients_coach_id
+    ON workouts.coach_clients (coach_id);
+
+-- Comments coaches leave on their clients' sessions.
+CREATE TABLE IF NOT EXISTS workouts.session_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES workouts.workout_sessions(id) ON DELETE CASCADE,
+    coach_id UUID NOT NULL REFERENCES workouts.users(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_comments_session_id
+    ON workouts.session_comments (session_id, created_at);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS workouts.idx_session_sets_number;
@@ -0,0 +1,29 @@
+-- Two presses of the same set button could log the set twice. Keep the
+-- first of each duplicate, moving drop sets over to it, so that a set
+-- number is logged at most once per exercise.
+WITH ranked AS (
+    SELECT id, first_value(id) OVER (
+        PARTITION BY session_exercise_id, number ORDER BY created_at, id
+    ) AS kept
+    FROM workouts.session_sets
+    WHERE parent_id IS NULL
+)
+UPDATE workouts.session_sets AS s
+SET parent_id = ranked.kept
+FROM ranked
+WHERE s.parent_id = ranked.id AND ranked.id <> ranked.kept;
+
+WITH ranked AS (
+    SELECT id, first_value(id) OVER (
+        PARTITION BY session_exercise_id, number ORDER BY created_at, id
+    ) AS kept
+    FROM workouts.session_sets
+    WHERE parent_id IS NULL
+)
+DELETE FROM workouts.session_sets AS s
+USING ranked
+WHERE s.id = ranked.id AND ranked.id <> ranked.kept;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_session_sets_number
+    ON workouts.session_sets (session_exercise_id, number)
+    WHERE parent_id IS NULL;
@@ -0,0 +1,7 @@
+-- The tokens cannot be recovered from their hashes, so the links are
+-- revoked.
+DELETE FROM workouts.calendar_tokens;
+
+ALTER TABLE workouts.calendar_tokens DROP CONSTRAINT IF EXISTS calendar_tokens_token_hash_key;
+ALTER TABLE workouts.calendar_tokens RENAME COLUMN token_hash TO token;
+ALTER TABLE workouts.calendar_tokens ADD CONSTRAINT calendar_tokens_token_key UNIQUE (token);
@@ -0,0 +1,11 @@
+-- Keep only a SHA-256 of each calendar token, so that the database alone
+-- does not open anyone's feed. Existing links keep working.
+ALTER TABLE workouts.calendar_tokens ADD COLUMN IF NOT EXISTS token_hash VARCHAR(64);
+
+UPDATE workouts.calendar_tokens
+SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex');
+
+ALTER TABLE workouts.calendar_tokens ALTER COLUMN token_hash SET NOT NULL;
+ALTER TABLE workouts.calendar_tokens DROP COLUMN token;
+ALTER TABLE workouts.calendar_tokens
+    ADD CONSTRAINT calendar_tokens_token_hash_key UNIQUE (token_hash);
@@ -2,63 +2,282 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"strings"
+	"sync"
+	"time"
+	"workouts_bot/src/bot/callbackdata"
 	"workouts_bot/src/bot/handlers"
 	"workouts_bot/src/bot/handlers/callbacks"
 	"workouts_bot/src/bot/handlers/messages"
 	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
 	"workouts_bot/src/config"
+	"workouts_bot/src/i18n"
 	"workouts_bot/src/logger"
+	"workouts_bot/src/services/broadcast"
+	"workouts_bot/src/services/confirmation"
+	"workouts_bot/src/services/media"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+const (
+	dialogStateTTL = 30 * time.Minute
+	// webhookStopTimeout bounds how long the webhook server may take to
+	// finish the requests it already accepted.
+	webhookStopTimeout = 5 * time.Second
+)
+
 type Bot struct {
 	api              *tgbotapi.BotAPI
+	sender           *sender.Telegram
+	database         *gorm.DB
+	states           *state.Store
+	broadcasts       *broadcast.Service
+	media            *media.Service
+	photoRetention   time.Duration
 	messageHandlers  map[string]handlers.Handler
-	callbackHandlers map[string]handlers.Handler
+	importHandler    handlers.Handler
+	groupHandler     *messages.GroupHandler
+	coachHandler     *messages.CoachHandler
+	stateHandlers    map[string]handlers.Handler
+	callbackHandlers map[string]handlers.CallbackHandler
+	codec            *callbackdata.Codec
 	webhookConfig    *config.WebhookConfig
+	server           *http.Server
+
+	inFlight  sync.WaitGroup
+	runners   sync.WaitGroup
+	activeMu  sync.Mutex
+	active    map[int]inFlightUpdate
+	receiving bool
+}
+
+type inFlightUpdate struct {
+	userID    int64
+	chatID    int64
+	kind      string
+	startedAt time.Time
 }
 
-func New(botToken string, database *gorm.DB, webhookCfg *config.WebhookConfig) (*Bot, error) {
-	bot, err := tgbotapi.NewBotAPI(botToken)
+func New(cfg *config.Config, database *gorm.DB, storage media.Storage) (*Bot, error) {
+	bot, err := tgbotapi.NewBotAPI(cfg.BotToken)
 	if err != nil {
 		logger.Error("Failed to create bot API:", err)
 		return nil, err
 	}
 
 	logger.Info("Bot API created successfully")
+	telegram := sender.NewTelegram(bot)
+	states := state.NewStore(dialogStateTTL)
+	codec := callbackdata.NewCodec(callbackdata.NewDatabaseStore(database))
+	broadcasts := broadcast.NewService(telegram, database)
+	mediaService := media.NewService(telegram, database, storage, cfg.BotToken)
+	confirmations := confirmation.NewService(database)
+	confirmations.Register(
+		messages.BroadcastAction,
+		callbacks.NewBroadcastConfirmation(database, broadcasts).Action(),
+	)
+	confirmations.Register(
+		messages.DeleteWorkoutAction,
+		callbacks.NewWorkoutDeleteConfirmation(database).Action(),
+	)
+	confirmations.Register(
+		messages.ResetStatsAction,
+		callbacks.NewStatsResetConfirmation(database).Action(),
+	)
+	confirmations.Register(
+		messages.LeaveProgramAction,
+		callbacks.NewProgramLeaveConfirmation(database).Action(),
+	)
+	confirmations.Register(
+		messages.CoachInviteAction,
+		callbacks.NewCoachInviteConfirmation(telegram, database, cfg, codec).Action(),
+	)
+	confirmations.Register(
+		messages.DeleteAccountAction,
+		callbacks.NewAccountDeleteConfirmation(database, cfg, mediaService, states).Action(),
+	)
+
+	broadcastHandler := messages.NewBroadcastHandler(
+		telegram, database, cfg, states, confirmations,
+	)
+
+	workoutsHandler := messages.NewWorkoutsHandler(
+		telegram, database, states, codec,
+	)
+
+	progressHandler := messages.NewProgressHandler(
+		telegram, database, states, mediaService,
+	)
+
+	measurementsHandler := messages.NewMeasurementsHandler(telegram, database)
+
+	programsHandler := messages.NewProgramsHandler(telegram, database, states)
+
+	cardioHandler := messages.NewCardioHandler(telegram, database, states, codec)
+
+	importHandler := messages.NewImportHandler(
+		telegram, database, states, mediaService, codec,
+	)
+
+	groupHandler := messages.NewGroupHandler(telegram, database, bot.Self, codec)
+
+	coachHandler := messages.NewCoachHandler(
+		telegram, database, cfg, states, confirmations, bot.Self.UserName, codec,
+	)
+
 	messageHandlers := map[string]handlers.Handler{
 		keyboards.StartMessage: messages.NewStartHandler(
-			bot, database,
+			telegram, database, coachHandler,
 		),
 		keyboards.SettingsMessage: messages.NewSettingsHandler(
-			bot, database,
+			telegram, database,
+		),
+		keyboards.BroadcastMessage: broadcastHandler,
+		keyboards.WorkoutsMessage:  workoutsHandler,
+		keyboards.ExercisesMessage: messages.NewExercisesHandler(
+			telegram, database,
+		),
+		keyboards.ProgressMessage:     progressHandler,
+		keyboards.MeasurementsMessage: measurementsHandler,
+		keyboards.ProgramsMessage:     programsHandler,
+		keyboards.OneRepMaxMessage: messages.NewOneRepMaxHandler(
+			telegram, database,
+		),
+		keyboards.CardioMessage: cardioHandler,
+		keyboards.ImportMessage: importHandler,
+		keyboards.StatsMessage: messages.NewStatsHandler(
+			telegram, database,
+		),
+		keyboards.ExportMessage: messages.NewExportHandler(
+			telegram, database,
+		),
+		keyboards.DeleteAccountMessage: messages.NewDeleteAccountHandler(
+			telegram, database, confirmations,
+		),
+		keyboards.ProfileMessage: messages.NewProfileHandler(
+			telegram, database,
+		),
+		keyboards.CoachMessage: coachHandler,
+	}
+	for command := range keyboards.MeasurementCommands {
+		messageHandlers[command] = measurementsHandler
+	}
+
+	stateHandlers := map[string]handlers.Handler{
+		messages.ExerciseMediaState: messages.NewExerciseMediaHandler(
+			telegram, database, cfg, states, mediaService,
+		),
+		messages.BroadcastTextState:   broadcastHandler,
+		messages.WorkoutNameState:     workoutsHandler,
+		messages.WorkoutRenameState:   workoutsHandler,
+		messages.WorkoutExerciseState: workoutsHandler,
+		messages.WorkoutTargetsState:  workoutsHandler,
+		messages.ProgressPhotoState:   progressHandler,
+		messages.ProgramMaxState:      programsHandler,
+		messages.CardioState:          cardioHandler,
+		messages.ImportAliasState:     importHandler,
+		messages.CoachCommentState:    coachHandler,
+		messages.PlatesState: messages.NewPlatesHandler(
+			telegram, database, states,
+		),
+		messages.SessionSetState: messages.NewSessionSetHandler(
+			telegram, database, states, codec,
 		),
 	}
 
-	callbackHandlers := map[string]handlers.Handler{
-		callbacks.SettingsCallbackType: callbacks.NewSettingsHandler(
-			bot, database,
+	callbackHandlers := map[string]handlers.CallbackHandler{
+		callbackdata.TypeSettings: callbacks.NewSettingsHandler(
+			telegram, database,
+		),
+		callbackdata.TypeExperience: callbacks.NewExperienceHandler(
+			telegram, database,
+		),
+		callbackdata.TypeConfirm: callbacks.NewConfirmHandler(
+			telegram, database, confirmations,
+		),
+		callbackdata.TypeLanguage: callbacks.NewLanguageHandler(
+			telegram, database,
+		),
+		callbackdata.TypeWorkout: callbacks.NewWorkoutHandler(
+			telegram, database, states, confirmations, codec,
+		),
+		callbackdata.TypeGoal: callbacks.NewGoalHandler(
+			telegram, database,
+		),
+		callbackdata.TypeExercise: callbacks.NewExerciseHandler(
+			telegram, database, cfg, states, codec, mediaService,
+		),
+		callbackdata.TypeSession: callbacks.NewSessionHandler(
+			telegram, database, states, cfg, codec,
+		),
+		callbackdata.TypeProgress: callbacks.NewProgressHandler(
+			telegram, database, states, mediaService,
+		),
+		callbackdata.TypeMeasurement: callbacks.NewMeasurementHandler(
+			telegram, database,
+		),
+		callbackdata.TypeProgression: callbacks.NewProgressionHandler(
+			telegram, database,
+		),
+		callbackdata.TypeProgram: callbacks.NewProgramHandler(
+			telegram, database, states, confirmations, codec,
+		),
+		callbackdata.TypeEquipment: callbacks.NewEquipmentHandler(
+			telegram, database, states,
+		),
+		callbackdata.TypeCardio: callbacks.NewCardioHandler(
+			telegram, database, states,
+		),
+		callbackdata.TypeImport: callbacks.NewImportHandler(
+			telegram, database, states, importHandler,
 		),
-		callbacks.ExperienceCallbackType: callbacks.NewExperienceHandler(
-			bot, database,
+		callbackdata.TypeExport: callbacks.NewExportHandler(
+			telegram, database, mediaService,
+		),
+		callbackdata.TypeCalendar: callbacks.NewCalendarHandler(
+			telegram, database, cfg,
+		),
+		callbackdata.TypeGroup: callbacks.NewGroupHandler(
+			telegram, database, codec,
+		),
+		callbackdata.TypeCoach: callbacks.NewCoachHandler(
+			telegram, database, cfg, states, codec, bot.Self.UserName,
 		),
 	}
 
 	return &Bot{
 		api:              bot,
+		sender:           telegram,
+		database:         database,
+		states:           states,
+		broadcasts:       broadcasts,
+		media:            mediaService,
+		photoRetention:   time.Duration(cfg.PhotoRetentionDays) * 24 * time.Hour,
 		messageHandlers:  messageHandlers,
+		importHandler:    importHandler,
+		groupHandler:     groupHandler,
+		coachHandler:     coachHandler,
+		stateHandlers:    stateHandlers,
 		callbackHandlers: callbackHandlers,
-		webhookConfig:    webhookCfg,
+		codec:            codec,
+		webhookConfig:    &cfg.Webhook,
+		active:           make(map[int]inFlightUpdate),
 	}, nil
 }
 
 func (bot *Bot) Start(botContext context.Context) error {
+	bot.broadcasts.Resume()
+	bot.run(func() { bot.media.RunRetention(botContext, bot.photoRetention) })
+	bot.run(func() { bot.groupHandler.RunChallengePosts(botContext) })
+	bot.run(func() { bot.coachHandler.RunMissedWorkouts(botContext) })
+
 	if bot.webhookConfig != nil && bot.webhookConfig.Enabled {
 		return bot.startWebhook(botContext)
 	}
@@ -74,13 +293,21 @@ func (bot *Bot) startPolling(botContext context.Context) error {
 	botUpdate.Timeout = 60
 
 	updates := bot.api.GetUpdatesChan(botUpdate)
+	bot.setReceiving(true)
 	for {
 		select {
 		case <-botContext.Done():
 			logger.Info("Stopping bot...")
+			bot.api.StopReceivingUpdates()
+			bot.drain(updates)
+			bot.setReceiving(false)
 			return nil
-		case update := <-updates:
-			go bot.handleUpdate(update)
+		case update, ok := <-updates:
+			if !ok {
+				bot.setReceiving(false)
+				return nil
+			}
+			bot.dispatch(update)
 		}
 	}
 }
@@ -110,6 +337,7 @@ func (bot *Bot) startWebhook(botContext context.Context) error {
 	server := &http.Server{
 		Addr: fmt.Sprintf(":%d", bot.webhookConfig.Port),
 	}
+	bot.server = server
 
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -128,21 +356,188 @@ func (bot *Bot) startWebhook(botContext context.Context) error {
 		}
 	}()
 
+	bot.setReceiving(true)
 	for {
 		select {
 		case <-botContext.Done():
 			logger.Info("Stopping webhook bot...")
-
 			_, _ = bot.api.Request(tgbotapi.DeleteWebhookConfig{})
-
-			if err := server.Shutdown(botContext); err != nil {
-				logger.Warn("Error shutting down HTTP server:", err)
-			}
+			bot.stopServer(updates)
+			bot.drain(updates)
+			bot.setReceiving(false)
 			return nil
 		case update := <-updates:
-			go bot.handleUpdate(update)
+			bot.dispatch(update)
+		}
+	}
+}
+
+// stopServer closes the webhook server while still dispatching, so that
+// requests blocked on a full updates channel are answered and no update
+// is acknowledged to Telegram after the bot stopped reading.
+func (bot *Bot) stopServer(updates tgbotapi.UpdatesChannel) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookStopTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if err := bot.server.Shutdown(ctx); err != nil {
+			logger.Warn("Error shutting down HTTP server:", err)
+		}
+	}()
+
+	for {
+		select {
+		case <-stopped:
+			return
+		case update := <-updates:
+			bot.dispatch(update)
+		}
+	}
+}
+
+// drain dispatches the updates already buffered when receiving stopped.
+// Telegram treats them as delivered, so dropping them would lose them.
+func (bot *Bot) drain(updates tgbotapi.UpdatesChannel) {
+	drained := 0
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			drained++
+			logger.WithField("update_id", update.UpdateID).Info("Dispatching update buffered before shutdown")
+			bot.dispatch(update)
+		default:
+			if drained > 0 {
+				logger.WithField("count", drained).Info("Buffered updates dispatched")
+			}
+			return
+		}
+	}
+}
+
+// run starts a background runner that Shutdown waits for. Runners stop
+// when the context passed to Start is done.
+func (bot *Bot) run(runner func()) {
+	bot.runners.Add(1)
+	go func() {
+		defer bot.runners.Done()
+		runner()
+	}()
+}
+
+// Shutdown waits for in-flight updates to finish, then for the background
+// runners and broadcasts, so that the database can be closed afterwards.
+// Updates still running when ctx expires are logged and reported in the
+// returned error.
+func (bot *Bot) Shutdown(ctx context.Context) error {
+	if !wait(ctx, &bot.inFlight) {
+		return bot.abandon(ctx)
+	}
+	logger.Info("All in-flight updates completed")
+
+	// Broadcasts stop once the sender is closed.
+	senderErr := bot.closeSender(ctx)
+	if !wait(ctx, &bot.runners) || !bot.broadcasts.Wait(ctx) {
+		logger.Warn("Abandoning background runners")
+		return fmt.Errorf("background runners still running: %w", ctx.Err())
+	}
+	return senderErr
+}
+
+// wait reports whether the group finished before ctx expired.
+func wait(ctx context.Context, group *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// abandon logs the updates still running when ctx expired.
+func (bot *Bot) abandon(ctx context.Context) error {
+	_ = bot.closeSender(ctx)
+
+	bot.activeMu.Lock()
+	defer bot.activeMu.Unlock()
+
+	for updateID, update := range bot.active {
+		logger.WithFields(logrus.Fields{
+			"update_id": updateID,
+			"user_id":   update.userID,
+			"chat_id":   update.chatID,
+			"kind":      update.kind,
+			"running":   time.Since(update.startedAt).String(),
+		}).Warn("Abandoning in-flight update")
+	}
+
+	return fmt.Errorf("abandoned %d in-flight updates: %w", len(bot.active), ctx.Err())
+}
+
+func (bot *Bot) closeSender(ctx context.Context) error {
+	abandoned, err := bot.sender.Close(ctx)
+	if err != nil {
+		logger.WithField("pending", abandoned).Warn("Abandoning pending outgoing messages")
+		return fmt.Errorf("abandoned %d outgoing messages: %w", abandoned, err)
+	}
+	return nil
+}
+
+func (bot *Bot) setReceiving(receiving bool) {
+	bot.activeMu.Lock()
+	defer bot.activeMu.Unlock()
+	bot.receiving = receiving
+}
+
+// dispatch runs the update in its own goroutine and tracks it until the
+// handler returns. Updates arriving after the bot stopped receiving are
+// dropped so that Shutdown never waits on work started after it.
+func (bot *Bot) dispatch(update tgbotapi.Update) {
+	bot.activeMu.Lock()
+	if !bot.receiving {
+		bot.activeMu.Unlock()
+		logger.WithField("update_id", update.UpdateID).Warn("Dropping update received during shutdown")
+		return
+	}
+
+	tracked := inFlightUpdate{startedAt: time.Now()}
+	if update.Message != nil {
+		tracked.kind = "message"
+		tracked.chatID = update.Message.Chat.ID
+		if update.Message.From != nil {
+			tracked.userID = update.Message.From.ID
+		}
+	} else if update.CallbackQuery != nil {
+		tracked.kind = "callback"
+		tracked.userID = update.CallbackQuery.From.ID
+		if update.CallbackQuery.Message != nil {
+			tracked.chatID = update.CallbackQuery.Message.Chat.ID
 		}
 	}
+
+	bot.active[update.UpdateID] = tracked
+	bot.inFlight.Add(1)
+	bot.activeMu.Unlock()
+
+	go func() {
+		defer func() {
+			bot.activeMu.Lock()
+			delete(bot.active, update.UpdateID)
+			bot.activeMu.Unlock()
+			bot.inFlight.Done()
+		}()
+		bot.handleUpdate(update)
+	}()
 }
 
 func (bot *Bot) handleUpdate(update tgbotapi.Update) {
@@ -162,10 +557,42 @@ func (bot *Bot) handleMessage(update tgbotapi.Update) {
 		"message": message.Text,
 	}).Info("Message:")
 
-	handler, ok := bot.messageHandlers[message.Text]
+	// Group chats have their own commands and never get the private menus.
+	if !message.Chat.IsPrivate() {
+		if err := bot.groupHandler.Handle(update); err != nil {
+			logger.WithFields(logrus.Fields{
+				"user_id": message.From.ID,
+				"chat_id": message.Chat.ID,
+				"message": message.Text,
+				"error":   err,
+			}).Error("Failed to handle group message")
+		}
+		return
+	}
+
+	// Reply keyboard labels are translated; handlers are keyed by catalog key.
+	// Commands are keyed by name so they may carry arguments.
+	key := message.Text
+	if message.IsCommand() {
+		key = "/" + message.Command()
+	} else if matched, found := i18n.Match(message.Text); found {
+		key = matched
+	}
+
+	handler, ok := bot.messageHandlers[key]
+	if ok {
+		// A menu button or command abandons any dialog in progress.
+		bot.states.Clear(message.From.ID)
+	} else if current, found := bot.states.Get(message.From.ID); found {
+		handler, ok = bot.stateHandlers[current.Name]
+	} else if messages.IsImportFile(message) {
+		// Workout files are imported whenever no dialog asks for a file.
+		handler, ok = bot.importHandler, true
+	}
 	if !ok {
-		msg := tgbotapi.NewMessage(message.Chat.ID, "Invalid command")
-		_, _ = bot.api.Send(msg)
+		locale := handlers.Locale(message.From, bot.database)
+		msg := tgbotapi.NewMessage(message.Chat.ID, i18n.T(locale, "error.unknown_command"))
+		_, _ = bot.sender.Send(msg)
 		return
 	}
 
@@ -182,33 +609,44 @@ func (bot *Bot) handleMessage(update tgbotapi.Update) {
 func (bot *Bot) handleCallbackQuery(update tgbotapi.Update) {
 	callbackQuery := update.CallbackQuery
 
-	callback := tgbotapi.NewCallback(callbackQuery.ID, "")
-	_, _ = bot.api.Request(callback)
-
 	logger.WithFields(logrus.Fields{
 		"user_id": callbackQuery.From.ID,
 		"chat_id": callbackQuery.Message.Chat.ID,
 		"data":    callbackQuery.Data,
 	}).Info("Callback query:")
 
-	data := callbackQuery.Data
-	parts := strings.Split(data, ":")
+	locale := handlers.Locale(callbackQuery.From, bot.database)
 
-	if len(parts) < 1 {
-		msg := tgbotapi.NewMessage(callbackQuery.Message.Chat.ID, "❌ Неверный формат команды")
-		_, _ = bot.api.Send(msg)
+	data, err := bot.codec.Decode(callbackQuery.Data)
+	if errors.Is(err, callbackdata.ErrOutdated) || err == nil && !data.Supported() {
+		logger.WithFields(logrus.Fields{
+			"user_id": callbackQuery.From.ID,
+			"data":    callbackQuery.Data,
+		}).Warn("Outdated callback data")
+		bot.expireKeyboard(locale, callbackQuery)
+		return
+	}
+
+	callback := tgbotapi.NewCallback(callbackQuery.ID, "")
+	_, _ = bot.sender.Request(callback)
+
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": callbackQuery.From.ID,
+			"data":    callbackQuery.Data,
+			"error":   err,
+		}).Warn("Failed to decode callback data")
+		handlers.SendErrorMessage(bot.sender, callbackQuery.Message.Chat.ID, i18n.T(locale, "error.invalid_format"))
 		return
 	}
 
-	handlerType := parts[0]
-	handler, ok := bot.callbackHandlers[handlerType]
+	handler, ok := bot.callbackHandlers[data.Type]
 	if !ok {
-		msg := tgbotapi.NewMessage(callbackQuery.Message.Chat.ID, "❌ Неизвестная команда")
-		_, _ = bot.api.Send(msg)
+		handlers.SendErrorMessage(bot.sender, callbackQuery.Message.Chat.ID, i18n.T(locale, "error.unknown_command"))
 		return
 	}
 
-	if err := handler.Handle(update); err != nil {
+	if err := handler.HandleCallback(update, data); err != nil {
 		logger.WithFields(logrus.Fields{
 			"user_id": callbackQuery.From.ID,
 			"chat_id": callbackQuery.Message.Chat.ID,
@@ -217,3 +655,20 @@ func (bot *Bot) handleCallbackQuery(update tgbotapi.Update) {
 		}).Error("Failed to handle callback query")
 	}
 }
+
+// expireKeyboard tells the user that the pressed button belongs to an old
+// keyboard and removes the buttons from that message.
+func (bot *Bot) expireKeyboard(locale string, callbackQuery *tgbotapi.CallbackQuery) {
+	callback := tgbotapi.NewCallbackWithAlert(
+		callbackQuery.ID,
+		i18n.T(locale, "callback.outdated"),
+	)
+	_, _ = bot.sender.Request(callback)
+
+	removeMarkup := tgbotapi.NewEditMessageReplyMarkup(
+		callbackQuery.Message.Chat.ID,
+		callbackQuery.Message.MessageID,
+		tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}},
+	)
+	_, _ = bot.sender.Request(removeMarkup)
+}
@@ -0,0 +1,223 @@
+package callbackdata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is written into every encoded payload. Bump it when the meaning
+// of arguments changes so handlers can tell old keyboards apart.
+const Version = 1
+
+// MaxSize is the Telegram limit for callback_data, in bytes.
+const MaxSize = 64
+
+const (
+	separator    = ":"
+	storedPrefix = "~"
+)
+
+const (
+	TypeSettings    = "settings"
+	TypeExperience  = "experience"
+	TypeConfirm     = "confirm"
+	TypeLanguage    = "language"
+	TypeWorkout     = "workout"
+	TypeGoal        = "goal"
+	TypeExercise    = "exercise"
+	TypeSession     = "session"
+	TypeProgress    = "progress"
+	TypeMeasurement = "measurement"
+	TypeProgression = "progression"
+	TypeProgram     = "program"
+	TypeEquipment   = "equipment"
+	TypeCardio      = "cardio"
+	TypeImport      = "import"
+	TypeExport      = "export"
+	TypeCalendar    = "calendar"
+	TypeGroup       = "group"
+	TypeCoach       = "coach"
+)
+
+var (
+	ErrInvalid   = errors.New("invalid callback data")
+	ErrTooLarge  = errors.New("callback data does not fit inline")
+	ErrSeparator = errors.New("callback data contains the separator")
+	ErrOutdated  = errors.New("callback data is outdated")
+)
+
+// unversioned lists the types whose handlers still understand payloads
+// sent before versioning, which decode as version 0.
+var unversioned = map[string]bool{
+	TypeSettings:   true,
+	TypeExperience: true,
+	TypeConfirm:    true,
+}
+
+// Data is a decoded callback payload: the handler type, the action inside
+// the handler and positional arguments.
+type Data struct {
+	Version int      `json:"v"`
+	Type    string   `json:"t"`
+	Action  string   `json:"a"`
+	Args    []string `json:"p,omitempty"`
+}
+
+func New(callbackType string, action string, args ...string) Data {
+	return Data{
+		Version: Version,
+		Type:    callbackType,
+		Action:  action,
+		Args:    args,
+	}
+}
+
+// Supported reports whether the handler of the payload's type understands
+// its version. Other payloads come from old keyboards and are outdated.
+func (d Data) Supported() bool {
+	return d.Version == Version || d.Version == 0 && unversioned[d.Type]
+}
+
+func (d Data) Arg(index int) string {
+	if index < 0 || index >= len(d.Args) {
+		return ""
+	}
+	return d.Args[index]
+}
+
+func (d Data) IntArg(index int) (int, error) {
+	if index < 0 || index >= len(d.Args) {
+		return 0, fmt.Errorf("%w: missing argument %d", ErrInvalid, index)
+	}
+	return strconv.Atoi(d.Args[index])
+}
+
+// String encodes the payload inline without checking its size. It is meant
+// for static payloads whose arguments come from a fixed set, such as
+// program slugs or page numbers. Payloads carrying IDs or user input go
+// through Codec.Encode.
+func (d Data) String() string {
+	parts := make([]string, 0, len(d.Args)+3)
+	parts = append(parts, strconv.Itoa(d.Version), d.Type, d.Action)
+	parts = append(parts, d.Args...)
+	return strings.Join(parts, separator)
+}
+
+// Encode returns the inline form of the payload. It returns ErrTooLarge
+// when the payload exceeds MaxSize and ErrSeparator when an argument
+// contains the separator; Codec.Encode stores both kinds on the server.
+func Encode(d Data) (string, error) {
+	if strings.HasPrefix(d.Type, storedPrefix) {
+		return "", fmt.Errorf("%w: reserved type %q", ErrInvalid, d.Type)
+	}
+	for _, value := range append([]string{d.Type, d.Action}, d.Args...) {
+		if strings.Contains(value, separator) {
+			return "", fmt.Errorf("%w: %q contains %q", ErrSeparator, value, separator)
+		}
+	}
+
+	encoded := d.String()
+	if len(encoded) > MaxSize {
+		return "", fmt.Errorf("%w: %d bytes", ErrTooLarge, len(encoded))
+	}
+	return encoded, nil
+}
+
+// Decode parses an inline payload. Payloads without a version prefix were
+// produced before the codec existed and decode as version 0.
+func Decode(raw string) (Data, error) {
+	parts := strings.Split(raw, separator)
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		if len(parts) < 2 || parts[0] == "" {
+			return Data{}, fmt.Errorf("%w: %q", ErrInvalid, raw)
+		}
+		return Data{
+			Version: 0,
+			Type:    parts[0],
+			Action:  parts[1],
+			Args:    parts[2:],
+		}, nil
+	}
+
+	if version > Version {
+		return Data{}, fmt.Errorf("%w: version %d", ErrOutdated, version)
+	}
+	if len(parts) < 3 || parts[1] == "" {
+		return Data{}, fmt.Errorf("%w: %q", ErrInvalid, raw)
+	}
+
+	return Data{
+		Version: version,
+		Type:    parts[1],
+		Action:  parts[2],
+		Args:    parts[3:],
+	}, nil
+}
+
+// Store keeps payloads that do not fit into callback_data on the server.
+type Store interface {
+	Save(payload string) (string, error)
+	Load(key string) (string, error)
+}
+
+// Codec encodes payloads inline when possible and falls back to the store
+// for larger ones, leaving only a short key in the keyboard.
+type Codec struct {
+	store Store
+}
+
+func NewCodec(store Store) *Codec {
+	return &Codec{store: store}
+}
+
+func (c *Codec) Encode(d Data) (string, error) {
+	encoded, err := Encode(d)
+	if err == nil {
+		return encoded, nil
+	}
+	if !errors.Is(err, ErrTooLarge) && !errors.Is(err, ErrSeparator) {
+		return "", err
+	}
+
+	payload, err := json.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := c.store.Save(string(payload))
+	if err != nil {
+		return "", err
+	}
+
+	stored := strconv.Itoa(Version) + separator + storedPrefix + key
+	if len(stored) > MaxSize {
+		return "", fmt.Errorf("%w: stored key is %d bytes", ErrTooLarge, len(stored))
+	}
+	return stored, nil
+}
+
+func (c *Codec) Decode(raw string) (Data, error) {
+	parts := strings.SplitN(raw, separator, 3)
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], storedPrefix) {
+		return Decode(raw)
+	}
+
+	payload, err := c.store.Load(strings.TrimPrefix(parts[1], storedPrefix))
+	if err != nil {
+		return Data{}, fmt.Errorf("%w: %v", ErrOutdated, err)
+	}
+
+	var d Data
+	if err := json.Unmarshal([]byte(payload), &d); err != nil {
+		return Data{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if d.Version > Version {
+		return Data{}, fmt.Errorf("%w: version %d", ErrOutdated, d.Version)
+	}
+	return d, nil
+}
@@ -0,0 +1,90 @@
+package callbackdata
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type memoryStore map[string]string
+
+func (s memoryStore) Save(payload string) (string, error) {
+	key := strconv.Itoa(len(s))
+	s[key] = payload
+	return key, nil
+}
+
+func (s memoryStore) Load(key string) (string, error) {
+	payload, ok := s[key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return payload, nil
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   Data
+		stored bool
+	}{
+		{"inline", New(TypeWorkout, "show", "42"), false},
+		{"separator", New(TypeImport, "alias", "10:30 run"), true},
+		{"too large", New(TypeCoach, "session", strings.Repeat("a", MaxSize)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memoryStore{}
+			codec := NewCodec(store)
+
+			raw, err := codec.Encode(tt.data)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if stored := len(store) > 0; stored != tt.stored {
+				t.Errorf("stored = %v, want %v", stored, tt.stored)
+			}
+
+			decoded, err := codec.Decode(raw)
+			if err != nil {
+				t.Fatalf("Decode(%q): %v", raw, err)
+			}
+			if !reflect.DeepEqual(decoded, tt.data) {
+				t.Errorf("Decode(%q) = %+v, want %+v", raw, decoded, tt.data)
+			}
+		})
+	}
+}
+
+func TestEncodeSeparator(t *testing.T) {
+	_, err := Encode(New(TypeImport, "alias", "a:b"))
+	if !errors.Is(err, ErrSeparator) {
+		t.Errorf("Encode error = %v, want ErrSeparator", err)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{"1:workout:show:42", true},
+		{"0:workout:show:42", false},
+		{"workout:show:42", false},
+		{"experience:2", true},
+		{"settings:language", true},
+	}
+
+	for _, tt := range tests {
+		data, err := Decode(tt.raw)
+		if err != nil {
+			t.Fatalf("Decode(%q): %v", tt.raw, err)
+		}
+		if got := data.Supported(); got != tt.want {
+			t.Errorf("Decode(%q).Supported() = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
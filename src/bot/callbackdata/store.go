@@ -0,0 +1,54 @@
+package callbackdata
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	storedPayloadTTL = 30 * 24 * time.Hour
+	keyBytes         = 9
+)
+
+// DatabaseStore keeps large callback payloads in the database. Keys expire
+// after a while; buttons referring to them then decode as outdated.
+type DatabaseStore struct {
+	database *gorm.DB
+}
+
+func NewDatabaseStore(database *gorm.DB) *DatabaseStore {
+	return &DatabaseStore{database: database}
+}
+
+func (s *DatabaseStore) Save(payload string) (string, error) {
+	buf := make([]byte, keyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	key := base64.RawURLEncoding.EncodeToString(buf)
+
+	_ = database.DeleteExpiredCallbackPayloads(s.database)
+
+	record := &models.CallbackPayload{
+		Key:       key,
+		Payload:   payload,
+		ExpiresAt: time.Now().Add(storedPayloadTTL),
+	}
+	if err := database.CreateCallbackPayload(record, s.database); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (s *DatabaseStore) Load(key string) (string, error) {
+	record, err := database.GetCallbackPayload(key, s.database)
+	if err != nil {
+		return "", err
+	}
+	return record.Payload, nil
+}
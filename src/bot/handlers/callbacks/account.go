@@ -0,0 +1,98 @@
+package callbacks
+
+import (
+	"context"
+	"time"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/confirmation"
+	"workouts_bot/src/services/media"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const deleteTimeout = 5 * time.Minute
+
+// AccountDeleteConfirmation deletes the user's account once they confirm:
+// every row in one transaction, then the progress photos from storage, so a
+// failed transaction leaves the account whole. The payload is the user ID,
+// so an account made again with /start since is not touched.
+type AccountDeleteConfirmation struct {
+	database *gorm.DB
+	cfg      *config.Config
+	media    *media.Service
+	states   *state.Store
+}
+
+func NewAccountDeleteConfirmation(
+	database *gorm.DB,
+	cfg *config.Config,
+	media *media.Service,
+	states *state.Store,
+) *AccountDeleteConfirmation {
+	return &AccountDeleteConfirmation{
+		database: database,
+		cfg:      cfg,
+		media:    media,
+		states:   states,
+	}
+}
+
+func (c *AccountDeleteConfirmation) Action() confirmation.Action {
+	return confirmation.Action{Execute: c.execute}
+}
+
+func (c *AccountDeleteConfirmation) execute(
+	pending *models.PendingAction,
+	chatID int64,
+	locale string,
+) (string, error) {
+	userID, err := uuid.Parse(pending.Payload)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := database.GetUserByTelegramID(pending.TelegramID, c.database)
+	if err != nil {
+		return "", err
+	}
+	if user.ID != userID {
+		return i18n.T(locale, "confirm.outdated"), nil
+	}
+
+	photos, err := database.ListUserProgressPhotos(user.ID, c.database)
+	if err != nil {
+		return "", err
+	}
+
+	subject := models.DeletionSubject(c.cfg.DeletionSecret, user.TelegramID)
+	deletion, err := database.DeleteUser(user, subject, len(photos), c.database)
+	if err != nil {
+		return "", err
+	}
+	c.states.Clear(pending.TelegramID)
+
+	// The account is gone already, so objects left behind are only logged.
+	ctx, cancel := context.WithTimeout(context.Background(), deleteTimeout)
+	defer cancel()
+	if err := c.media.DeleteProgressPhotoObjects(ctx, user.ID, photos); err != nil {
+		logger.WithFields(logrus.Fields{
+			"deletion_id": deletion.ID,
+			"photos":      len(photos),
+			"error":       err,
+		}).Error("Failed to delete progress photos of a deleted account")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"deletion_id": deletion.ID,
+		"photos":      deletion.Photos,
+	}).Info("Account deleted")
+
+	return i18n.T(locale, "account.deleted"), nil
+}
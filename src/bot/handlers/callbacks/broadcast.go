@@ -0,0 +1,76 @@
+package callbacks
+
+import (
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/broadcast"
+	"workouts_bot/src/services/confirmation"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// BroadcastConfirmation starts or cancels a draft broadcast once the admin
+// answers the confirmation keyboard. The payload is the broadcast ID.
+type BroadcastConfirmation struct {
+	database   *gorm.DB
+	broadcasts *broadcast.Service
+}
+
+func NewBroadcastConfirmation(
+	database *gorm.DB,
+	broadcasts *broadcast.Service,
+) *BroadcastConfirmation {
+	return &BroadcastConfirmation{
+		database:   database,
+		broadcasts: broadcasts,
+	}
+}
+
+func (c *BroadcastConfirmation) Action() confirmation.Action {
+	return confirmation.Action{
+		Execute: c.execute,
+		Cancel:  c.cancel,
+	}
+}
+
+func (c *BroadcastConfirmation) execute(
+	pending *models.PendingAction,
+	chatID int64,
+	locale string,
+) (string, error) {
+	draft, err := c.draft(pending)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.broadcasts.Start(draft, chatID, locale); err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": draft.ID,
+			"error":        err,
+		}).Error("Failed to start broadcast")
+		return "", err
+	}
+	return i18n.N(locale, "broadcast.started", draft.Total), nil
+}
+
+func (c *BroadcastConfirmation) cancel(pending *models.PendingAction) error {
+	draft, err := c.draft(pending)
+	if err != nil {
+		return err
+	}
+
+	draft.Status = models.BroadcastStatusCancelled
+	return database.UpdateBroadcast(draft, c.database)
+}
+
+func (c *BroadcastConfirmation) draft(pending *models.PendingAction) (*models.Broadcast, error) {
+	broadcastID, err := uuid.Parse(pending.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return database.GetBroadcast(broadcastID, c.database)
+}
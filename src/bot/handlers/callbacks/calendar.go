@@ -0,0 +1,103 @@
+package callbacks
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/calendar"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CalendarHandler shows the link of the user's calendar feed in settings
+// and makes a new one or turns the feed off.
+type CalendarHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	cfg      *config.Config
+}
+
+func NewCalendarHandler(bot sender.Sender, database *gorm.DB, cfg *config.Config) *CalendarHandler {
+	return &CalendarHandler{
+		bot:      bot,
+		database: database,
+		cfg:      cfg,
+	}
+}
+
+func (h *CalendarHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	if h.cfg.PublicURL == "" {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.calendar_disabled"))
+		return nil
+	}
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	// Only the token's hash is stored, so the link is shown once, when it
+	// is made.
+	var token *models.CalendarToken
+	var secret string
+	switch data.Action {
+	case "menu":
+		token, err = database.FindCalendarToken(user.ID, h.database)
+	case "rotate":
+		secret, err = calendar.NewToken()
+		if err == nil {
+			token = &models.CalendarToken{UserID: user.ID, TokenHash: calendar.HashToken(secret)}
+			err = database.SaveCalendarToken(token, h.database)
+		}
+	case "revoke":
+		err = database.DeleteCalendarToken(user.ID, h.database)
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown calendar action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.calendar_update"))
+		return err
+	}
+
+	text := i18n.T(locale, "calendar.off")
+	switch {
+	case secret != "":
+		text = i18n.T(locale, "calendar.on", calendar.URL(h.cfg.PublicURL, secret))
+	case token != nil:
+		text = i18n.T(locale, "calendar.active")
+	}
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	keyboard := keyboards.CreateCalendarKeyboard(locale, token != nil)
+	editMsg.ReplyMarkup = &keyboard
+	editMsg.DisableWebPagePreview = true
+	_, err = h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send calendar menu")
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package callbacks
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CardioHandler starts logging an activity picked from /cardio.
+type CardioHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+}
+
+func NewCardioHandler(bot sender.Sender, database *gorm.DB, states *state.Store) *CardioHandler {
+	return &CardioHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+	}
+}
+
+func (h *CardioHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	id, err := uuid.Parse(data.Arg(0))
+	if data.Action != "log" || err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+			"args":    data.Args,
+		}).Error("Invalid cardio callback")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	exercise, err := database.GetExercise(id, h.database)
+	if err != nil || !exercise.Cardio() {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		return nil
+	}
+
+	h.states.Set(userID, messages.CardioState, map[string]string{
+		"exercise_id": exercise.ID.String(),
+	})
+	text := i18n.T(locale, "cardio.ask_entry", views.ExerciseName(locale, exercise))
+	_, err = h.bot.Send(tgbotapi.NewMessage(chatID, text))
+	return err
+}
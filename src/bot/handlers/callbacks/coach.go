@@ -0,0 +1,403 @@
+package callbacks
+
+import (
+	"time"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/achievements"
+	"workouts_bot/src/services/coaching"
+	"workouts_bot/src/services/confirmation"
+	"workouts_bot/src/services/programs"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// recentClientSessions is how many of a client's sessions the client card
+// opens.
+const recentClientSessions = 5
+
+// CoachHandler lets coaches browse their clients, open their sessions,
+// comment on them, assign programs and make invite links, and lets a
+// client stop sharing with their coach. Every client is looked up through
+// the coach's own links, so a coach never reaches another coach's client.
+type CoachHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	cfg      *config.Config
+	states   *state.Store
+	codec    *callbackdata.Codec
+	botName  string
+}
+
+func NewCoachHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	cfg *config.Config,
+	states *state.Store,
+	codec *callbackdata.Codec,
+	botName string,
+) *CoachHandler {
+	return &CoachHandler{
+		bot:      bot,
+		database: database,
+		cfg:      cfg,
+		states:   states,
+		codec:    codec,
+		botName:  botName,
+	}
+}
+
+func (h *CoachHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"action":  data.Action,
+		"args":    data.Args,
+	}).Info("Coach callback received")
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	if data.Action == "leave" {
+		return h.leave(locale, user, chatID, messageID)
+	}
+	if !h.cfg.IsCoach(user.TelegramID) {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"action":  data.Action,
+		}).Warn("Coach action by a user who is not a coach")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_forbidden"))
+		return nil
+	}
+
+	switch data.Action {
+	case "clients":
+		return h.showClients(locale, user, chatID, messageID)
+	case "invite":
+		return h.rotateInvite(locale, user, chatID, messageID)
+	}
+
+	id, err := uuid.Parse(data.Arg(0))
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	switch data.Action {
+	case "session", "comment":
+		session, err := database.GetClientSession(id, user.ID, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_forbidden"))
+			return nil
+		}
+		if data.Action == "comment" {
+			h.states.Set(userID, messages.CoachCommentState, map[string]string{"session_id": session.ID.String()})
+			_, err := h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "coach.ask_comment")))
+			return err
+		}
+		return h.showSession(locale, chatID, messageID, session)
+	}
+
+	link, err := database.GetCoachClient(user.ID, id, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_forbidden"))
+		return nil
+	}
+
+	switch data.Action {
+	case "client":
+		return h.showClient(locale, chatID, messageID, &link.Client)
+	case "programs":
+		keyboard, err := keyboards.CreateAssignProgramKeyboard(locale, link.ClientID, h.codec)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+			return err
+		}
+		return h.edit(chatID, messageID, i18n.T(locale, "coach.choose_program", views.ClientName(&link.Client)), &keyboard)
+	case "assign":
+		return h.assignProgram(locale, user, chatID, messageID, &link.Client, data.Arg(1))
+	case "remove":
+		return h.removeClient(locale, user, chatID, messageID, &link.Client)
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown coach action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+}
+
+func (h *CoachHandler) showClients(locale string, coach *models.User, chatID int64, messageID int) error {
+	text, keyboard, err := messages.CoachClients(locale, coach, h.botName, h.codec, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+// rotateInvite makes a new invite link; the old one stops working.
+func (h *CoachHandler) rotateInvite(locale string, coach *models.User, chatID int64, messageID int) error {
+	token, err := coaching.NewToken()
+	if err == nil {
+		err = database.SaveCoachInvite(&models.CoachInvite{CoachID: coach.ID, Token: token}, h.database)
+	}
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_link"))
+		return err
+	}
+	return h.showClients(locale, coach, chatID, messageID)
+}
+
+func (h *CoachHandler) showClient(locale string, chatID int64, messageID int, client *models.User) error {
+	now := time.Now()
+	starts, err := database.ListSessionStarts(client.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+	enrollment, err := database.FindProgramEnrollment(client.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+	sessions, err := database.ListRecentSessions(client.ID, recentClientSessions, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+
+	perWeek := achievements.WeeklySessions
+	if enrollment != nil {
+		if program, ok := programs.Find(enrollment.Program); ok {
+			perWeek = len(program.TrainingDays())
+		}
+	}
+
+	keyboard, err := keyboards.CreateClientKeyboard(locale, client.ID, sessions, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+
+	text := views.ClientCard(locale, client, enrollment, coaching.Measure(starts, perWeek, now), sessions)
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *CoachHandler) showSession(
+	locale string,
+	chatID int64,
+	messageID int,
+	session *models.WorkoutSession,
+) error {
+	client, err := database.GetUserByID(session.UserID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+	comments, err := database.ListSessionComments(session.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+
+	keyboard, err := keyboards.CreateClientSessionKeyboard(locale, session, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+	return h.edit(chatID, messageID, views.ClientSession(locale, client, session, comments), &keyboard)
+}
+
+// assignProgram enrolls the client in the program from its first day and
+// asks the client to open it and set their training maxes.
+func (h *CoachHandler) assignProgram(
+	locale string,
+	coach *models.User,
+	chatID int64,
+	messageID int,
+	client *models.User,
+	slug string,
+) error {
+	program, ok := programs.Find(slug)
+	if !ok {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	if _, err := database.EnrollInProgram(client.ID, program.Slug, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_save"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"coach_id":  coach.ID,
+		"client_id": client.ID,
+		"program":   program.Slug,
+	}).Info("Coach assigned program")
+
+	clientLocale := i18n.UserLocale(client)
+	msg := tgbotapi.NewMessage(client.TelegramID, i18n.T(
+		clientLocale, "coach.notify_program",
+		views.ClientName(coach), views.ProgramName(clientLocale, program.Slug),
+	))
+	msg.ReplyMarkup = keyboards.CreateAssignedProgramKeyboard(clientLocale)
+	_, _ = h.bot.Send(msg)
+
+	return h.showClient(locale, chatID, messageID, client)
+}
+
+func (h *CoachHandler) removeClient(
+	locale string,
+	coach *models.User,
+	chatID int64,
+	messageID int,
+	client *models.User,
+) error {
+	if _, err := database.UnlinkCoachClient(coach.ID, client.ID, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_link"))
+		return err
+	}
+
+	clientLocale := i18n.UserLocale(client)
+	notice := i18n.T(clientLocale, "coach.notify_removed", views.ClientName(coach))
+	_, _ = h.bot.Send(tgbotapi.NewMessage(client.TelegramID, notice))
+
+	return h.showClients(locale, coach, chatID, messageID)
+}
+
+// leave stops the client sharing their training with their coach.
+func (h *CoachHandler) leave(locale string, client *models.User, chatID int64, messageID int) error {
+	link, err := database.FindClientCoach(client.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+	if link == nil {
+		return h.edit(chatID, messageID, i18n.T(locale, "coach.no_coach"), nil)
+	}
+	if _, err := database.UnlinkCoachClient(link.CoachID, client.ID, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_link"))
+		return err
+	}
+
+	coachLocale := i18n.UserLocale(&link.Coach)
+	notice := i18n.T(coachLocale, "coach.notify_left", views.ClientName(client))
+	_, _ = h.bot.Send(tgbotapi.NewMessage(link.Coach.TelegramID, notice))
+
+	return h.edit(chatID, messageID, i18n.T(locale, "coach.left", views.ClientName(&link.Coach)), nil)
+}
+
+func (h *CoachHandler) edit(
+	chatID int64,
+	messageID int,
+	text string,
+	keyboard *tgbotapi.InlineKeyboardMarkup,
+) error {
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	editMsg.ReplyMarkup = keyboard
+	editMsg.DisableWebPagePreview = true
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send coach message")
+	}
+	return err
+}
+
+// CoachInviteConfirmation makes the user a client of the coach whose
+// invite they opened, once they agree to share their training. The
+// payload is the invite token, checked again so that a replaced link or a
+// coach who lost the role no longer works.
+type CoachInviteConfirmation struct {
+	bot      sender.Sender
+	database *gorm.DB
+	cfg      *config.Config
+	codec    *callbackdata.Codec
+}
+
+func NewCoachInviteConfirmation(
+	bot sender.Sender,
+	database *gorm.DB,
+	cfg *config.Config,
+	codec *callbackdata.Codec,
+) *CoachInviteConfirmation {
+	return &CoachInviteConfirmation{
+		bot:      bot,
+		database: database,
+		cfg:      cfg,
+		codec:    codec,
+	}
+}
+
+func (c *CoachInviteConfirmation) Action() confirmation.Action {
+	return confirmation.Action{Execute: c.execute}
+}
+
+func (c *CoachInviteConfirmation) execute(
+	pending *models.PendingAction,
+	chatID int64,
+	locale string,
+) (string, error) {
+	coach, err := database.GetCoachByInvite(pending.Payload, c.database)
+	if err != nil || !c.cfg.IsCoach(coach.TelegramID) {
+		return i18n.T(locale, "error.coach_invite"), nil
+	}
+	client, err := database.GetUserByTelegramID(pending.TelegramID, c.database)
+	if err != nil {
+		return "", err
+	}
+	if coach.ID == client.ID {
+		return i18n.T(locale, "error.coach_self"), nil
+	}
+
+	replaced, err := database.LinkCoachClient(coach.ID, client.ID, c.database)
+	if err != nil {
+		return "", err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"coach_id":  coach.ID,
+		"client_id": client.ID,
+	}).Info("Client linked to coach")
+
+	if replaced != nil {
+		replacedLocale := i18n.UserLocale(replaced)
+		notice := i18n.T(replacedLocale, "coach.notify_left", views.ClientName(client))
+		_, _ = c.bot.Send(tgbotapi.NewMessage(replaced.TelegramID, notice))
+	}
+
+	coachLocale := i18n.UserLocale(coach)
+	msg := tgbotapi.NewMessage(coach.TelegramID, i18n.T(coachLocale, "coach.notify_joined", views.ClientName(client)))
+	if keyboard, err := keyboards.CreateCoachClientLinkKeyboard(coachLocale, client.ID, c.codec); err == nil {
+		msg.ReplyMarkup = keyboard
+	}
+	_, _ = c.bot.Send(msg)
+
+	return i18n.T(locale, "coach.linked", views.ClientName(coach)), nil
+}
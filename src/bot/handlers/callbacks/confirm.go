@@ -0,0 +1,95 @@
+package callbacks
+
+import (
+	"errors"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/services/confirmation"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ConfirmHandler answers keyboards built with CreateConfirmationKeyboard,
+// whose action is the ID of a pending action record.
+type ConfirmHandler struct {
+	bot           sender.Sender
+	database      *gorm.DB
+	confirmations *confirmation.Service
+}
+
+func NewConfirmHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	confirmations *confirmation.Service,
+) *ConfirmHandler {
+	return &ConfirmHandler{
+		bot:           bot,
+		database:      database,
+		confirmations: confirmations,
+	}
+}
+
+func (h *ConfirmHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	actionID, err := uuid.Parse(data.Action)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Invalid pending action ID")
+		h.replace(chatID, messageID, i18n.T(locale, "confirm.outdated"))
+		return nil
+	}
+
+	confirmed := data.Arg(0) == "yes"
+	result, err := h.confirmations.Resolve(actionID, userID, chatID, locale, confirmed)
+
+	switch {
+	case errors.Is(err, confirmation.ErrNotFound):
+		h.replace(chatID, messageID, i18n.T(locale, "confirm.already_done"))
+		return nil
+	case errors.Is(err, confirmation.ErrExpired):
+		h.replace(chatID, messageID, i18n.T(locale, "confirm.expired"))
+		return nil
+	case errors.Is(err, confirmation.ErrForbidden):
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "confirm.foreign"))
+		return nil
+	case err != nil:
+		logger.WithFields(logrus.Fields{
+			"user_id":   userID,
+			"chat_id":   chatID,
+			"action_id": actionID,
+			"error":     err,
+		}).Error("Failed to resolve pending action")
+		h.replace(chatID, messageID, i18n.T(locale, "confirm.failed"))
+		return err
+	}
+
+	if !confirmed {
+		result = i18n.T(locale, "confirm.cancelled")
+	}
+	h.replace(chatID, messageID, result)
+	return nil
+}
+
+func (h *ConfirmHandler) replace(chatID int64, messageID int, text string) {
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	if _, err := h.bot.Send(editMsg); err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Warn("Failed to update confirmation message")
+	}
+}
@@ -0,0 +1,104 @@
+package callbacks
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// EquipmentHandler edits the plates and dumbbells warm-ups are rounded to
+// and turns warm-up sets on and off.
+type EquipmentHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+}
+
+func NewEquipmentHandler(bot sender.Sender, database *gorm.DB, states *state.Store) *EquipmentHandler {
+	return &EquipmentHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+	}
+}
+
+func (h *EquipmentHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	switch data.Action {
+	case "menu":
+	case "unit":
+		unit := data.Arg(0)
+		if unit != models.UnitKg && unit != models.UnitLb {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+			return nil
+		}
+		if unit != user.PlateUnit {
+			// Plates listed in the old unit no longer apply.
+			if err := database.UpdateUserPlateUnit(userID, unit, h.database); err != nil {
+				handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.equipment_update"))
+				return nil
+			}
+			if err := database.UpdateUserPlates(userID, "", h.database); err != nil {
+				handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.equipment_update"))
+				return nil
+			}
+			user.PlateUnit = unit
+			user.Plates = ""
+		}
+	case "plates":
+		h.states.Set(userID, messages.PlatesState, nil)
+		msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "equipment.ask_plates", i18n.T(locale, "equipment.unit_"+user.PlateUnit)))
+		_, err := h.bot.Send(msg)
+		return err
+	case "warmups":
+		if err := database.UpdateUserWarmups(userID, !user.Warmups, h.database); err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.equipment_update"))
+			return nil
+		}
+		user.Warmups = !user.Warmups
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown equipment action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, views.Loadout(locale, user))
+	keyboard := keyboards.CreateEquipmentKeyboard(locale, user)
+	editMsg.ReplyMarkup = &keyboard
+	_, err = h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send equipment menu")
+	}
+	return err
+}
@@ -0,0 +1,368 @@
+package callbacks
+
+import (
+	"context"
+	"slices"
+	"time"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/media"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const mediaTimeout = 2 * time.Minute
+
+// ExerciseHandler browses the exercise catalog, serves technique media and
+// adds exercises to the user's templates or active session.
+type ExerciseHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	config   *config.Config
+	states   *state.Store
+	codec    *callbackdata.Codec
+	media    *media.Service
+}
+
+func NewExerciseHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	cfg *config.Config,
+	states *state.Store,
+	codec *callbackdata.Codec,
+	mediaService *media.Service,
+) *ExerciseHandler {
+	return &ExerciseHandler{
+		bot:      bot,
+		database: database,
+		config:   cfg,
+		states:   states,
+		codec:    codec,
+		media:    mediaService,
+	}
+}
+
+func (h *ExerciseHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"action":  data.Action,
+		"args":    data.Args,
+	}).Info("Exercise callback received")
+
+	switch data.Action {
+	case "categories":
+		keyboard := keyboards.CreateCategoryKeyboard(locale)
+		return h.edit(chatID, messageID, i18n.T(locale, "exercises.categories_title"), &keyboard)
+	case "list":
+		page, _ := data.IntArg(1)
+		return h.showList(locale, chatID, messageID, data.Arg(0), page)
+	}
+
+	exerciseID, err := uuid.Parse(data.Arg(0))
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	exercise, err := database.GetExercise(exerciseID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		return nil
+	}
+
+	switch data.Action {
+	case "card":
+		keyboard, err := keyboards.CreateExerciseCardKeyboard(locale, exercise, h.config.IsAdmin(userID), h.codec)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+			return err
+		}
+		return h.edit(chatID, messageID, views.ExerciseCard(locale, exercise), &keyboard)
+	case "details":
+		kinds := []string{models.MediaKindImage, models.MediaKindAnimation}
+		return h.sendMedia(locale, chatID, exercise, kinds, "exercises.no_images")
+	case "video":
+		kinds := []string{models.MediaKindVideo}
+		return h.sendMedia(locale, chatID, exercise, kinds, "exercises.no_video")
+	case "upload":
+		return h.askUpload(locale, userID, chatID, exercise)
+	}
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	switch data.Action {
+	case "add":
+		page, _ := data.IntArg(1)
+		return h.showTargets(locale, user, chatID, messageID, exercise, page)
+	case "to_template":
+		return h.addToTemplate(locale, user, chatID, messageID, exercise, data.Arg(1))
+	case "to_session":
+		return h.addToSession(locale, user, chatID, messageID, exercise, data.Arg(1))
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown exercise action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+}
+
+// sendMedia sends the exercise's media of the given kinds, captioning the
+// first one with the exercise card.
+func (h *ExerciseHandler) sendMedia(
+	locale string,
+	chatID int64,
+	exercise *models.Exercise,
+	kinds []string,
+	emptyKey string,
+) error {
+	items, err := database.ListExerciseMedia(exercise.ID, kinds, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.media_send"))
+		return err
+	}
+	if len(items) == 0 {
+		msg := tgbotapi.NewMessage(chatID, i18n.T(locale, emptyKey, views.ExerciseName(locale, exercise)))
+		_, err := h.bot.Send(msg)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mediaTimeout)
+	defer cancel()
+
+	caption := views.ExerciseCard(locale, exercise)
+	for i := range items {
+		if err := h.media.Send(ctx, chatID, &items[i], caption); err != nil {
+			logger.WithFields(logrus.Fields{
+				"chat_id":  chatID,
+				"media_id": items[i].ID,
+				"error":    err,
+			}).Error("Failed to send exercise media")
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.media_send"))
+			return err
+		}
+		caption = ""
+	}
+	return nil
+}
+
+func (h *ExerciseHandler) askUpload(
+	locale string,
+	userID int64,
+	chatID int64,
+	exercise *models.Exercise,
+) error {
+	if !h.config.IsAdmin(userID) {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.admin_only"))
+		return nil
+	}
+	if !h.media.Enabled() {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.media_disabled"))
+		return nil
+	}
+
+	h.states.Set(userID, messages.ExerciseMediaState, map[string]string{
+		"exercise_id": exercise.ID.String(),
+	})
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "media.ask_upload", views.ExerciseName(locale, exercise)))
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func (h *ExerciseHandler) showList(
+	locale string,
+	chatID int64,
+	messageID int,
+	category string,
+	page int,
+) error {
+	if !slices.Contains(keyboards.Categories, category) {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	page = max(page, 0)
+	exercises, total, err := database.ListExercisesByCategory(
+		category, page*keyboards.CatalogPageSize, keyboards.CatalogPageSize, h.database,
+	)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		return err
+	}
+
+	// The catalog may have shrunk since the keyboard was sent.
+	pages := keyboards.Pages(total, keyboards.CatalogPageSize)
+	if page >= pages {
+		return h.showList(locale, chatID, messageID, category, pages-1)
+	}
+
+	keyboard, err := keyboards.CreateExerciseListKeyboard(locale, category, exercises, page, pages, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		return err
+	}
+
+	text := i18n.T(locale, "exercises.category_title", views.Category(locale, category))
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *ExerciseHandler) showTargets(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	exercise *models.Exercise,
+	page int,
+) error {
+	session, err := database.FindActiveSession(user.ID, h.database)
+	if err != nil {
+		return err
+	}
+	templates, err := database.ListWorkoutTemplates(user.ID, h.database)
+	if err != nil {
+		return err
+	}
+
+	if session == nil && len(templates) == 0 {
+		keyboard := keyboards.CreateBackKeyboard(
+			locale, callbackdata.New(callbackdata.TypeExercise, "card", exercise.ID.String()).String(),
+		)
+		return h.edit(chatID, messageID, i18n.T(locale, "exercises.add_empty"), &keyboard)
+	}
+
+	keyboard, err := keyboards.CreateAddTargetKeyboard(locale, exercise, session, templates, page, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	text := i18n.T(locale, "exercises.add_question", views.ExerciseName(locale, exercise))
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *ExerciseHandler) addToTemplate(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	exercise *models.Exercise,
+	templateID string,
+) error {
+	id, err := uuid.Parse(templateID)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	template, err := database.GetWorkoutTemplate(id, user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return nil
+	}
+
+	item, err := messages.AddExerciseToTemplate(user, template, exercise, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	text := i18n.T(
+		locale, "exercises.added_template",
+		views.ExerciseName(locale, exercise), template.Name, views.Targets(locale, item),
+	)
+	return h.showAdded(locale, chatID, messageID, exercise, text)
+}
+
+func (h *ExerciseHandler) addToSession(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	exercise *models.Exercise,
+	sessionID string,
+) error {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	session, err := database.GetWorkoutSession(id, user.ID, h.database)
+	if err != nil || session.FinishedAt != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+		return nil
+	}
+
+	item, err := messages.AddExerciseToSession(user, session, exercise, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	text := i18n.T(
+		locale, "exercises.added_session",
+		views.ExerciseName(locale, exercise), views.SessionTargets(locale, item),
+	)
+	return h.showAdded(locale, chatID, messageID, exercise, text)
+}
+
+func (h *ExerciseHandler) showAdded(
+	locale string,
+	chatID int64,
+	messageID int,
+	exercise *models.Exercise,
+	text string,
+) error {
+	logger.WithFields(logrus.Fields{
+		"chat_id":     chatID,
+		"exercise_id": exercise.ID,
+	}).Info("Exercise added from catalog")
+
+	keyboard := keyboards.CreateBackKeyboard(
+		locale, callbackdata.New(callbackdata.TypeExercise, "card", exercise.ID.String()).String(),
+	)
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *ExerciseHandler) edit(
+	chatID int64,
+	messageID int,
+	text string,
+	keyboard *tgbotapi.InlineKeyboardMarkup,
+) error {
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	editMsg.ReplyMarkup = keyboard
+
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to edit exercise message")
+	}
+	return err
+}
@@ -2,9 +2,11 @@ package callbacks
 
 import (
 	"strconv"
-	"strings"
+	"workouts_bot/src/bot/callbackdata"
 	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/sender"
 	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
 	"workouts_bot/src/logger"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -12,47 +14,50 @@ import (
 	"gorm.io/gorm"
 )
 
-const ExperienceCallbackType = "experience"
+// ExperienceSetAction sets the experience level given as the first argument.
+const ExperienceSetAction = "set"
 
 type ExperienceHandler struct {
-	bot      *tgbotapi.BotAPI
+	bot      sender.Sender
 	database *gorm.DB
 }
 
-func NewExperienceHandler(bot *tgbotapi.BotAPI, database *gorm.DB) *ExperienceHandler {
+func NewExperienceHandler(bot sender.Sender, database *gorm.DB) *ExperienceHandler {
 	return &ExperienceHandler{
 		bot:      bot,
 		database: database,
 	}
 }
 
-func (h *ExperienceHandler) Handle(update tgbotapi.Update) error {
+func (h *ExperienceHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
 	callbackQuery := update.CallbackQuery
 	userID := callbackQuery.From.ID
 	chatID := callbackQuery.Message.Chat.ID
 	messageID := callbackQuery.Message.MessageID
-	data := callbackQuery.Data
-	parts := strings.Split(data, ":")
+	locale := handlers.Locale(callbackQuery.From, h.database)
 
 	logger.WithFields(logrus.Fields{
 		"user_id":    userID,
 		"chat_id":    chatID,
 		"message_id": messageID,
-		"data":       data,
+		"action":     data.Action,
+		"args":       data.Args,
 	}).Info("Experience callback received")
 
-	if len(parts) < 2 {
+	// Keyboards sent before versioning carried the level as "experience:<n>".
+	experienceStr := data.Arg(0)
+	if data.Version == 0 {
+		experienceStr = data.Action
+	} else if data.Action != ExperienceSetAction {
 		logger.WithFields(logrus.Fields{
 			"user_id": userID,
 			"chat_id": chatID,
-			"data":    data,
-		}).Error("Invalid experience callback format")
-		handlers.SendErrorMessage(h.bot, chatID, "Неверный формат команды")
+			"action":  data.Action,
+		}).Error("Unknown experience action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
 		return nil
 	}
 
-	experienceStr := parts[1]
-
 	experience, err := strconv.Atoi(experienceStr)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
@@ -61,7 +66,7 @@ func (h *ExperienceHandler) Handle(update tgbotapi.Update) error {
 			"experience_str": experienceStr,
 			"error":          err,
 		}).Error("Failed to parse experience level")
-		handlers.SendErrorMessage(h.bot, chatID, "Неверный уровень опыта")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_experience"))
 		return nil
 	}
 
@@ -78,7 +83,7 @@ func (h *ExperienceHandler) Handle(update tgbotapi.Update) error {
 			"chat_id": chatID,
 			"error":   err,
 		}).Error("Failed to get user for experience update")
-		handlers.SendErrorMessage(h.bot, chatID, "Пользователь не найден")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
 		return nil
 	}
 
@@ -91,7 +96,7 @@ func (h *ExperienceHandler) Handle(update tgbotapi.Update) error {
 			"experience": experience,
 			"error":      err,
 		}).Error("Failed to update user experience")
-		handlers.SendErrorMessage(h.bot, chatID, "Ошибка обновления уровня опыта")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.experience_update"))
 		return nil
 	}
 
@@ -101,7 +106,7 @@ func (h *ExperienceHandler) Handle(update tgbotapi.Update) error {
 		"experience": experience,
 	}).Info("User experience updated successfully")
 
-	msg := tgbotapi.NewMessage(chatID, "✅ Уровень опыта обновлен!")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "experience.updated"))
 	_, err = h.bot.Send(msg)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
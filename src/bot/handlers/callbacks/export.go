@@ -0,0 +1,164 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/export"
+	"workouts_bot/src/services/media"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ExportHandler exports the user's data in the format picked from /export,
+// or their progress photos, and sends it back as a document.
+type ExportHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	media    *media.Service
+}
+
+// photosTimeout bounds reading every progress photo back from storage.
+const photosTimeout = 5 * time.Minute
+
+func NewExportHandler(bot sender.Sender, database *gorm.DB, media *media.Service) *ExportHandler {
+	return &ExportHandler{
+		bot:      bot,
+		database: database,
+		media:    media,
+	}
+}
+
+func (h *ExportHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	format := data.Action
+	if format != keyboards.ExportPhotosAction && !slices.Contains(export.Formats, format) {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown export format")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	_, _ = h.bot.Request(tgbotapi.NewChatAction(chatID, tgbotapi.ChatUploadDocument))
+
+	// The export is written to disk rather than memory, and sent from there.
+	dir, err := os.MkdirTemp("", "export-")
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.export_failed"))
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if format == keyboards.ExportPhotosAction {
+		return h.exportPhotos(locale, user, chatID, dir)
+	}
+
+	path := filepath.Join(dir, export.FileName(format, time.Now()))
+	if err := writeExport(path, format, user, h.database); err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"format":  format,
+			"error":   err,
+		}).Error("Failed to export user data")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.export_failed"))
+		return err
+	}
+
+	document := tgbotapi.NewDocument(chatID, tgbotapi.FilePath(path))
+	document.Caption = i18n.T(locale, "export.caption")
+	if _, err := h.bot.Send(document); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.export_failed"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"format":  format,
+	}).Info("User data exported")
+	return nil
+}
+
+// exportPhotos sends the user's progress photos as one zip archive.
+func (h *ExportHandler) exportPhotos(locale string, user *models.User, chatID int64, dir string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), photosTimeout)
+	defer cancel()
+
+	path := filepath.Join(dir, fmt.Sprintf("progress-%s.zip", time.Now().Format("2006-01-02")))
+	count, err := writePhotos(ctx, path, user, h.media)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"error":   err,
+		}).Error("Failed to export progress photos")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.export_failed"))
+		return err
+	}
+	if count == 0 {
+		_, err := h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "progress.empty")))
+		return err
+	}
+
+	document := tgbotapi.NewDocument(chatID, tgbotapi.FilePath(path))
+	document.Caption = i18n.T(locale, "export.photos", count)
+	if _, err := h.bot.Send(document); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.export_failed"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"photos":  count,
+	}).Info("Progress photos exported")
+	return nil
+}
+
+func writeExport(path string, format string, user *models.User, db *gorm.DB) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := export.Write(file, format, user, db); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func writePhotos(ctx context.Context, path string, user *models.User, service *media.Service) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	count, err := service.WriteProgressPhotos(ctx, file, user.ID)
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+	return count, file.Close()
+}
@@ -0,0 +1,76 @@
+package callbacks
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var goals = map[string]bool{
+	models.GoalMuscleGain: true,
+	models.GoalStrength:   true,
+	models.GoalEndurance:  true,
+	models.GoalWeightLoss: true,
+}
+
+type GoalHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewGoalHandler(bot sender.Sender, database *gorm.DB) *GoalHandler {
+	return &GoalHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *GoalHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	goal := data.Arg(0)
+	if data.Action != "set" || !goals[goal] {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+			"args":    data.Args,
+		}).Error("Invalid goal callback")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	if err := database.UpdateUserGoal(userID, goal, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.goal_update"))
+		return nil
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"goal":    goal,
+	}).Info("User goal updated")
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, i18n.T(locale, "goal.updated"))
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send goal update confirmation")
+	}
+	return err
+}
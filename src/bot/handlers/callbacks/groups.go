@@ -0,0 +1,244 @@
+package callbacks
+
+import (
+	"slices"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/groups"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GroupHandler sets how the user appears in groups, and starts and joins
+// group challenges. Only members who opted in with /join take part.
+type GroupHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	codec    *callbackdata.Codec
+}
+
+func NewGroupHandler(bot sender.Sender, database *gorm.DB, codec *callbackdata.Codec) *GroupHandler {
+	return &GroupHandler{
+		bot:      bot,
+		database: database,
+		codec:    codec,
+	}
+}
+
+func (h *GroupHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	chatID := callbackQuery.Message.Chat.ID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	switch data.Action {
+	case "privacy":
+		return h.setPrivacy(locale, callbackQuery, data.Arg(0))
+	case "start", "join":
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": callbackQuery.From.ID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown group action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	group, err := database.FindGroup(chatID, h.database)
+	if err != nil || group == nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.group_load"))
+		return err
+	}
+	locale = i18n.Resolve(group.Language)
+
+	user, err := database.FindUserByTelegramID(callbackQuery.From.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_fetch"))
+		return err
+	}
+	member := false
+	if user != nil {
+		member, err = database.IsGroupMember(group.ID, user.ID, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.group_load"))
+			return err
+		}
+	}
+	if !member {
+		h.replyTo(callbackQuery, i18n.T(locale, "group.members_only"))
+		return nil
+	}
+
+	if data.Action == "start" {
+		return h.startChallenge(locale, callbackQuery, group, user, data.Arg(0))
+	}
+	challengeID, err := uuid.Parse(data.Arg(0))
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	return h.joinChallenge(locale, callbackQuery, group, user, challengeID)
+}
+
+func (h *GroupHandler) setPrivacy(locale string, callbackQuery *tgbotapi.CallbackQuery, privacy string) error {
+	chatID := callbackQuery.Message.Chat.ID
+
+	user, err := database.GetUserByTelegramID(callbackQuery.From.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+	if privacy != "" && privacy != user.GroupPrivacy {
+		if !slices.Contains(models.GroupPrivacies, privacy) {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+			return nil
+		}
+		if err := database.UpdateUserGroupPrivacy(user.TelegramID, privacy, h.database); err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.group_update"))
+			return err
+		}
+		user.GroupPrivacy = privacy
+	}
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, callbackQuery.Message.MessageID, i18n.T(locale, "group.privacy"))
+	keyboard := keyboards.CreateGroupPrivacyKeyboard(locale, user.GroupPrivacy)
+	editMsg.ReplyMarkup = &keyboard
+	_, err = h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send group privacy menu")
+	}
+	return err
+}
+
+func (h *GroupHandler) startChallenge(
+	locale string,
+	callbackQuery *tgbotapi.CallbackQuery,
+	group *models.Group,
+	user *models.User,
+	kind string,
+) error {
+	chatID := callbackQuery.Message.Chat.ID
+	challenge, ok := groups.Find(kind)
+	if !ok {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	today := database.Today()
+	running, err := database.ListActiveChallenges(group.ID, today, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.group_load"))
+		return err
+	}
+	if slices.ContainsFunc(running, func(c models.Challenge) bool { return c.Kind == kind }) {
+		h.replyTo(callbackQuery, i18n.T(locale, "group.challenge_running"))
+		return h.refreshChallenges(locale, callbackQuery, running)
+	}
+
+	started := challenge.Start(group.ID, today)
+	if err := database.CreateChallenge(&started, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.group_update"))
+		return err
+	}
+	if _, err := database.JoinChallenge(started.ID, user.ID, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.group_update"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"group_id":     group.ID,
+		"challenge_id": started.ID,
+		"kind":         kind,
+	}).Info("Group challenge started")
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(
+		locale, "group.challenge_started",
+		views.ChallengeName(locale, challenge), views.ChallengeAmount(locale, challenge, challenge.Goal),
+		views.Date(locale, started.EndsOn),
+	))
+	if _, err := h.bot.Send(msg); err != nil {
+		return err
+	}
+	return h.refreshChallenges(locale, callbackQuery, append(running, started))
+}
+
+func (h *GroupHandler) joinChallenge(
+	locale string,
+	callbackQuery *tgbotapi.CallbackQuery,
+	group *models.Group,
+	user *models.User,
+	challengeID uuid.UUID,
+) error {
+	chatID := callbackQuery.Message.Chat.ID
+	running, err := database.GetChallenge(challengeID, h.database)
+	if err != nil || running.GroupID != group.ID {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	challenge, ok := groups.Find(running.Kind)
+	if !ok || running.EndsOn.Before(database.Today()) {
+		h.replyTo(callbackQuery, i18n.T(locale, "group.challenge_over"))
+		return nil
+	}
+
+	joined, err := database.JoinChallenge(running.ID, user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.group_update"))
+		return err
+	}
+
+	// The confirmation goes to the user's private chat so that joining
+	// does not name anonymous members in the group.
+	locale = i18n.UserLocale(user)
+	text := i18n.T(locale, "group.challenge_already_joined", views.ChallengeName(locale, challenge))
+	if joined {
+		text = i18n.T(
+			locale, "group.challenge_joined",
+			views.ChallengeName(locale, challenge), group.Title,
+			views.ChallengeAmount(locale, challenge, challenge.Goal), views.Date(locale, running.EndsOn),
+		)
+	}
+	_, err = h.bot.Send(tgbotapi.NewMessage(user.TelegramID, text))
+	return err
+}
+
+// refreshChallenges updates the challenge list the button was pressed on.
+func (h *GroupHandler) refreshChallenges(
+	locale string,
+	callbackQuery *tgbotapi.CallbackQuery,
+	running []models.Challenge,
+) error {
+	keyboard, err := keyboards.CreateChallengesKeyboard(locale, running, h.codec)
+	if err != nil {
+		return err
+	}
+
+	editMsg := tgbotapi.NewEditMessageText(
+		callbackQuery.Message.Chat.ID,
+		callbackQuery.Message.MessageID,
+		views.ActiveChallenges(locale, running),
+	)
+	editMsg.ReplyMarkup = &keyboard
+	_, err = h.bot.Send(editMsg)
+	return err
+}
+
+func (h *GroupHandler) replyTo(callbackQuery *tgbotapi.CallbackQuery, text string) {
+	msg := tgbotapi.NewMessage(callbackQuery.Message.Chat.ID, text)
+	msg.ReplyToMessageID = callbackQuery.Message.MessageID
+	_, _ = h.bot.Send(msg)
+}
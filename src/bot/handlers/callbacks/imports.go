@@ -0,0 +1,92 @@
+package callbacks
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ImportHandler records which catalog exercise an exercise name from an
+// imported export is, or that it is skipped, and resumes the import.
+type ImportHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+	imports  *messages.ImportHandler
+}
+
+func NewImportHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	states *state.Store,
+	imports *messages.ImportHandler,
+) *ImportHandler {
+	return &ImportHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+		imports:  imports,
+	}
+}
+
+func (h *ImportHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	current, ok := h.states.Get(userID)
+	if !ok || current.Name != messages.ImportAliasState {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_expired"))
+		return nil
+	}
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	alias := &models.ImportAlias{UserID: user.ID, Name: current.Data["name"]}
+	switch data.Action {
+	case "map":
+		id, err := uuid.Parse(data.Arg(0))
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+			return nil
+		}
+		exercise, err := database.GetExercise(id, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+			return nil
+		}
+		alias.ExerciseID = &exercise.ID
+	case "skip":
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown import action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	if err := database.SaveImportAlias(alias, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+	return h.imports.ImportHistory(locale, user, chatID, current.Data["file_id"])
+}
@@ -0,0 +1,69 @@
+package callbacks
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LanguageHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewLanguageHandler(bot sender.Sender, database *gorm.DB) *LanguageHandler {
+	return &LanguageHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *LanguageHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	// An empty language means "follow the Telegram client".
+	language := data.Arg(0)
+	if data.Action != "set" || (language != "" && i18n.Resolve(language) != language) {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+			"args":    data.Args,
+		}).Error("Invalid language callback")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	if err := database.UpdateUserLanguage(userID, language, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.language_update"))
+		return nil
+	}
+
+	locale = handlers.Locale(callbackQuery.From, h.database)
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"locale":  locale,
+	}).Info("User language updated")
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, i18n.T(locale, "language.updated"))
+	_, _ = h.bot.Send(editMsg)
+
+	// The reply keyboard keeps its old labels until it is sent again.
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "menu.main"))
+	msg.ReplyMarkup = keyboards.CreateMainMenu(locale)
+	_, err := h.bot.Send(msg)
+	return err
+}
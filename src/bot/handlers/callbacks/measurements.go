@@ -0,0 +1,92 @@
+package callbacks
+
+import (
+	"slices"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/services/body"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MeasurementHandler shows the measurement summary and per-kind history.
+type MeasurementHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewMeasurementHandler(bot sender.Sender, database *gorm.DB) *MeasurementHandler {
+	return &MeasurementHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *MeasurementHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"action":  data.Action,
+		"args":    data.Args,
+	}).Info("Measurement callback received")
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	var text string
+	var keyboard tgbotapi.InlineKeyboardMarkup
+	switch data.Action {
+	case "summary":
+		text, err = messages.MeasurementsText(locale, user, h.database)
+		keyboard = keyboards.CreateMeasurementsKeyboard(locale)
+	case "history":
+		kind := data.Arg(0)
+		if !slices.Contains(body.Kinds, kind) {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+			return nil
+		}
+		page, _ := data.IntArg(1)
+		text, keyboard, err = messages.MeasurementHistory(locale, user, kind, page, h.database)
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown measurement action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.measurement_load"))
+		return err
+	}
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	editMsg.ReplyMarkup = &keyboard
+
+	_, err = h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to edit measurement message")
+	}
+	return err
+}
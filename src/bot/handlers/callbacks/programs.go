@@ -0,0 +1,365 @@
+package callbacks
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/confirmation"
+	"workouts_bot/src/services/programs"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ProgramHandler browses the program library, enrolls users, edits their
+// training maxes and starts program days.
+type ProgramHandler struct {
+	bot           sender.Sender
+	database      *gorm.DB
+	states        *state.Store
+	confirmations *confirmation.Service
+	codec         *callbackdata.Codec
+}
+
+func NewProgramHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	states *state.Store,
+	confirmations *confirmation.Service,
+	codec *callbackdata.Codec,
+) *ProgramHandler {
+	return &ProgramHandler{
+		bot:           bot,
+		database:      database,
+		states:        states,
+		confirmations: confirmations,
+		codec:         codec,
+	}
+}
+
+func (h *ProgramHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"action":  data.Action,
+		"args":    data.Args,
+	}).Info("Program callback received")
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	switch data.Action {
+	case "list":
+		return h.showList(locale, user, chatID, messageID)
+	case "view", "enroll":
+		program, ok := programs.Find(data.Arg(0))
+		if !ok {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+			return nil
+		}
+		if data.Action == "enroll" {
+			return h.enroll(locale, user, chatID, messageID, program)
+		}
+		return h.showProgram(locale, user, chatID, messageID, program)
+	case "max":
+		err := messages.AskExerciseMax(h.bot, h.states, locale, user, chatID, data.Arg(0), h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		}
+		return err
+	}
+
+	enrollment, err := database.FindProgramEnrollment(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+	if enrollment == nil {
+		return h.showList(locale, user, chatID, messageID)
+	}
+
+	switch data.Action {
+	case "current":
+		return h.showEnrollment(locale, user, chatID, messageID, enrollment)
+	case "start":
+		return h.start(locale, user, chatID, messageID, enrollment)
+	case "maxes":
+		return h.showMaxes(locale, user, chatID, messageID, enrollment)
+	case "leave":
+		return h.requestLeave(locale, user, chatID, messageID, enrollment)
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown program action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+}
+
+func (h *ProgramHandler) showList(locale string, user *models.User, chatID int64, messageID int) error {
+	enrollment, err := database.FindProgramEnrollment(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+
+	keyboard := keyboards.CreateProgramListKeyboard(locale, enrollment)
+	return h.edit(chatID, messageID, i18n.T(locale, "programs.list"), &keyboard)
+}
+
+func (h *ProgramHandler) showProgram(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	program *programs.Program,
+) error {
+	exercises, err := database.ListExercisesBySlugs(program.Maxes(), h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+
+	text := views.Program(locale, program, exercises)
+	enrollment, err := database.FindProgramEnrollment(user.ID, h.database)
+	if err == nil && enrollment != nil {
+		text += i18n.T(locale, "programs.replace_warning", views.ProgramName(locale, enrollment.Program))
+	}
+
+	keyboard := keyboards.CreateProgramKeyboard(locale, program.Slug)
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+// enroll starts the program from its first day and asks for the training
+// maxes it needs that the user has not entered yet.
+func (h *ProgramHandler) enroll(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	program *programs.Program,
+) error {
+	enrollment, err := database.EnrollInProgram(user.ID, program.Slug, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_save"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id": user.TelegramID,
+		"program": program.Slug,
+	}).Info("User enrolled in program")
+
+	asked, err := messages.AskTrainingMax(h.bot, h.states, locale, user, chatID, enrollment, h.database)
+	if err != nil || asked {
+		return err
+	}
+	return h.showEnrollment(locale, user, chatID, messageID, enrollment)
+}
+
+func (h *ProgramHandler) showEnrollment(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	enrollment *models.ProgramEnrollment,
+) error {
+	text, keyboard, err := messages.ProgramView(locale, user, enrollment, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *ProgramHandler) showMaxes(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	enrollment *models.ProgramEnrollment,
+) error {
+	program, ok := programs.Find(enrollment.Program)
+	if !ok {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return programs.ErrUnknown
+	}
+
+	maxes, err := messages.TrainingMaxes(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+	exercises, err := database.ListExercisesBySlugs(program.Maxes(), h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+
+	keyboard := keyboards.CreateTrainingMaxesKeyboard(locale, program, exercises, maxes)
+	return h.edit(chatID, messageID, i18n.T(locale, "programs.maxes"), &keyboard)
+}
+
+// start begins the current program day unless a session is already
+// running, in which case the running one is shown instead. Missing
+// training maxes are asked for first.
+func (h *ProgramHandler) start(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	enrollment *models.ProgramEnrollment,
+) error {
+	active, err := database.FindActiveSession(user.ID, h.database)
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "sessions.already_active", active.Name))
+		return h.showSession(locale, user, chatID, messageID, active)
+	}
+
+	asked, err := messages.AskTrainingMax(h.bot, h.states, locale, user, chatID, enrollment, h.database)
+	if err != nil || asked {
+		return err
+	}
+
+	session, err := messages.StartProgramDay(locale, user, enrollment, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":    user.TelegramID,
+		"session_id": session.ID,
+		"program":    enrollment.Program,
+		"week":       enrollment.Week,
+		"day":        enrollment.Day,
+	}).Info("Program day started")
+
+	session, err = database.GetWorkoutSession(session.ID, user.ID, h.database)
+	if err != nil {
+		return err
+	}
+	return h.showSession(locale, user, chatID, messageID, session)
+}
+
+func (h *ProgramHandler) showSession(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	session *models.WorkoutSession,
+) error {
+	training.PrepareWarmups(user, session.Current(), h.database)
+	text, keyboard, err := messages.SessionView(locale, user, session, h.codec, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_save"))
+		return err
+	}
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *ProgramHandler) requestLeave(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	enrollment *models.ProgramEnrollment,
+) error {
+	pending, err := h.confirmations.Request(user.TelegramID, messages.LeaveProgramAction, enrollment.ID.String())
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_save"))
+		return err
+	}
+
+	text := i18n.T(locale, "programs.leave_question", views.ProgramName(locale, enrollment.Program))
+	keyboard := keyboards.CreateConfirmationKeyboard(locale, pending.ID.String())
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *ProgramHandler) edit(
+	chatID int64,
+	messageID int,
+	text string,
+	keyboard *tgbotapi.InlineKeyboardMarkup,
+) error {
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	editMsg.ReplyMarkup = keyboard
+
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to edit program message")
+	}
+	return err
+}
+
+// ProgramLeaveConfirmation ends the user's enrollment once confirmed. The
+// payload is the enrollment ID, so a confirmation left over from an
+// earlier enrollment does nothing.
+type ProgramLeaveConfirmation struct {
+	database *gorm.DB
+}
+
+func NewProgramLeaveConfirmation(database *gorm.DB) *ProgramLeaveConfirmation {
+	return &ProgramLeaveConfirmation{database: database}
+}
+
+func (c *ProgramLeaveConfirmation) Action() confirmation.Action {
+	return confirmation.Action{Execute: c.execute}
+}
+
+func (c *ProgramLeaveConfirmation) execute(
+	pending *models.PendingAction,
+	chatID int64,
+	locale string,
+) (string, error) {
+	user, err := database.GetUserByTelegramID(pending.TelegramID, c.database)
+	if err != nil {
+		return "", err
+	}
+
+	enrollment, err := database.FindProgramEnrollment(user.ID, c.database)
+	if err != nil {
+		return "", err
+	}
+	if enrollment == nil || enrollment.ID.String() != pending.Payload {
+		return i18n.T(locale, "programs.not_enrolled"), nil
+	}
+
+	if err := database.LeaveProgram(user.ID, c.database); err != nil {
+		return "", err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id": pending.TelegramID,
+		"program": enrollment.Program,
+	}).Info("User left program")
+
+	return i18n.T(locale, "programs.left", views.ProgramName(locale, enrollment.Program)), nil
+}
@@ -0,0 +1,305 @@
+package callbacks
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/media"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ProgressHandler adds progress photos, lists them by date and compares
+// two dates of the same pose as a collage.
+type ProgressHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+	media    *media.Service
+}
+
+func NewProgressHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	states *state.Store,
+	mediaService *media.Service,
+) *ProgressHandler {
+	return &ProgressHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+		media:    mediaService,
+	}
+}
+
+func (h *ProgressHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"action":  data.Action,
+		"args":    data.Args,
+	}).Info("Progress callback received")
+
+	switch data.Action {
+	case "menu":
+		keyboard := keyboards.CreateProgressKeyboard(locale)
+		return h.edit(chatID, messageID, i18n.T(locale, "progress.title"), &keyboard)
+	case "add":
+		keyboard := keyboards.CreatePoseKeyboard(locale, "pose")
+		return h.edit(chatID, messageID, i18n.T(locale, "progress.choose_pose"), &keyboard)
+	case "compare":
+		keyboard := keyboards.CreatePoseKeyboard(locale, "from", "0")
+		return h.edit(chatID, messageID, i18n.T(locale, "progress.choose_pose"), &keyboard)
+	}
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	switch data.Action {
+	case "pose":
+		return h.askPhoto(locale, userID, chatID, data.Arg(0))
+	case "history":
+		page, _ := data.IntArg(0)
+		return h.showHistory(locale, user, chatID, messageID, page)
+	case "day":
+		return h.sendDay(locale, user, chatID, data.Arg(0))
+	case "from", "to":
+		return h.showCompareDates(locale, user, chatID, messageID, data)
+	case "collage":
+		return h.sendCollage(locale, user, chatID, data.Arg(0), data.Arg(1), data.Arg(2))
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown progress action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+}
+
+func (h *ProgressHandler) askPhoto(locale string, userID int64, chatID int64, pose string) error {
+	if !slices.Contains(keyboards.Poses, pose) {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+	if !h.media.Enabled() {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.media_disabled"))
+		return nil
+	}
+
+	h.states.Set(userID, messages.ProgressPhotoState, map[string]string{"pose": pose})
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "progress.ask_photo", views.Pose(locale, pose)))
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func (h *ProgressHandler) showHistory(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	page int,
+) error {
+	page = max(page, 0)
+	dates, total, err := database.ListProgressPhotoDates(
+		user.ID, "", time.Time{}, page*keyboards.CatalogPageSize, keyboards.CatalogPageSize, h.database,
+	)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.progress_send"))
+		return err
+	}
+
+	if total == 0 {
+		keyboard := keyboards.CreateBackKeyboard(locale, callbackdata.New(callbackdata.TypeProgress, "menu").String())
+		return h.edit(chatID, messageID, i18n.T(locale, "progress.empty"), &keyboard)
+	}
+
+	pages := keyboards.Pages(total, keyboards.CatalogPageSize)
+	if page >= pages {
+		return h.showHistory(locale, user, chatID, messageID, pages-1)
+	}
+
+	keyboard := keyboards.CreateProgressHistoryKeyboard(locale, dates, page, pages)
+	return h.edit(chatID, messageID, i18n.T(locale, "progress.history_title"), &keyboard)
+}
+
+func (h *ProgressHandler) sendDay(locale string, user *models.User, chatID int64, value string) error {
+	day, err := time.Parse(views.DateLayout, value)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	photos, err := database.ListProgressPhotosOn(user.ID, day, h.database)
+	if err != nil || len(photos) == 0 {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "progress.empty"))
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mediaTimeout)
+	defer cancel()
+
+	for i := range photos {
+		caption := i18n.T(locale, "progress.photo_caption", views.Pose(locale, photos[i].Pose), views.Date(locale, day))
+		if err := h.media.SendProgressPhoto(ctx, chatID, &photos[i], caption); err != nil {
+			logger.WithFields(logrus.Fields{
+				"chat_id":  chatID,
+				"photo_id": photos[i].ID,
+				"error":    err,
+			}).Error("Failed to send progress photo")
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.progress_send"))
+			return err
+		}
+	}
+	return nil
+}
+
+// showCompareDates lists the days to compare: "from" picks the earlier day
+// of the pose, "to" a later one. Args are pose[, from], page.
+func (h *ProgressHandler) showCompareDates(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	data callbackdata.Data,
+) error {
+	pose := data.Arg(0)
+	if !slices.Contains(keyboards.Poses, pose) {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	var from time.Time
+	pageArg := 1
+	if data.Action == "to" {
+		var err error
+		from, err = time.Parse(views.DateLayout, data.Arg(1))
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+			return nil
+		}
+		pageArg = 2
+	}
+	page, _ := data.IntArg(pageArg)
+	page = max(page, 0)
+
+	dates, total, err := database.ListProgressPhotoDates(
+		user.ID, pose, from, page*keyboards.CatalogPageSize, keyboards.CatalogPageSize, h.database,
+	)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.progress_send"))
+		return err
+	}
+
+	// Comparing needs a later day to pick after the first one.
+	if (data.Action == "from" && total < 2) || total == 0 {
+		keyboard := keyboards.CreateBackKeyboard(locale, callbackdata.New(callbackdata.TypeProgress, "compare").String())
+		text := i18n.T(locale, "progress.compare_empty", views.Pose(locale, pose))
+		return h.edit(chatID, messageID, text, &keyboard)
+	}
+
+	pages := keyboards.Pages(total, keyboards.CatalogPageSize)
+	if page >= pages {
+		args := slices.Clone(data.Args)
+		args[pageArg] = strconv.Itoa(pages - 1)
+		return h.showCompareDates(locale, user, chatID, messageID, callbackdata.New(data.Type, data.Action, args...))
+	}
+
+	if data.Action == "from" {
+		text := i18n.T(locale, "progress.compare_from", views.Pose(locale, pose))
+		keyboard := keyboards.CreateCompareFromKeyboard(locale, pose, dates, page, pages)
+		return h.edit(chatID, messageID, text, &keyboard)
+	}
+
+	text := i18n.T(locale, "progress.compare_to", views.Pose(locale, pose), views.Date(locale, from))
+	keyboard := keyboards.CreateCompareToKeyboard(locale, pose, data.Arg(1), dates, page, pages)
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *ProgressHandler) sendCollage(
+	locale string,
+	user *models.User,
+	chatID int64,
+	pose string,
+	fromValue string,
+	toValue string,
+) error {
+	from, fromErr := time.Parse(views.DateLayout, fromValue)
+	to, toErr := time.Parse(views.DateLayout, toValue)
+	if fromErr != nil || toErr != nil || !slices.Contains(keyboards.Poses, pose) {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	before, err := database.FindProgressPhoto(user.ID, pose, from, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "progress.empty"))
+		return nil
+	}
+	after, err := database.FindProgressPhoto(user.ID, pose, to, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "progress.empty"))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mediaTimeout)
+	defer cancel()
+
+	caption := i18n.T(
+		locale, "progress.collage",
+		views.Pose(locale, pose), views.Date(locale, from), views.Date(locale, to),
+	)
+	if err := h.media.SendProgressCollage(ctx, chatID, before, after, caption); err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send progress collage")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.progress_send"))
+		return err
+	}
+	return nil
+}
+
+func (h *ProgressHandler) edit(
+	chatID int64,
+	messageID int,
+	text string,
+	keyboard *tgbotapi.InlineKeyboardMarkup,
+) error {
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	editMsg.ReplyMarkup = keyboard
+
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to edit progress message")
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+package callbacks
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var progressions = map[string]bool{
+	models.ProgressionLinear: true,
+	models.ProgressionDouble: true,
+	models.ProgressionRPE:    true,
+}
+
+// ProgressionHandler switches the progression scheme used for session
+// recommendations.
+type ProgressionHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewProgressionHandler(bot sender.Sender, database *gorm.DB) *ProgressionHandler {
+	return &ProgressionHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *ProgressionHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	progression := data.Arg(0)
+	if data.Action != "set" || !progressions[progression] {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+			"args":    data.Args,
+		}).Error("Invalid progression callback")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	if err := database.UpdateUserProgression(userID, progression, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.progression_update"))
+		return nil
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"chat_id":     chatID,
+		"progression": progression,
+	}).Info("User progression updated")
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, i18n.T(locale, "progression.updated"))
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send progression update confirmation")
+	}
+	return err
+}
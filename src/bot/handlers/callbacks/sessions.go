@@ -0,0 +1,420 @@
+package callbacks
+
+import (
+	"strconv"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/achievements"
+	"workouts_bot/src/services/coaching"
+	"workouts_bot/src/services/programs"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// SessionHandler runs a workout session: starting it from a template,
+// logging or skipping sets, accepting progression recommendations and
+// finishing it.
+type SessionHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+	cfg      *config.Config
+	codec    *callbackdata.Codec
+}
+
+func NewSessionHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	states *state.Store,
+	cfg *config.Config,
+	codec *callbackdata.Codec,
+) *SessionHandler {
+	return &SessionHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+		cfg:      cfg,
+		codec:    codec,
+	}
+}
+
+func (h *SessionHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"action":  data.Action,
+		"args":    data.Args,
+	}).Info("Session callback received")
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	id, err := uuid.Parse(data.Arg(0))
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	switch data.Action {
+	case "start":
+		return h.start(locale, user, chatID, messageID, id)
+	case "open", "finish":
+		session, err := database.GetWorkoutSession(id, user.ID, h.database)
+		if err != nil || session.FinishedAt != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+			return nil
+		}
+		if data.Action == "finish" {
+			return h.finish(locale, chatID, messageID, session)
+		}
+		return h.open(locale, user, chatID, messageID, session)
+	case "complete", "skip":
+		number, _ := data.IntArg(1)
+		set := &models.SessionSet{Skipped: data.Action == "skip"}
+		if rpe, err := strconv.ParseFloat(data.Arg(2), 64); err == nil {
+			set.RPE = rpe
+		}
+		return h.logSet(locale, user, chatID, messageID, id, number, set)
+	case "other", "cardio":
+		h.states.Set(userID, messages.SessionSetState, map[string]string{
+			"session_exercise_id": id.String(),
+			"number":              data.Arg(1),
+			"message_id":          strconv.Itoa(messageID),
+			"cardio":              strconv.FormatBool(data.Action == "cardio"),
+		})
+		question := "sessions.ask_set"
+		if data.Action == "cardio" {
+			question = "cardio.ask_session"
+		}
+		msg := tgbotapi.NewMessage(chatID, i18n.T(locale, question))
+		_, err := h.bot.Send(msg)
+		return err
+	case "sub":
+		return h.askSubSet(locale, user, chatID, messageID, id, data.Arg(1))
+	case "accept":
+		return h.accept(locale, user, chatID, messageID, id)
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown session action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+}
+
+// start begins a session from the template unless one is already running,
+// in which case the running one is shown instead.
+func (h *SessionHandler) start(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	templateID uuid.UUID,
+) error {
+	active, err := database.FindActiveSession(user.ID, h.database)
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "sessions.already_active", active.Name))
+		return h.open(locale, user, chatID, messageID, active)
+	}
+
+	template, err := database.GetWorkoutTemplate(templateID, user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return nil
+	}
+
+	session, err := database.StartWorkoutSession(template, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":     user.TelegramID,
+		"session_id":  session.ID,
+		"template_id": template.ID,
+	}).Info("Workout session started")
+
+	session, err = database.GetWorkoutSession(session.ID, user.ID, h.database)
+	if err != nil {
+		return err
+	}
+	return h.open(locale, user, chatID, messageID, session)
+}
+
+// logSet records set number for the exercise. Sets completed with a
+// button get the set's target reps and weight. Presses for a set that was
+// already logged only refresh the message.
+func (h *SessionHandler) logSet(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	sessionExerciseID uuid.UUID,
+	number int,
+	set *models.SessionSet,
+) error {
+	exercise, session, err := database.GetSessionExercise(sessionExerciseID, user.ID, h.database)
+	if err != nil || session.FinishedAt != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+		return nil
+	}
+
+	if number == exercise.Logged()+1 && !exercise.Done() {
+		target := exercise.Target(number)
+		switch {
+		case exercise.Intervals():
+			if !set.Skipped {
+				set.Seconds = exercise.WorkSeconds
+			}
+		case !set.Skipped:
+			set.Reps = target.Reps
+			set.Weight = target.Weight
+		}
+		set.Warmup = target.Warmup
+		logged, err := database.LogSessionSet(exercise, set, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+			return err
+		}
+		// A concurrent press logged the set first; show it as saved.
+		if !logged {
+			session, err = database.GetWorkoutSession(session.ID, user.ID, h.database)
+			if err != nil {
+				handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+				return err
+			}
+		}
+	}
+
+	// The set may have finished the exercise and brought up the next one.
+	return h.open(locale, user, chatID, messageID, session)
+}
+
+// askSubSet asks for the reps, and the weight of a drop set, of a set that
+// extends the exercise's last working set.
+func (h *SessionHandler) askSubSet(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	sessionExerciseID uuid.UUID,
+	kind string,
+) error {
+	if kind != models.SetKindDrop && kind != models.SetKindRestPause {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	exercise, session, err := database.GetSessionExercise(sessionExerciseID, user.ID, h.database)
+	if err != nil || session.FinishedAt != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+		return nil
+	}
+	parent := exercise.LastWorkSet()
+	if parent == nil {
+		return h.show(locale, user, chatID, messageID, session)
+	}
+
+	h.states.Set(user.TelegramID, messages.SessionSetState, map[string]string{
+		"session_exercise_id": exercise.ID.String(),
+		"kind":                kind,
+		"message_id":          strconv.Itoa(messageID),
+	})
+	weight := messages.SubSetWeight(exercise, parent, kind)
+	text := i18n.T(locale, "sessions.ask_"+kind, views.ExerciseName(locale, &exercise.Exercise), views.Weight(weight))
+	_, err = h.bot.Send(tgbotapi.NewMessage(chatID, text))
+	return err
+}
+
+// accept applies the current recommendation to the exercise and, for
+// sessions started from a template, to the template so the next session
+// starts from the new targets. The recommendation is computed again rather
+// than taken from the button.
+func (h *SessionHandler) accept(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	sessionExerciseID uuid.UUID,
+) error {
+	exercise, session, err := database.GetSessionExercise(sessionExerciseID, user.ID, h.database)
+	if err != nil || session.FinishedAt != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+		return nil
+	}
+
+	recommendation := training.RecommendFor(user, exercise, h.database)
+	if recommendation == nil {
+		return h.show(locale, user, chatID, messageID, session)
+	}
+
+	err = database.UpdateSessionExerciseTargets(
+		exercise, recommendation.Sets, recommendation.Reps, recommendation.Weight, h.database,
+	)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+		return err
+	}
+	if session.TemplateID != nil {
+		err := database.UpdateTemplateTargets(
+			*session.TemplateID, exercise.ExerciseID,
+			recommendation.Sets, recommendation.Reps, recommendation.Weight, h.database,
+		)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+			return err
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":             user.TelegramID,
+		"session_exercise_id": exercise.ID,
+		"action":              recommendation.Action,
+	}).Info("Progression recommendation accepted")
+
+	return h.show(locale, user, chatID, messageID, session)
+}
+
+func (h *SessionHandler) finish(
+	locale string,
+	chatID int64,
+	messageID int,
+	session *models.WorkoutSession,
+) error {
+	finished, err := database.FinishWorkoutSession(session, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+		return err
+	}
+	// A concurrent press finished it and runs the follow-ups, summary
+	// included; this press is only answered.
+	if !finished {
+		logger.WithFields(logrus.Fields{
+			"chat_id":    chatID,
+			"session_id": session.ID,
+		}).Info("Workout session already finished")
+		return nil
+	}
+
+	logger.WithFields(logrus.Fields{
+		"chat_id":    chatID,
+		"session_id": session.ID,
+	}).Info("Workout session finished")
+
+	text := views.SessionSummary(locale, session)
+	text += views.NewOneRepMaxes(locale, training.RecordSessionMaxes(session, h.database))
+	program, enrollment, cycled, err := programs.Advance(session, h.database)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_id": session.ID,
+			"error":      err,
+		}).Error("Failed to advance program")
+	} else if enrollment != nil {
+		text += views.ProgramAdvanced(locale, program, enrollment, cycled)
+	}
+	earned, err := achievements.Award(session.UserID, h.database)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_id": session.ID,
+			"error":      err,
+		}).Error("Failed to award achievements")
+	}
+	text += views.NewAchievements(locale, earned)
+	h.notifyCoach(session)
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	_, err = h.bot.Send(editMsg)
+	return err
+}
+
+// notifyCoach tells the client's coach about the finished session.
+func (h *SessionHandler) notifyCoach(session *models.WorkoutSession) {
+	link, err := coaching.ClientCoach(session.UserID, h.cfg, h.database)
+	if err != nil || link == nil {
+		return
+	}
+
+	locale := i18n.UserLocale(&link.Coach)
+	keyboard, err := keyboards.CreateCoachSessionLinkKeyboard(locale, session.ID, h.codec)
+	if err != nil {
+		return
+	}
+
+	msg := tgbotapi.NewMessage(link.Coach.TelegramID, views.SessionFinishedNotice(locale, &link.Client, session))
+	msg.ReplyMarkup = keyboard
+	if _, err := h.bot.Send(msg); err != nil {
+		logger.WithFields(logrus.Fields{
+			"coach_id":   link.CoachID,
+			"session_id": session.ID,
+			"error":      err,
+		}).Warn("Failed to notify coach")
+	}
+}
+
+// open shows the session after planning the warm-ups of the exercise it
+// is on, the first time that exercise comes up.
+func (h *SessionHandler) open(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	session *models.WorkoutSession,
+) error {
+	training.PrepareWarmups(user, session.Current(), h.database)
+	return h.show(locale, user, chatID, messageID, session)
+}
+
+func (h *SessionHandler) show(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	session *models.WorkoutSession,
+) error {
+	text, keyboard, err := messages.SessionView(locale, user, session, h.codec, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+		return err
+	}
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	editMsg.ReplyMarkup = &keyboard
+
+	_, err = h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id":    chatID,
+			"session_id": session.ID,
+			"error":      err,
+		}).Error("Failed to show workout session")
+	}
+	return err
+}
@@ -1,9 +1,11 @@
 package callbacks
 
 import (
-	"strings"
+	"workouts_bot/src/bot/callbackdata"
 	"workouts_bot/src/bot/handlers"
 	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/i18n"
 	"workouts_bot/src/logger"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -11,64 +13,57 @@ import (
 	"gorm.io/gorm"
 )
 
-const SettingsCallbackType = "settings"
-
 type SettingsHandler struct {
-	bot      *tgbotapi.BotAPI
+	bot      sender.Sender
 	database *gorm.DB
 }
 
-func NewSettingsHandler(bot *tgbotapi.BotAPI, database *gorm.DB) *SettingsHandler {
+func NewSettingsHandler(bot sender.Sender, database *gorm.DB) *SettingsHandler {
 	return &SettingsHandler{
 		bot:      bot,
 		database: database,
 	}
 }
 
-func (h *SettingsHandler) Handle(update tgbotapi.Update) error {
+func (h *SettingsHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
 	callbackQuery := update.CallbackQuery
 	userID := callbackQuery.From.ID
 	chatID := callbackQuery.Message.Chat.ID
 	messageID := callbackQuery.Message.MessageID
-	data := callbackQuery.Data
-	parts := strings.Split(data, ":")
+	setting := data.Action
+	locale := handlers.Locale(callbackQuery.From, h.database)
 
 	logger.WithFields(logrus.Fields{
 		"user_id":    userID,
 		"chat_id":    chatID,
 		"message_id": messageID,
-		"data":       data,
+		"setting":    setting,
 	}).Info("Settings callback received")
 
-	if len(parts) < 2 {
-		logger.WithFields(logrus.Fields{
-			"user_id": userID,
-			"chat_id": chatID,
-			"data":    data,
-		}).Error("Invalid settings callback format")
-		handlers.SendErrorMessage(h.bot, chatID, "Неверный формат команды")
-		return nil
-	}
-
-	setting := parts[1]
-
 	switch setting {
+	case "goal":
+		return h.showGoalMenu(locale, userID, chatID, messageID)
 	case "experience":
-		return h.showExperienceMenu(userID, chatID, messageID)
-	case "experience_back":
-		return h.showMainSettingsMenu(userID, chatID, messageID)
+		return h.showExperienceMenu(locale, userID, chatID, messageID)
+	case "progression":
+		return h.showProgressionMenu(locale, userID, chatID, messageID)
+	case "language":
+		return h.showLanguageMenu(locale, userID, chatID, messageID)
+	case "back", "experience_back":
+		return h.showMainSettingsMenu(locale, userID, chatID, messageID)
 	default:
 		logger.WithFields(logrus.Fields{
 			"user_id": userID,
 			"chat_id": chatID,
 			"setting": setting,
 		}).Error("Unknown settings option")
-		handlers.SendErrorMessage(h.bot, chatID, "Неизвестная настройка")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.unknown_setting"))
 		return nil
 	}
 }
 
 func (h *SettingsHandler) showMainSettingsMenu(
+	locale string,
 	userID int64,
 	chatID int64,
 	messageID int,
@@ -78,10 +73,10 @@ func (h *SettingsHandler) showMainSettingsMenu(
 		"chat_id": chatID,
 	}).Info("Showing main settings menu")
 
-	text := "⚙️ Настройки"
+	text := i18n.T(locale, "settings.title")
 
 	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
-	keyboard := keyboards.CreateSettingsKeyboard()
+	keyboard := keyboards.CreateSettingsKeyboard(locale)
 	editMsg.ReplyMarkup = &keyboard
 
 	_, err := h.bot.Send(editMsg)
@@ -95,7 +90,64 @@ func (h *SettingsHandler) showMainSettingsMenu(
 	return err
 }
 
+func (h *SettingsHandler) showGoalMenu(
+	locale string,
+	userID int64,
+	chatID int64,
+	messageID int,
+) error {
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+	}).Info("Showing goal menu")
+
+	text := i18n.T(locale, "settings.goal_question")
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	keyboard := keyboards.CreateGoalKeyboard(locale)
+	editMsg.ReplyMarkup = &keyboard
+
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send goal menu")
+	}
+	return err
+}
+
+func (h *SettingsHandler) showProgressionMenu(
+	locale string,
+	userID int64,
+	chatID int64,
+	messageID int,
+) error {
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+	}).Info("Showing progression menu")
+
+	text := i18n.T(locale, "settings.progression_question")
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	keyboard := keyboards.CreateProgressionKeyboard(locale)
+	editMsg.ReplyMarkup = &keyboard
+
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send progression menu")
+	}
+	return err
+}
+
 func (h *SettingsHandler) showExperienceMenu(
+	locale string,
 	userID int64,
 	chatID int64,
 	messageID int,
@@ -105,10 +157,10 @@ func (h *SettingsHandler) showExperienceMenu(
 		"chat_id": chatID,
 	}).Info("Showing experience menu")
 
-	text := "📈 Какой у вас уровень опыта в тренировках?"
+	text := i18n.T(locale, "settings.experience_question")
 
 	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
-	keyboard := keyboards.CreateExperienceLevelKeyboard()
+	keyboard := keyboards.CreateExperienceLevelKeyboard(locale)
 	editMsg.ReplyMarkup = &keyboard
 
 	_, err := h.bot.Send(editMsg)
@@ -121,3 +173,31 @@ func (h *SettingsHandler) showExperienceMenu(
 	}
 	return err
 }
+
+func (h *SettingsHandler) showLanguageMenu(
+	locale string,
+	userID int64,
+	chatID int64,
+	messageID int,
+) error {
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+	}).Info("Showing language menu")
+
+	text := i18n.T(locale, "settings.language_question")
+
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	keyboard := keyboards.CreateLanguageKeyboard(locale)
+	editMsg.ReplyMarkup = &keyboard
+
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send language menu")
+	}
+	return err
+}
@@ -0,0 +1,445 @@
+package callbacks
+
+import (
+	"errors"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/handlers/messages"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/confirmation"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type WorkoutHandler struct {
+	bot           sender.Sender
+	database      *gorm.DB
+	states        *state.Store
+	confirmations *confirmation.Service
+	codec         *callbackdata.Codec
+}
+
+func NewWorkoutHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	states *state.Store,
+	confirmations *confirmation.Service,
+	codec *callbackdata.Codec,
+) *WorkoutHandler {
+	return &WorkoutHandler{
+		bot:           bot,
+		database:      database,
+		states:        states,
+		confirmations: confirmations,
+		codec:         codec,
+	}
+}
+
+func (h *WorkoutHandler) HandleCallback(update tgbotapi.Update, data callbackdata.Data) error {
+	callbackQuery := update.CallbackQuery
+	userID := callbackQuery.From.ID
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	locale := handlers.Locale(callbackQuery.From, h.database)
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"chat_id": chatID,
+		"action":  data.Action,
+		"args":    data.Args,
+	}).Info("Workout callback received")
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	switch data.Action {
+	case "list":
+		return h.showList(locale, user, chatID, messageID)
+	case "create":
+		h.states.Set(userID, messages.WorkoutNameState, nil)
+		return h.ask(chatID, i18n.T(locale, "workouts.ask_name"))
+	case "stats":
+		text, err := messages.StatsText(locale, user, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.stats_load"))
+			return err
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = keyboards.CreateStatsKeyboard(locale)
+		_, err = h.bot.Send(msg)
+		return err
+	case "reset":
+		return h.requestReset(locale, user, chatID, messageID)
+	}
+
+	id, err := uuid.Parse(data.Arg(0))
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	switch data.Action {
+	case "open", "edit", "rename", "add", "pick", "duplicate", "delete":
+		template, err := database.GetWorkoutTemplate(id, user.ID, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+			return nil
+		}
+		return h.handleTemplate(locale, user, chatID, messageID, template, data)
+	case "item", "up", "down", "targets", "remove", "link":
+		item, template, err := database.GetTemplateExercise(id, user.ID, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+			return nil
+		}
+		return h.handleItem(locale, user, chatID, messageID, template, item, data.Action)
+	default:
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"chat_id": chatID,
+			"action":  data.Action,
+		}).Error("Unknown workout action")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+}
+
+func (h *WorkoutHandler) handleTemplate(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	template *models.WorkoutTemplate,
+	data callbackdata.Data,
+) error {
+	switch data.Action {
+	case "open":
+		return h.showTemplate(locale, template, chatID, messageID)
+	case "edit":
+		return h.showEditor(locale, template, chatID, messageID)
+	case "rename":
+		h.states.Set(user.TelegramID, messages.WorkoutRenameState, map[string]string{
+			"template_id": template.ID.String(),
+		})
+		return h.ask(chatID, i18n.T(locale, "workouts.ask_rename", template.Name))
+	case "add":
+		h.states.Set(user.TelegramID, messages.WorkoutExerciseState, map[string]string{
+			"template_id": template.ID.String(),
+		})
+		return h.ask(chatID, i18n.T(locale, "workouts.ask_exercise"))
+	case "pick":
+		return h.pick(locale, user, chatID, messageID, template, data.Arg(1))
+	case "duplicate":
+		name := i18n.T(locale, "workouts.copy_name", template.Name)
+		duplicate, err := database.DuplicateWorkoutTemplate(template, name, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+			return err
+		}
+		duplicate, err = database.GetWorkoutTemplate(duplicate.ID, user.ID, h.database)
+		if err != nil {
+			return err
+		}
+		return h.showTemplate(locale, duplicate, chatID, messageID)
+	default:
+		return h.requestDelete(locale, user, chatID, messageID, template)
+	}
+}
+
+func (h *WorkoutHandler) handleItem(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	template *models.WorkoutTemplate,
+	item *models.TemplateExercise,
+	action string,
+) error {
+	switch action {
+	case "targets":
+		h.states.Set(user.TelegramID, messages.WorkoutTargetsState, map[string]string{
+			"item_id": item.ID.String(),
+		})
+		name := views.ExerciseName(locale, &item.Exercise)
+		return h.ask(chatID, i18n.T(locale, "workouts.ask_targets", name))
+	case "remove":
+		if err := database.RemoveTemplateExercise(item, h.database); err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+			return err
+		}
+		return h.reloadEditor(locale, user, chatID, messageID, template.ID)
+	case "link":
+		if !item.Linked && item.ID == template.Exercises[len(template.Exercises)-1].ID {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.link_last"))
+			return nil
+		}
+		item.Linked = !item.Linked
+		if err := database.UpdateTemplateExercise(item, h.database); err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+			return err
+		}
+	case "up", "down":
+		delta := -1
+		if action == "down" {
+			delta = 1
+		}
+		if err := database.MoveTemplateExercise(template, item.ID, delta, h.database); err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+			return err
+		}
+
+		var err error
+		item, template, err = database.GetTemplateExercise(item.ID, user.ID, h.database)
+		if err != nil {
+			return err
+		}
+	}
+
+	keyboard, err := keyboards.CreateTemplateItemKeyboard(locale, item, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+	return h.edit(chatID, messageID, views.TemplateItem(locale, template, item), &keyboard)
+}
+
+func (h *WorkoutHandler) pick(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	template *models.WorkoutTemplate,
+	exerciseID string,
+) error {
+	id, err := uuid.Parse(exerciseID)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.invalid_format"))
+		return nil
+	}
+
+	exercise, err := database.GetExercise(id, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return nil
+	}
+
+	h.states.Clear(user.TelegramID)
+	if _, err := messages.AddExerciseToTemplate(user, template, exercise, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+	return h.reloadEditor(locale, user, chatID, messageID, template.ID)
+}
+
+func (h *WorkoutHandler) requestDelete(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	template *models.WorkoutTemplate,
+) error {
+	pending, err := h.confirmations.Request(user.TelegramID, messages.DeleteWorkoutAction, template.ID.String())
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	text := i18n.T(locale, "workouts.delete_question", template.Name)
+	keyboard := keyboards.CreateConfirmationKeyboard(locale, pending.ID.String())
+	return h.edit(chatID, messageID, text, &keyboard)
+}
+
+func (h *WorkoutHandler) requestReset(locale string, user *models.User, chatID int64, messageID int) error {
+	pending, err := h.confirmations.Request(user.TelegramID, messages.ResetStatsAction, user.ID.String())
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.stats_reset"))
+		return err
+	}
+
+	keyboard := keyboards.CreateConfirmationKeyboard(locale, pending.ID.String())
+	return h.edit(chatID, messageID, i18n.T(locale, "stats.reset_question"), &keyboard)
+}
+
+func (h *WorkoutHandler) showList(locale string, user *models.User, chatID int64, messageID int) error {
+	templates, err := database.ListWorkoutTemplates(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return err
+	}
+
+	session, err := database.FindActiveSession(user.ID, h.database)
+	if err != nil {
+		return err
+	}
+
+	keyboard, err := keyboards.CreateWorkoutListKeyboard(locale, templates, session, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return err
+	}
+	return h.edit(chatID, messageID, messages.WorkoutListText(locale, templates), &keyboard)
+}
+
+func (h *WorkoutHandler) showTemplate(
+	locale string,
+	template *models.WorkoutTemplate,
+	chatID int64,
+	messageID int,
+) error {
+	keyboard, err := keyboards.CreateWorkoutKeyboard(locale, template.ID, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return err
+	}
+	return h.edit(chatID, messageID, views.WorkoutTemplate(locale, template), &keyboard)
+}
+
+func (h *WorkoutHandler) showEditor(
+	locale string,
+	template *models.WorkoutTemplate,
+	chatID int64,
+	messageID int,
+) error {
+	keyboard, err := keyboards.CreateWorkoutEditKeyboard(locale, template, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+	return h.edit(chatID, messageID, messages.WorkoutEditorText(locale, template), &keyboard)
+}
+
+func (h *WorkoutHandler) reloadEditor(
+	locale string,
+	user *models.User,
+	chatID int64,
+	messageID int,
+	templateID uuid.UUID,
+) error {
+	template, err := database.GetWorkoutTemplate(templateID, user.ID, h.database)
+	if err != nil {
+		return err
+	}
+	return h.showEditor(locale, template, chatID, messageID)
+}
+
+func (h *WorkoutHandler) ask(chatID int64, text string) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func (h *WorkoutHandler) edit(
+	chatID int64,
+	messageID int,
+	text string,
+	keyboard *tgbotapi.InlineKeyboardMarkup,
+) error {
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	editMsg.ReplyMarkup = keyboard
+
+	_, err := h.bot.Send(editMsg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to edit workout message")
+	}
+	return err
+}
+
+// WorkoutDeleteConfirmation deletes a template once its owner confirms.
+// The payload is the template ID.
+type WorkoutDeleteConfirmation struct {
+	database *gorm.DB
+}
+
+func NewWorkoutDeleteConfirmation(database *gorm.DB) *WorkoutDeleteConfirmation {
+	return &WorkoutDeleteConfirmation{database: database}
+}
+
+func (c *WorkoutDeleteConfirmation) Action() confirmation.Action {
+	return confirmation.Action{Execute: c.execute}
+}
+
+func (c *WorkoutDeleteConfirmation) execute(
+	pending *models.PendingAction,
+	chatID int64,
+	locale string,
+) (string, error) {
+	templateID, err := uuid.Parse(pending.Payload)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := database.GetUserByTelegramID(pending.TelegramID, c.database)
+	if err != nil {
+		return "", err
+	}
+
+	template, err := database.GetWorkoutTemplate(templateID, user.ID, c.database)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return i18n.T(locale, "error.workout_not_found"), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if err := database.DeleteWorkoutTemplate(template.ID, user.ID, c.database); err != nil {
+		return "", err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":     pending.TelegramID,
+		"template_id": template.ID,
+	}).Info("Workout template deleted")
+
+	return i18n.T(locale, "workouts.deleted", template.Name), nil
+}
+
+// StatsResetConfirmation deletes the history the statistics are totalled
+// from once the user confirms. The payload is the user ID.
+type StatsResetConfirmation struct {
+	database *gorm.DB
+}
+
+func NewStatsResetConfirmation(database *gorm.DB) *StatsResetConfirmation {
+	return &StatsResetConfirmation{database: database}
+}
+
+func (c *StatsResetConfirmation) Action() confirmation.Action {
+	return confirmation.Action{Execute: c.execute}
+}
+
+func (c *StatsResetConfirmation) execute(
+	pending *models.PendingAction,
+	chatID int64,
+	locale string,
+) (string, error) {
+	user, err := database.GetUserByTelegramID(pending.TelegramID, c.database)
+	if err != nil {
+		return "", err
+	}
+	if user.ID.String() != pending.Payload {
+		return "", errors.New("stats reset requested for another user")
+	}
+
+	if err := database.ResetStats(user.ID, c.database); err != nil {
+		return "", err
+	}
+
+	logger.WithField("user_id", pending.TelegramID).Info("Stats reset")
+
+	return i18n.T(locale, "stats.reset_done"), nil
+}
@@ -1,12 +1,43 @@
 package handlers
 
-import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
 
 type Handler interface {
 	Handle(update tgbotapi.Update) error
 }
 
-func SendErrorMessage(bot *tgbotapi.BotAPI, chatID int64, errorText string) {
+// CallbackHandler handles inline keyboard presses whose payload has already
+// been decoded by the bot.
+type CallbackHandler interface {
+	HandleCallback(update tgbotapi.Update, data callbackdata.Data) error
+}
+
+// Locale returns the locale for the sender of an update: the language chosen
+// in settings if any, otherwise the Telegram client language.
+func Locale(from *tgbotapi.User, db *gorm.DB) string {
+	if from == nil {
+		return i18n.Default
+	}
+
+	user, err := database.FindUserByTelegramID(from.ID, db)
+	if err == nil && user != nil {
+		if user.Language == "" && user.LanguageCode == "" {
+			user.LanguageCode = from.LanguageCode
+		}
+		return i18n.UserLocale(user)
+	}
+	return i18n.Resolve(from.LanguageCode)
+}
+
+func SendErrorMessage(bot sender.Sender, chatID int64, errorText string) {
 	msg := tgbotapi.NewMessage(chatID, "❌ "+errorText)
 	_, _ = bot.Send(msg)
 }
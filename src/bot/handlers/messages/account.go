@@ -0,0 +1,58 @@
+package messages
+
+import (
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/services/confirmation"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
+
+const DeleteAccountAction = "delete_account"
+
+// DeleteAccountHandler serves /delete_me, asking to confirm deleting the
+// account and everything in it.
+type DeleteAccountHandler struct {
+	bot           sender.Sender
+	database      *gorm.DB
+	confirmations *confirmation.Service
+}
+
+func NewDeleteAccountHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	confirmations *confirmation.Service,
+) *DeleteAccountHandler {
+	return &DeleteAccountHandler{
+		bot:           bot,
+		database:      database,
+		confirmations: confirmations,
+	}
+}
+
+func (h *DeleteAccountHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	user, err := database.GetUserByTelegramID(message.From.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	pending, err := h.confirmations.Request(user.TelegramID, DeleteAccountAction, user.ID.String())
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.account_delete_failed"))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "account.delete_ask"))
+	msg.ReplyMarkup = keyboards.CreateDeleteAccountKeyboard(locale, pending.ID.String())
+	_, err = h.bot.Send(msg)
+	return err
+}
@@ -0,0 +1,195 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"time"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/activities"
+	"workouts_bot/src/services/imports"
+	"workouts_bot/src/services/media"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// duplicateWindow is how close two start times must be for the activities
+// to count as the same one, recorded by different devices or exported twice.
+const duplicateWindow = time.Minute
+
+// ImportHandler reads files sent to the bot: GPX, TCX and FIT activities
+// into cardio entries and CSV exports of other apps into sessions. While an
+// export waits for an exercise name to be matched, typed text searches the
+// catalog. /import explains how.
+type ImportHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+	media    *media.Service
+	codec    *callbackdata.Codec
+}
+
+func NewImportHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	states *state.Store,
+	media *media.Service,
+	codec *callbackdata.Codec,
+) *ImportHandler {
+	return &ImportHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+		media:    media,
+		codec:    codec,
+	}
+}
+
+// IsImportFile reports whether the message carries a file ImportHandler
+// reads.
+func IsImportFile(message *tgbotapi.Message) bool {
+	if message.Document == nil {
+		return false
+	}
+	return activities.Supported(message.Document.FileName) || imports.Supported(message.Document.FileName)
+}
+
+func (h *ImportHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	current, inDialog := h.states.Get(message.From.ID)
+	if !IsImportFile(message) {
+		if inDialog && current.Name == ImportAliasState {
+			return h.searchAlias(locale, chatID, current.Data["name"], message.Text)
+		}
+		_, err := h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "import.help")))
+		return err
+	}
+	h.states.Clear(message.From.ID)
+
+	user, err := database.GetUserByTelegramID(message.From.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	document := message.Document
+	if imports.Supported(document.FileName) {
+		if document.FileSize > imports.MaxFileSize {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_too_large"))
+			return nil
+		}
+		return h.ImportHistory(locale, user, chatID, document.FileID)
+	}
+	if document.FileSize > activities.MaxFileSize {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_too_large"))
+		return nil
+	}
+
+	activity, err := h.read(document)
+	if errors.Is(err, activities.ErrInvalid) || errors.Is(err, activities.ErrEmpty) {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_format"))
+		return nil
+	}
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+
+	slug := activity.Sport
+	if slug == "" {
+		slug = activities.GuessSport(activity)
+	}
+	exercises, err := database.ListExercisesBySlugs([]string{slug}, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+	exercise, ok := exercises[slug]
+	if !ok {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		return nil
+	}
+
+	entry := &models.CardioEntry{
+		UserID:          user.ID,
+		ExerciseID:      exercise.ID,
+		Exercise:        exercise,
+		StartedAt:       activity.StartedAt,
+		DurationSeconds: int(activity.Duration.Seconds()),
+		DistanceMeters:  activity.Distance,
+		HeartRate:       activity.AverageHeartRate(),
+		Source:          activities.Format(document.FileName),
+		ElevationGain:   activity.ElevationGain,
+		MaxHeartRate:    activity.MaxHeartRate(),
+		HeartRates:      activity.HeartRates,
+	}
+	duplicate, err := database.ImportCardioEntry(entry, duplicateWindow, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+	if duplicate != nil {
+		_, err = h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "import.duplicate", views.CardioEntry(locale, duplicate))))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":     user.TelegramID,
+		"exercise_id": exercise.ID,
+		"source":      entry.Source,
+	}).Info("Cardio activity imported")
+
+	_, err = h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "import.saved", views.ImportedActivity(locale, entry))))
+	return err
+}
+
+// searchAlias offers the catalog exercises matching the text for the name
+// being matched.
+func (h *ImportHandler) searchAlias(locale string, chatID int64, name string, text string) error {
+	exercises, err := database.SearchExercises(text, aliasCandidateLimit, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+	if len(exercises) == 0 {
+		_, err := h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "workouts.exercise_not_found", text)))
+		return err
+	}
+
+	keyboard, err := keyboards.CreateImportAliasKeyboard(locale, exercises, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "import.choose_alias", name))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+func (h *ImportHandler) read(document *tgbotapi.Document) (*activities.Activity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	file, err := h.media.Open(ctx, document.FileID)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return activities.Parse(document.FileName, file)
+}
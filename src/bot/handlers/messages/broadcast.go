@@ -0,0 +1,114 @@
+package messages
+
+import (
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/confirmation"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	BroadcastTextState = "broadcast_text"
+	BroadcastAction    = "broadcast"
+)
+
+type BroadcastHandler struct {
+	bot           sender.Sender
+	database      *gorm.DB
+	config        *config.Config
+	states        *state.Store
+	confirmations *confirmation.Service
+}
+
+func NewBroadcastHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	cfg *config.Config,
+	states *state.Store,
+	confirmations *confirmation.Service,
+) *BroadcastHandler {
+	return &BroadcastHandler{
+		bot:           bot,
+		database:      database,
+		config:        cfg,
+		states:        states,
+		confirmations: confirmations,
+	}
+}
+
+func (h *BroadcastHandler) Handle(update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	locale := handlers.Locale(update.Message.From, h.database)
+
+	if !h.config.IsAdmin(userID) {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"user_id": userID,
+		}).Warn("Non-admin tried to broadcast")
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.admin_only"))
+		return nil
+	}
+
+	if update.Message.IsCommand() {
+		return h.askForText(locale, userID, chatID)
+	}
+	return h.preview(locale, update)
+}
+
+func (h *BroadcastHandler) askForText(locale string, userID int64, chatID int64) error {
+	h.states.Set(userID, BroadcastTextState, nil)
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "broadcast.ask_text"))
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func (h *BroadcastHandler) preview(locale string, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	text := update.Message.Text
+
+	if text == "" {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.broadcast_text_only"))
+		return nil
+	}
+	h.states.Clear(userID)
+
+	broadcast := &models.Broadcast{
+		AdminTelegramID: userID,
+		Text:            text,
+		Status:          models.BroadcastStatusDraft,
+	}
+	if err := database.CreateBroadcast(broadcast, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.broadcast_save"))
+		return err
+	}
+
+	pending, err := h.confirmations.Request(userID, BroadcastAction, broadcast.ID.String())
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.broadcast_save"))
+		return err
+	}
+
+	previewMsg := tgbotapi.NewMessage(chatID, text)
+	if _, err := h.bot.Send(previewMsg); err != nil {
+		return err
+	}
+
+	confirmMsg := tgbotapi.NewMessage(chatID, i18n.T(locale, "broadcast.confirm_question"))
+	confirmMsg.ReplyMarkup = keyboards.CreateConfirmationKeyboard(locale, pending.ID.String())
+	_, err = h.bot.Send(confirmMsg)
+	return err
+}
@@ -0,0 +1,262 @@
+package messages
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	CardioState = "cardio_entry"
+
+	ResetStatsAction = "reset_stats"
+)
+
+const (
+	recentCardioLimit = 10
+	statsWeeks        = 4
+
+	maxCardioDuration = 24 * time.Hour
+	maxDistanceKm     = 1000
+	maxSpeedKmh       = 100
+	minHeartRate      = 30
+	maxHeartRate      = 230
+)
+
+var ErrInvalidCardio = errors.New("invalid cardio entry")
+
+// cardioPattern accepts "duration [km] [heart rate] [@effort]": "30",
+// "25:30 5", "1:02:03 21,1 152 @8". A bare duration is in minutes.
+var cardioPattern = regexp.MustCompile(
+	`^(\d{1,4}(?::\d{1,2}){0,2})(?:\s+(\d+(?:[.,]\d+)?))?(?:\s+(\d{2,3}))?(?:\s*@\s*(\d{1,2}))?$`,
+)
+
+// CardioHandler serves /cardio, which lists recent activities and offers
+// to log a new one, and saves the activity the user typed.
+type CardioHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+	codec    *callbackdata.Codec
+}
+
+func NewCardioHandler(bot sender.Sender, database *gorm.DB, states *state.Store, codec *callbackdata.Codec) *CardioHandler {
+	return &CardioHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+		codec:    codec,
+	}
+}
+
+func (h *CardioHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	userID := message.From.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	if current, ok := h.states.Get(userID); ok && current.Name == CardioState {
+		return h.save(locale, user, chatID, current.Data["exercise_id"], message.Text)
+	}
+
+	entries, err := database.ListRecentCardioEntries(user.ID, recentCardioLimit, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.cardio_load"))
+		return err
+	}
+	exercises, err := database.ListCardioExercises(h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.cardio_load"))
+		return err
+	}
+	keyboard, err := keyboards.CreateCardioKeyboard(locale, exercises, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.cardio_load"))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, views.CardioLog(locale, entries)+i18n.T(locale, "cardio.choose"))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+func (h *CardioHandler) save(
+	locale string,
+	user *models.User,
+	chatID int64,
+	exerciseID string,
+	text string,
+) error {
+	entry, err := ParseCardio(text)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.cardio_format"))
+		return nil
+	}
+	h.states.Clear(user.TelegramID)
+
+	id, err := uuid.Parse(exerciseID)
+	if err != nil {
+		return err
+	}
+	exercise, err := database.GetExercise(id, h.database)
+	if err != nil || !exercise.Cardio() {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		return nil
+	}
+
+	entry.UserID = user.ID
+	entry.ExerciseID = exercise.ID
+	entry.Exercise = *exercise
+	if err := database.SaveCardioEntry(entry, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.cardio_save"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":     user.TelegramID,
+		"exercise_id": exercise.ID,
+	}).Info("Cardio entry saved")
+
+	_, err = h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "cardio.saved", views.CardioEntry(locale, entry))))
+	return err
+}
+
+// ParseCardio reads "duration [km] [heart rate] [@effort]" into an entry
+// that started the duration ago. A distance of 0 means not measured.
+func ParseCardio(text string) (*models.CardioEntry, error) {
+	match := cardioPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return nil, ErrInvalidCardio
+	}
+
+	duration, err := parseDuration(match[1])
+	if err != nil || duration <= 0 || duration > maxCardioDuration {
+		return nil, ErrInvalidCardio
+	}
+	entry := &models.CardioEntry{
+		StartedAt:       time.Now().Add(-duration),
+		DurationSeconds: int(duration.Seconds()),
+	}
+
+	if match[2] != "" {
+		km, err := strconv.ParseFloat(strings.ReplaceAll(match[2], ",", "."), 64)
+		if err != nil || km > maxDistanceKm {
+			return nil, ErrInvalidCardio
+		}
+		entry.DistanceMeters = km * 1000
+		if training.Speed(entry) > maxSpeedKmh {
+			return nil, ErrInvalidCardio
+		}
+	}
+	if match[3] != "" {
+		heartRate, _ := strconv.Atoi(match[3])
+		if heartRate < minHeartRate || heartRate > maxHeartRate {
+			return nil, ErrInvalidCardio
+		}
+		entry.HeartRate = heartRate
+	}
+	if match[4] != "" {
+		effort, _ := strconv.Atoi(match[4])
+		if effort < 1 || effort > 10 {
+			return nil, ErrInvalidCardio
+		}
+		entry.Effort = effort
+	}
+	return entry, nil
+}
+
+// parseDuration reads minutes, "mm:ss" or "h:mm:ss".
+func parseDuration(text string) (time.Duration, error) {
+	parts := strings.Split(text, ":")
+	if len(parts) == 1 {
+		minutes, err := strconv.Atoi(parts[0])
+		return time.Duration(minutes) * time.Minute, err
+	}
+
+	var seconds int
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || (i > 0 && value >= 60) {
+			return 0, ErrInvalidCardio
+		}
+		seconds = seconds*60 + value
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// StatsHandler serves /stats.
+type StatsHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewStatsHandler(bot sender.Sender, database *gorm.DB) *StatsHandler {
+	return &StatsHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *StatsHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	user, err := database.GetUserByTelegramID(message.From.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	text, err := StatsText(locale, user, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.stats_load"))
+		return err
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboards.CreateStatsKeyboard(locale)
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// StatsText totals the sessions and cardio of the last weeks.
+func StatsText(locale string, user *models.User, db *gorm.DB) (string, error) {
+	now := time.Now()
+	since := training.WeekStart(now).AddDate(0, 0, -7*(statsWeeks-1))
+
+	sessions, err := database.ListFinishedSessions(user.ID, since, db)
+	if err != nil {
+		return "", err
+	}
+	entries, err := database.ListCardioEntries(user.ID, since, db)
+	if err != nil {
+		return "", err
+	}
+	return views.WeeklyStats(locale, training.Weekly(sessions, entries, now, statsWeeks)), nil
+}
@@ -0,0 +1,320 @@
+package messages
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/coaching"
+	"workouts_bot/src/services/confirmation"
+	"workouts_bot/src/services/programs"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	CoachCommentState = "coach_comment"
+	CoachInviteAction = "coach_invite"
+)
+
+const (
+	// missedWorkoutInterval is how often finished days are checked for
+	// program workouts clients missed.
+	missedWorkoutInterval = time.Hour
+	maxCommentLength      = 1000
+)
+
+// CoachHandler serves /coach: coaches get their clients and invite link,
+// clients see who their coach is. It also saves the comment a coach types
+// on a client's session. Coaches are the users listed in the config, and
+// every access to a client checks that the client is the coach's.
+type CoachHandler struct {
+	bot           sender.Sender
+	database      *gorm.DB
+	cfg           *config.Config
+	states        *state.Store
+	confirmations *confirmation.Service
+	botName       string
+	codec         *callbackdata.Codec
+}
+
+func NewCoachHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	cfg *config.Config,
+	states *state.Store,
+	confirmations *confirmation.Service,
+	botName string,
+	codec *callbackdata.Codec,
+) *CoachHandler {
+	return &CoachHandler{
+		bot:           bot,
+		database:      database,
+		cfg:           cfg,
+		states:        states,
+		confirmations: confirmations,
+		botName:       botName,
+		codec:         codec,
+	}
+}
+
+func (h *CoachHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	userID := message.From.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	if current, ok := h.states.Get(userID); ok && current.Name == CoachCommentState {
+		return h.saveComment(locale, user, chatID, current.Data["session_id"], message.Text)
+	}
+
+	if h.cfg.IsCoach(user.TelegramID) {
+		text, keyboard, err := CoachClients(locale, user, h.botName, h.codec, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+			return err
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = keyboard
+		msg.DisableWebPagePreview = true
+		_, err = h.bot.Send(msg)
+		return err
+	}
+
+	link, err := database.FindClientCoach(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+	if link == nil {
+		_, err = h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "coach.no_coach")))
+		return err
+	}
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "coach.your_coach", views.ClientName(&link.Coach)))
+	msg.ReplyMarkup = keyboards.CreateClientCoachKeyboard(locale)
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// CoachClients is the coach's dashboard: the clients and the invite link.
+func CoachClients(
+	locale string,
+	coach *models.User,
+	botName string,
+	codec *callbackdata.Codec,
+	db *gorm.DB,
+) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	links, err := database.ListCoachClients(coach.ID, db)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	invite, err := database.FindCoachInvite(coach.ID, db)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	link := ""
+	if invite != nil {
+		link = coaching.InviteLink(botName, invite.Token)
+	}
+	keyboard, err := keyboards.CreateCoachKeyboard(locale, links, codec)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	return views.CoachClients(locale, links, link), keyboard, nil
+}
+
+// Invite asks the user who opened a coach's invite link whether to share
+// their training with the coach.
+func (h *CoachHandler) Invite(locale string, user *models.User, chatID int64, token string) error {
+	coach, err := database.GetCoachByInvite(token, h.database)
+	if err != nil || !h.cfg.IsCoach(coach.TelegramID) {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_invite"))
+		return nil
+	}
+	if coach.ID == user.ID {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_self"))
+		return nil
+	}
+
+	current, err := database.FindClientCoach(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_load"))
+		return err
+	}
+	if current != nil && current.CoachID == coach.ID {
+		_, err = h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "coach.already_client", views.ClientName(coach))))
+		return err
+	}
+
+	pending, err := h.confirmations.Request(user.TelegramID, CoachInviteAction, token)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_link"))
+		return err
+	}
+
+	text := i18n.T(locale, "coach.invite_ask", views.ClientName(coach))
+	if current != nil {
+		text += i18n.T(locale, "coach.invite_replaces", views.ClientName(&current.Coach))
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboards.CreateConfirmationKeyboard(locale, pending.ID.String())
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// saveComment saves the coach's comment on the session asked about. The
+// user is checked to still be a coach first: the state outlives a change
+// of COACH_TELEGRAM_IDS.
+func (h *CoachHandler) saveComment(
+	locale string,
+	coach *models.User,
+	chatID int64,
+	sessionID string,
+	text string,
+) error {
+	if !h.cfg.IsCoach(coach.TelegramID) {
+		h.states.Clear(coach.TelegramID)
+		logger.WithFields(logrus.Fields{
+			"user_id": coach.TelegramID,
+		}).Warn("Session comment by a user who is not a coach")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_forbidden"))
+		return nil
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxCommentLength {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_comment_length", maxCommentLength))
+		return nil
+	}
+	h.states.Clear(coach.TelegramID)
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_forbidden"))
+		return nil
+	}
+	session, err := database.GetClientSession(id, coach.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_forbidden"))
+		return nil
+	}
+
+	comment := &models.SessionComment{SessionID: session.ID, CoachID: coach.ID, Text: text}
+	if err := database.CreateSessionComment(comment, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.coach_comment"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"coach_id":   coach.ID,
+		"session_id": session.ID,
+	}).Info("Coach commented on session")
+
+	client, err := database.GetUserByID(session.UserID, h.database)
+	if err == nil {
+		clientLocale := i18n.UserLocale(client)
+		notice := i18n.T(
+			clientLocale, "coach.notify_comment",
+			views.ClientName(coach), session.Name, views.Date(clientLocale, session.StartedAt), text,
+		)
+		_, _ = h.bot.Send(tgbotapi.NewMessage(client.TelegramID, notice))
+	}
+
+	_, err = h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(locale, "coach.comment_saved")))
+	return err
+}
+
+// RunMissedWorkouts tells coaches, once per finished day, which clients
+// skipped a program workout planned for that day, until ctx is done.
+func (h *CoachHandler) RunMissedWorkouts(ctx context.Context) {
+	ticker := time.NewTicker(missedWorkoutInterval)
+	defer ticker.Stop()
+
+	for {
+		h.checkMissedWorkouts(ctx, database.Today().AddDate(0, 0, -1))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *CoachHandler) checkMissedWorkouts(ctx context.Context, day time.Time) {
+	links, err := database.ListUncheckedCoachClients(day, h.database)
+	if err != nil {
+		return
+	}
+
+	for i := range links {
+		link := &links[i]
+		if err := h.checkMissedWorkout(ctx, link, day); err != nil {
+			logger.WithFields(logrus.Fields{
+				"coach_id":  link.CoachID,
+				"client_id": link.ClientID,
+				"error":     err,
+			}).Error("Failed to check missed workout")
+			continue
+		}
+		_ = database.MarkCoachClientChecked(link.ID, day, h.database)
+	}
+}
+
+func (h *CoachHandler) checkMissedWorkout(ctx context.Context, link *models.CoachClient, day time.Time) error {
+	next := day.AddDate(0, 0, 1)
+	if !h.cfg.IsCoach(link.Coach.TelegramID) || !link.CreatedAt.Before(next) {
+		return nil
+	}
+
+	enrollment, err := database.FindProgramEnrollment(link.ClientID, h.database)
+	if err != nil || enrollment == nil || !enrollment.CreatedAt.Before(day) {
+		return err
+	}
+	program, ok := programs.Find(enrollment.Program)
+	if !ok || !slices.Contains(program.TrainingDays(), day.Weekday()) {
+		return nil
+	}
+
+	started, err := database.CountSessionsStarted(link.ClientID, day, next, h.database)
+	if err != nil || started > 0 {
+		return err
+	}
+
+	locale := i18n.UserLocale(&link.Coach)
+	keyboard, err := keyboards.CreateCoachClientLinkKeyboard(locale, link.ClientID, h.codec)
+	if err != nil {
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(link.Coach.TelegramID, i18n.T(
+		locale, "coach.notify_missed",
+		views.ClientName(&link.Client), views.ProgramName(locale, enrollment.Program), views.Date(locale, day),
+	))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.SendContext(ctx, msg)
+	return err
+}
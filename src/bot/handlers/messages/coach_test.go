@@ -0,0 +1,69 @@
+package messages
+
+import (
+	"testing"
+	"time"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/config"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/confirmation"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+)
+
+func TestCoachHandlerUnknownUser(t *testing.T) {
+	db := emptyDatabase(t)
+	bot := &sender.Fake{}
+	states := state.NewStore(time.Minute)
+	handler := NewCoachHandler(bot, db, &config.Config{}, states, confirmation.NewService(db), "workouts_bot", nil)
+
+	states.Set(42, CoachCommentState, map[string]string{"session_id": "x"})
+	update := tgbotapi.Update{Message: &tgbotapi.Message{
+		From: &tgbotapi.User{ID: 42, LanguageCode: "en"},
+		Chat: &tgbotapi.Chat{ID: 42, Type: "private"},
+		Text: "Nice work",
+	}}
+	if err := handler.Handle(update); err != nil {
+		t.Fatalf("Handle = %v", err)
+	}
+
+	messages := bot.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(messages))
+	}
+	if want := "❌ " + i18n.T("en", "error.user_not_found"); messages[0].Text != want || messages[0].ChatID != 42 {
+		t.Errorf("sent %q to %d, want %q to 42", messages[0].Text, messages[0].ChatID, want)
+	}
+	if _, ok := states.Get(42); ok {
+		t.Error("comment state kept for a user who is not registered")
+	}
+}
+
+func TestCoachHandlerCommentByFormerCoach(t *testing.T) {
+	db := emptyDatabase(t)
+	bot := &sender.Fake{}
+	states := state.NewStore(time.Minute)
+	handler := NewCoachHandler(bot, db, &config.Config{}, states, confirmation.NewService(db), "workouts_bot", nil)
+
+	// The comment was asked for while the user was a coach; they are not
+	// one any more, so nothing reaches the database.
+	user := &models.User{ID: uuid.New(), TelegramID: 42}
+	states.Set(42, CoachCommentState, map[string]string{"session_id": uuid.NewString()})
+	if err := handler.saveComment("en", user, 42, uuid.NewString(), "Nice work"); err != nil {
+		t.Fatalf("saveComment = %v", err)
+	}
+
+	messages := bot.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(messages))
+	}
+	if want := "❌ " + i18n.T("en", "error.coach_forbidden"); messages[0].Text != want {
+		t.Errorf("sent %q, want %q", messages[0].Text, want)
+	}
+	if _, ok := states.Get(42); ok {
+		t.Error("comment state kept for a user who is not a coach")
+	}
+}
@@ -0,0 +1,78 @@
+package messages
+
+import (
+	"strings"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const PlatesState = "equipment_plates"
+
+// platesReset restores the standard plate set.
+const platesReset = "0"
+
+// PlatesHandler saves the plate sizes the user typed.
+type PlatesHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+}
+
+func NewPlatesHandler(bot sender.Sender, database *gorm.DB, states *state.Store) *PlatesHandler {
+	return &PlatesHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+	}
+}
+
+func (h *PlatesHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	userID := message.From.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	text := strings.TrimSpace(message.Text)
+	plates := ""
+	if text != platesReset {
+		parsed, err := training.ParsePlates(text)
+		if err != nil || len(parsed) == 0 {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.plates_format"))
+			return nil
+		}
+		plates = training.FormatPlates(parsed)
+	}
+	h.states.Clear(userID)
+
+	if err := database.UpdateUserPlates(userID, plates, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.equipment_update"))
+		return nil
+	}
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"plates":  plates,
+	}).Info("User plates updated")
+
+	msg := tgbotapi.NewMessage(chatID, views.Loadout(locale, user))
+	msg.ReplyMarkup = keyboards.CreateEquipmentKeyboard(locale, user)
+	_, err = h.bot.Send(msg)
+	return err
+}
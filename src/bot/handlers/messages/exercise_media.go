@@ -0,0 +1,130 @@
+package messages
+
+import (
+	"context"
+	"time"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/media"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const ExerciseMediaState = "exercise_media"
+
+const uploadTimeout = 2 * time.Minute
+
+// ExerciseMediaHandler stores technique photos, GIFs and videos that an
+// admin sends after pressing the upload button on an exercise card. The
+// dialog stays open so several files can be sent in a row.
+type ExerciseMediaHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	config   *config.Config
+	states   *state.Store
+	media    *media.Service
+}
+
+func NewExerciseMediaHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	cfg *config.Config,
+	states *state.Store,
+	mediaService *media.Service,
+) *ExerciseMediaHandler {
+	return &ExerciseMediaHandler{
+		bot:      bot,
+		database: database,
+		config:   cfg,
+		states:   states,
+		media:    mediaService,
+	}
+}
+
+func (h *ExerciseMediaHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	userID := message.From.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	current, _ := h.states.Get(userID)
+	if !h.config.IsAdmin(userID) {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.admin_only"))
+		return nil
+	}
+
+	exerciseID, err := uuid.Parse(current.Data["exercise_id"])
+	if err != nil {
+		h.states.Clear(userID)
+		return err
+	}
+	exercise, err := database.GetExercise(exerciseID, h.database)
+	if err != nil {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		return nil
+	}
+
+	kind, fileID, contentType := uploadedFile(message)
+	if fileID == "" {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.media_type"))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	stored, err := h.media.Store(ctx, exercise, kind, fileID, contentType)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":     userID,
+			"exercise_id": exercise.ID,
+			"error":       err,
+		}).Error("Failed to store exercise media")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.media_upload"))
+		return nil
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":    userID,
+		"media_id":   stored.ID,
+		"object_key": stored.ObjectKey,
+	}).Info("Exercise media stored")
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "media.uploaded", views.ExerciseName(locale, exercise)))
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// uploadedFile picks the media kind, file ID and content type of a photo,
+// GIF or video message. The file ID is empty for anything else.
+func uploadedFile(message *tgbotapi.Message) (string, string, string) {
+	switch {
+	case len(message.Photo) > 0:
+		return models.MediaKindImage, message.Photo[len(message.Photo)-1].FileID, "image/jpeg"
+	case message.Animation != nil:
+		return models.MediaKindAnimation, message.Animation.FileID, mimeType(message.Animation.MimeType)
+	case message.Video != nil:
+		return models.MediaKindVideo, message.Video.FileID, mimeType(message.Video.MimeType)
+	default:
+		return "", "", ""
+	}
+}
+
+func mimeType(value string) string {
+	if value == "" {
+		return "video/mp4"
+	}
+	return value
+}
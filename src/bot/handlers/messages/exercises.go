@@ -0,0 +1,42 @@
+package messages
+
+import (
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ExercisesHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewExercisesHandler(bot sender.Sender, database *gorm.DB) *ExercisesHandler {
+	return &ExercisesHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *ExercisesHandler) Handle(update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+	locale := handlers.Locale(update.Message.From, h.database)
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "exercises.categories_title"))
+	msg.ReplyMarkup = keyboards.CreateCategoryKeyboard(locale)
+
+	_, err := h.bot.Send(msg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send exercise categories")
+	}
+	return err
+}
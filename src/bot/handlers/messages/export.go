@@ -0,0 +1,34 @@
+package messages
+
+import (
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
+
+// ExportHandler serves /export, asking which format to export the data in.
+type ExportHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewExportHandler(bot sender.Sender, database *gorm.DB) *ExportHandler {
+	return &ExportHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *ExportHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	locale := handlers.Locale(message.From, h.database)
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, i18n.T(locale, "export.choose"))
+	msg.ReplyMarkup = keyboards.CreateExportKeyboard(locale)
+	_, err := h.bot.Send(msg)
+	return err
+}
@@ -0,0 +1,268 @@
+package messages
+
+import (
+	"context"
+	"strings"
+	"time"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/groups"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// challengePostInterval is how often finished challenge days are looked
+// for. Each day is posted once, soon after midnight UTC.
+const challengePostInterval = time.Hour
+
+// GroupHandler serves the bot in group chats: members opt in with /join,
+// see the weekly leaderboard and start challenges. Only commands addressed
+// to the bot are answered; everything else is members talking.
+type GroupHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	self     tgbotapi.User
+	codec    *callbackdata.Codec
+}
+
+func NewGroupHandler(bot sender.Sender, database *gorm.DB, self tgbotapi.User, codec *callbackdata.Codec) *GroupHandler {
+	return &GroupHandler{
+		bot:      bot,
+		database: database,
+		self:     self,
+		codec:    codec,
+	}
+}
+
+func (h *GroupHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+
+	if message.LeftChatMember != nil && message.LeftChatMember.ID == h.self.ID {
+		return database.DeleteGroup(message.Chat.ID, h.database)
+	}
+	added := h.added(message)
+	if !added && !h.addressed(message) {
+		return nil
+	}
+
+	group, err := h.register(message)
+	if err != nil {
+		return err
+	}
+	locale := i18n.Resolve(group.Language)
+
+	if added {
+		return h.reply(message, i18n.T(locale, "group.welcome", h.self.UserName))
+	}
+	switch message.Command() {
+	case "start", "help":
+		return h.reply(message, i18n.T(locale, "group.welcome", h.self.UserName))
+	case "join":
+		return h.join(locale, group, message)
+	case "leave":
+		return h.leave(locale, group, message)
+	case "leaderboard":
+		return h.showLeaderboard(locale, group, message)
+	case "challenge", "challenges":
+		return h.showChallenges(locale, group, message)
+	}
+	return nil
+}
+
+// register records the chat the first time the bot is used there, in the
+// language of whoever used it, and afterwards only follows its title.
+func (h *GroupHandler) register(message *tgbotapi.Message) (*models.Group, error) {
+	group, err := database.FindGroup(message.Chat.ID, h.database)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		group = &models.Group{
+			ChatID:   message.Chat.ID,
+			Title:    message.Chat.Title,
+			Language: handlers.Locale(message.From, h.database),
+		}
+		return group, database.CreateGroup(group, h.database)
+	}
+	if group.Title != message.Chat.Title {
+		return group, database.RenameGroup(group, message.Chat.Title, h.database)
+	}
+	return group, nil
+}
+
+// added reports whether the message is the bot being added to the chat.
+func (h *GroupHandler) added(message *tgbotapi.Message) bool {
+	if message.GroupChatCreated || message.SuperGroupChatCreated {
+		return true
+	}
+	for _, member := range message.NewChatMembers {
+		if member.ID == h.self.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// addressed reports whether the message is a command for this bot rather
+// than for another bot in the chat.
+func (h *GroupHandler) addressed(message *tgbotapi.Message) bool {
+	if !message.IsCommand() {
+		return false
+	}
+	_, botName, found := strings.Cut(message.CommandWithAt(), "@")
+	return !found || strings.EqualFold(botName, h.self.UserName)
+}
+
+func (h *GroupHandler) join(locale string, group *models.Group, message *tgbotapi.Message) error {
+	user, err := database.FindUserByTelegramID(message.From.ID, h.database)
+	if err != nil {
+		return h.reply(message, "❌ "+i18n.T(locale, "error.user_fetch"))
+	}
+	if user == nil {
+		return h.reply(message, i18n.T(locale, "group.start_first", h.self.UserName))
+	}
+
+	joined, err := database.AddGroupMember(group.ID, user.ID, h.database)
+	if err != nil {
+		return h.reply(message, "❌ "+i18n.T(locale, "error.group_update"))
+	}
+	if !joined {
+		return h.reply(message, i18n.T(locale, "group.already_joined"))
+	}
+
+	logger.WithFields(logrus.Fields{
+		"group_id": group.ID,
+		"user_id":  user.ID,
+	}).Info("User joined group")
+	return h.reply(message, i18n.T(locale, "group.joined", h.self.UserName))
+}
+
+func (h *GroupHandler) leave(locale string, group *models.Group, message *tgbotapi.Message) error {
+	user, err := database.FindUserByTelegramID(message.From.ID, h.database)
+	if err != nil {
+		return h.reply(message, "❌ "+i18n.T(locale, "error.user_fetch"))
+	}
+	if user == nil {
+		return h.reply(message, i18n.T(locale, "group.not_member"))
+	}
+
+	left, err := database.RemoveGroupMember(group.ID, user.ID, h.database)
+	if err != nil {
+		return h.reply(message, "❌ "+i18n.T(locale, "error.group_update"))
+	}
+	if !left {
+		return h.reply(message, i18n.T(locale, "group.not_member"))
+	}
+	return h.reply(message, i18n.T(locale, "group.left"))
+}
+
+func (h *GroupHandler) showLeaderboard(locale string, group *models.Group, message *tgbotapi.Message) error {
+	now := time.Now()
+	standings, err := groups.Leaderboard(group.ID, now, h.database)
+	if err != nil {
+		return h.reply(message, "❌ "+i18n.T(locale, "error.group_load"))
+	}
+	return h.reply(message, views.Leaderboard(locale, standings, now))
+}
+
+func (h *GroupHandler) showChallenges(locale string, group *models.Group, message *tgbotapi.Message) error {
+	running, err := database.ListActiveChallenges(group.ID, database.Today(), h.database)
+	if err != nil {
+		return h.reply(message, "❌ "+i18n.T(locale, "error.group_load"))
+	}
+	keyboard, err := keyboards.CreateChallengesKeyboard(locale, running, h.codec)
+	if err != nil {
+		return h.reply(message, "❌ "+i18n.T(locale, "error.group_load"))
+	}
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, views.ActiveChallenges(locale, running))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+func (h *GroupHandler) reply(message *tgbotapi.Message, text string) error {
+	msg := tgbotapi.NewMessage(message.Chat.ID, text)
+	msg.ReplyToMessageID = message.MessageID
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+// RunChallengePosts posts the progress of every running challenge for
+// each finished day, and the results after the last one, until ctx is
+// done.
+func (h *GroupHandler) RunChallengePosts(ctx context.Context) {
+	ticker := time.NewTicker(challengePostInterval)
+	defer ticker.Stop()
+
+	for {
+		h.postChallenges(ctx, database.Today().AddDate(0, 0, -1))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *GroupHandler) postChallenges(ctx context.Context, day time.Time) {
+	challenges, err := database.ListUnpostedChallenges(day, h.database)
+	if err != nil {
+		return
+	}
+
+	for i := range challenges {
+		running := &challenges[i]
+		posted := day
+		if running.EndsOn.Before(day) {
+			posted = running.EndsOn
+		}
+		if err := h.postChallenge(ctx, running, posted); err != nil {
+			logger.WithFields(logrus.Fields{
+				"challenge_id": running.ID,
+				"chat_id":      running.Group.ChatID,
+				"error":        err,
+			}).Error("Failed to post challenge progress")
+			continue
+		}
+		_ = database.MarkChallengePosted(running.ID, posted, h.database)
+	}
+}
+
+func (h *GroupHandler) postChallenge(ctx context.Context, running *models.Challenge, day time.Time) error {
+	challenge, ok := groups.Find(running.Kind)
+	if !ok {
+		logger.WithField("kind", running.Kind).Warn("Skipping challenge of unknown kind")
+		return nil
+	}
+
+	participants, err := database.ListChallengeParticipants(running.ID, h.database)
+	if err != nil || len(participants) == 0 {
+		return err
+	}
+	userIDs := make([]uuid.UUID, len(participants))
+	for i := range participants {
+		userIDs[i] = participants[i].UserID
+	}
+	days, err := database.ExerciseDays(userIDs, challenge.Exercise, running.StartsOn, day, h.database)
+	if err != nil {
+		return err
+	}
+
+	locale := i18n.Resolve(running.Group.Language)
+	scores := challenge.Scores(participants, days, day)
+	msg := tgbotapi.NewMessage(running.Group.ChatID, views.ChallengeDay(locale, challenge, running, day, scores))
+	_, err = h.bot.SendContext(ctx, msg)
+	return err
+}
@@ -0,0 +1,58 @@
+package messages
+
+import (
+	"strings"
+	"testing"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/logger"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// emptyDatabase is a database without tables: every query fails, as when
+// the database is unreachable.
+func emptyDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	logger.InitSimple("panic")
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func groupCommand(text string) tgbotapi.Update {
+	command, _, _ := strings.Cut(text, " ")
+	return tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID: 1,
+		From:      &tgbotapi.User{ID: 42},
+		Chat:      &tgbotapi.Chat{ID: -100, Type: "supergroup", Title: "Gym"},
+		Text:      text,
+		Entities:  []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(command)}},
+	}}
+}
+
+func TestGroupHandlerIgnoresOtherBots(t *testing.T) {
+	bot := &sender.Fake{}
+	handler := NewGroupHandler(bot, emptyDatabase(t), tgbotapi.User{ID: 1, UserName: "workouts_bot"}, nil)
+
+	for _, text := range []string{"/leaderboard@other_bot", "/join@other_bot now", "hello"} {
+		update := groupCommand(text)
+		if text == "hello" {
+			update.Message.Entities = nil
+		}
+		if err := handler.Handle(update); err != nil {
+			t.Fatalf("Handle(%q) = %v", text, err)
+		}
+	}
+
+	if calls := bot.Calls(); len(calls) != 0 {
+		t.Fatalf("sent %d messages for commands to other bots, want none", len(calls))
+	}
+}
@@ -0,0 +1,114 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/imports"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const ImportAliasState = "import_alias"
+
+const aliasCandidateLimit = 6
+
+// formatNames are the apps exports come from, as shown to the user.
+var formatNames = map[string]string{
+	imports.FormatStrong:   "Strong",
+	imports.FormatHevy:     "Hevy",
+	imports.FormatFitNotes: "FitNotes",
+}
+
+// ImportHistory imports a Strong, Hevy or FitNotes export the user sent.
+// Exercise names that neither the catalog nor the user's earlier answers
+// resolve are asked about one at a time, and the file is read again once
+// the user answers. Workouts imported before are skipped.
+func (h *ImportHandler) ImportHistory(locale string, user *models.User, chatID int64, fileID string) error {
+	history, err := h.readHistory(user, fileID)
+	if errors.Is(err, imports.ErrUnknownFormat) || errors.Is(err, imports.ErrInvalid) || errors.Is(err, imports.ErrEmpty) {
+		h.states.Clear(user.TelegramID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_history_format"))
+		return nil
+	}
+	if err != nil {
+		h.states.Clear(user.TelegramID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+
+	resolution, err := imports.Resolve(user.ID, history, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+	if len(resolution.Unknown) > 0 {
+		return h.askAlias(locale, user, chatID, fileID, resolution)
+	}
+	h.states.Clear(user.TelegramID)
+
+	name := i18n.T(locale, "import.workout_name", formatNames[history.Format])
+	imported, existing, err := imports.Save(user, history, resolution.Exercises, name, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":  user.TelegramID,
+		"format":   history.Format,
+		"imported": imported,
+		"existing": existing,
+	}).Info("Workout history imported")
+
+	_, err = h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(
+		locale, "import.history_done", formatNames[history.Format], imported, existing,
+	)))
+	return err
+}
+
+// askAlias asks which catalog exercise the first unknown name is.
+func (h *ImportHandler) askAlias(
+	locale string,
+	user *models.User,
+	chatID int64,
+	fileID string,
+	resolution *imports.Resolution,
+) error {
+	name := resolution.Unknown[0]
+	keyboard, err := keyboards.CreateImportAliasKeyboard(
+		locale, imports.Candidates(name, resolution.Catalog, aliasCandidateLimit), h.codec,
+	)
+	if err != nil {
+		h.states.Clear(user.TelegramID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.import_failed"))
+		return err
+	}
+	h.states.Set(user.TelegramID, ImportAliasState, map[string]string{
+		"file_id": fileID,
+		"name":    name,
+	})
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "import.ask_alias", name, len(resolution.Unknown)))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+func (h *ImportHandler) readHistory(user *models.User, fileID string) (*imports.History, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	file, err := h.media.Open(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return imports.Parse(file, user.PlateUnit)
+}
@@ -0,0 +1,193 @@
+package messages
+
+import (
+	"strconv"
+	"strings"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/body"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MeasurementsHandler serves /measurements and the quick-entry commands
+// such as "/weight 82.5".
+type MeasurementsHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewMeasurementsHandler(bot sender.Sender, database *gorm.DB) *MeasurementsHandler {
+	return &MeasurementsHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *MeasurementsHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	user, err := database.GetUserByTelegramID(message.From.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	kind, ok := keyboards.MeasurementCommands["/"+message.Command()]
+	if !ok {
+		text, err := MeasurementsText(locale, user, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.measurement_load"))
+			return err
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = keyboards.CreateMeasurementsKeyboard(locale)
+		return h.send(msg)
+	}
+
+	argument := strings.TrimSpace(message.CommandArguments())
+	if argument == "" {
+		text, keyboard, err := MeasurementHistory(locale, user, kind, 0, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.measurement_load"))
+			return err
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = keyboard
+		return h.send(msg)
+	}
+
+	return h.save(locale, user, chatID, kind, argument)
+}
+
+func (h *MeasurementsHandler) save(
+	locale string,
+	user *models.User,
+	chatID int64,
+	kind string,
+	argument string,
+) error {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(argument, ",", "."), 64)
+	if err != nil || !body.Valid(kind, value) {
+		limits := body.Limits[kind]
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(
+			locale, "error.measurement_value",
+			views.MeasurementValue(locale, kind, limits[0]),
+			views.MeasurementValue(locale, kind, limits[1]),
+		))
+		return nil
+	}
+
+	measurement := &models.BodyMeasurement{
+		UserID:     user.ID,
+		Kind:       kind,
+		Value:      value,
+		MeasuredOn: database.Today(),
+	}
+	if err := database.SaveBodyMeasurement(measurement, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.measurement_save"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id": user.TelegramID,
+		"kind":    kind,
+	}).Info("Body measurement saved")
+
+	text := i18n.T(
+		locale, "measurements.saved",
+		views.MeasurementName(locale, kind), views.MeasurementValue(locale, kind, value),
+	)
+	measurements, err := database.ListBodyMeasurements(
+		user.ID, kind, database.Today().Add(-body.TrendPeriod-body.Window), h.database,
+	)
+	if err == nil {
+		if trend, ok := body.Calculate(kind, user.Goal, measurements); ok {
+			text += views.MeasurementTrend(locale, kind, trend)
+		}
+	}
+
+	return h.send(tgbotapi.NewMessage(chatID, text))
+}
+
+func (h *MeasurementsHandler) send(msg tgbotapi.MessageConfig) error {
+	_, err := h.bot.Send(msg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": msg.ChatID,
+			"error":   err,
+		}).Error("Failed to send measurements")
+	}
+	return err
+}
+
+// MeasurementsText is the summary of the latest values and their trends.
+func MeasurementsText(locale string, user *models.User, db *gorm.DB) (string, error) {
+	latest, err := database.LatestBodyMeasurements(user.ID, db)
+	if err != nil {
+		return "", err
+	}
+
+	since := database.Today().Add(-body.TrendPeriod - body.Window)
+	trends := make(map[string]body.Trend, len(latest))
+	for kind := range latest {
+		measurements, err := database.ListBodyMeasurements(user.ID, kind, since, db)
+		if err != nil {
+			return "", err
+		}
+		if trend, ok := body.Calculate(kind, user.Goal, measurements); ok {
+			trends[kind] = trend
+		}
+	}
+	return views.MeasurementsSummary(locale, latest, trends), nil
+}
+
+// MeasurementHistory renders a page of the kind's history with the moving
+// average of each day.
+func MeasurementHistory(
+	locale string,
+	user *models.User,
+	kind string,
+	page int,
+	db *gorm.DB,
+) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	page = max(page, 0)
+	measurements, total, err := database.ListBodyMeasurementPage(
+		user.ID, kind, page*keyboards.CatalogPageSize, keyboards.CatalogPageSize, db,
+	)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	pages := keyboards.Pages(total, keyboards.CatalogPageSize)
+	if page >= pages {
+		return MeasurementHistory(locale, user, kind, pages-1, db)
+	}
+
+	averages := make(map[string]float64, len(measurements))
+	if len(measurements) > 0 {
+		// The average of the oldest row on the page needs the week before it.
+		oldest := measurements[len(measurements)-1].MeasuredOn
+		window, err := database.ListBodyMeasurements(user.ID, kind, oldest.Add(-body.Window), db)
+		if err != nil {
+			return "", tgbotapi.InlineKeyboardMarkup{}, err
+		}
+		for i, average := range body.MovingAverage(window) {
+			averages[window[i].MeasuredOn.Format(views.DateLayout)] = average
+		}
+	}
+
+	text := views.MeasurementHistory(locale, kind, measurements, averages)
+	keyboard := keyboards.CreateMeasurementHistoryKeyboard(locale, kind, page, pages)
+	return text, keyboard, nil
+}
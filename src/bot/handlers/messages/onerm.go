@@ -0,0 +1,102 @@
+package messages
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidTestedSet = errors.New("invalid tested set")
+
+// testedSetPattern accepts "100", "100x5" and "100x5 @8"; the weight may use
+// a decimal comma and the separator may be a Latin or Cyrillic x, a times
+// sign, an asterisk or a space.
+var testedSetPattern = regexp.MustCompile(
+	`^(\d+(?:[.,]\d+)?)(?:\s*[xх×*\s]\s*(\d+))?(?:\s*@\s*(\d+(?:[.,]5)?))?$`,
+)
+
+// OneRepMaxHandler answers /1rm: with a set it estimates the 1RM and
+// prints a percentage table, without one it lists the training maxes.
+type OneRepMaxHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewOneRepMaxHandler(bot sender.Sender, database *gorm.DB) *OneRepMaxHandler {
+	return &OneRepMaxHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *OneRepMaxHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	argument := strings.TrimSpace(message.CommandArguments())
+	if argument != "" {
+		weight, reps, rpe, err := ParseTestedSet(argument)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.onerm_format", training.MaxEstimateReps))
+			return nil
+		}
+		_, err = h.bot.Send(tgbotapi.NewMessage(chatID, views.OneRepMax(locale, weight, max(reps, 1), rpe)))
+		return err
+	}
+
+	user, err := database.GetUserByTelegramID(message.From.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	maxes, err := database.ListTrainingMaxes(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+
+	text := views.TrainingMaxes(locale, maxes) + i18n.T(locale, "onerm.usage")
+	_, err = h.bot.Send(tgbotapi.NewMessage(chatID, text))
+	return err
+}
+
+// ParseTestedSet reads "weight[xreps] [@rpe]". Reps and rpe are 0 when not
+// given; a set without reps counts as a single.
+func ParseTestedSet(text string) (float64, int, float64, error) {
+	match := testedSetPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return 0, 0, 0, ErrInvalidTestedSet
+	}
+
+	weight, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", "."), 64)
+	if err != nil || weight > maxTrainingMax {
+		return 0, 0, 0, ErrInvalidTestedSet
+	}
+
+	var reps int
+	if match[2] != "" {
+		reps, _ = strconv.Atoi(match[2])
+	}
+
+	var rpe float64
+	if match[3] != "" {
+		rpe, _ = strconv.ParseFloat(strings.ReplaceAll(match[3], ",", "."), 64)
+	}
+
+	if !training.ValidSet(weight, max(reps, 1), rpe) {
+		return 0, 0, 0, ErrInvalidTestedSet
+	}
+	return weight, reps, rpe, nil
+}
@@ -0,0 +1,53 @@
+package messages
+
+import (
+	"time"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/services/achievements"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
+
+// ProfileHandler serves /profile: totals, streaks and achievements.
+type ProfileHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+}
+
+func NewProfileHandler(bot sender.Sender, database *gorm.DB) *ProfileHandler {
+	return &ProfileHandler{
+		bot:      bot,
+		database: database,
+	}
+}
+
+func (h *ProfileHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	user, err := database.GetUserByTelegramID(message.From.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	facts, err := achievements.Collect(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_fetch"))
+		return err
+	}
+	earned, err := database.ListAchievements(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_fetch"))
+		return err
+	}
+
+	_, err = h.bot.Send(tgbotapi.NewMessage(chatID, views.Profile(locale, user, facts, earned, time.Now())))
+	return err
+}
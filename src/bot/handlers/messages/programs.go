@@ -0,0 +1,317 @@
+package messages
+
+import (
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/programs"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	ProgramMaxState = "program_max"
+
+	LeaveProgramAction = "leave_program"
+)
+
+const maxTrainingMax = 500
+
+// ProgramsHandler shows the program library on /programs and takes
+// training maxes typed by the user.
+type ProgramsHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+}
+
+func NewProgramsHandler(bot sender.Sender, database *gorm.DB, states *state.Store) *ProgramsHandler {
+	return &ProgramsHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+	}
+}
+
+func (h *ProgramsHandler) Handle(update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	locale := handlers.Locale(update.Message.From, h.database)
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	current, ok := h.states.Get(userID)
+	if ok && current.Name == ProgramMaxState {
+		return h.saveMax(locale, user, chatID, current.Data["exercise"], update.Message.Text)
+	}
+
+	enrollment, err := database.FindProgramEnrollment(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "programs.list"))
+	msg.ReplyMarkup = keyboards.CreateProgramListKeyboard(locale, enrollment)
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// saveMax stores the training max, then asks for the next one the program
+// still needs or shows the program once all are known. A plain weight is
+// the training max itself; a tested set such as 120x5 sets the 1RM and the
+// training max is derived from it.
+func (h *ProgramsHandler) saveMax(
+	locale string,
+	user *models.User,
+	chatID int64,
+	slug string,
+	text string,
+) error {
+	weight, reps, rpe, err := ParseTestedSet(text)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.training_max", maxTrainingMax))
+		return nil
+	}
+
+	exercises, err := database.ListExercisesBySlugs([]string{slug}, h.database)
+	exercise, ok := exercises[slug]
+	if err != nil || !ok {
+		h.states.Clear(user.TelegramID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.exercise_not_found"))
+		return nil
+	}
+
+	if reps == 0 {
+		err = database.SaveTrainingMax(&models.TrainingMax{
+			UserID:     user.ID,
+			ExerciseID: exercise.ID,
+			Weight:     weight,
+			Source:     models.TrainingMaxManual,
+		}, h.database)
+	} else {
+		oneRepMax := training.RoundWeight(training.Average(training.EstimateAll(weight, reps, rpe)), 0.5)
+		weight = training.TrainingMax(oneRepMax)
+		_, _, err = database.RecordOneRepMax(
+			user.ID, exercise.ID, oneRepMax, weight, models.TrainingMaxTest, h.database,
+		)
+	}
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_save"))
+		return err
+	}
+	h.states.Clear(user.TelegramID)
+
+	logger.WithFields(logrus.Fields{
+		"user_id":  user.TelegramID,
+		"exercise": slug,
+	}).Info("Training max saved")
+
+	enrollment, err := database.FindProgramEnrollment(user.ID, h.database)
+	if err != nil || enrollment == nil {
+		_, err := h.bot.Send(tgbotapi.NewMessage(chatID, i18n.T(
+			locale, "programs.max_saved", views.TrainingMax(locale, &exercise, weight),
+		)))
+		return err
+	}
+
+	asked, err := AskTrainingMax(h.bot, h.states, locale, user, chatID, enrollment, h.database)
+	if err != nil || asked {
+		return err
+	}
+
+	text, keyboard, err := ProgramView(locale, user, enrollment, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.program_load"))
+		return err
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// TrainingMaxes returns the user's training maxes keyed by exercise slug.
+func TrainingMaxes(userID uuid.UUID, db *gorm.DB) (map[string]float64, error) {
+	maxes, err := database.ListTrainingMaxes(userID, db)
+	if err != nil {
+		return nil, err
+	}
+
+	bySlug := make(map[string]float64, len(maxes))
+	for _, trainingMax := range maxes {
+		bySlug[trainingMax.Exercise.Slug] = trainingMax.Weight
+	}
+	return bySlug, nil
+}
+
+// AskTrainingMax asks for the first training max the enrolled program needs
+// and the user has not entered yet. It reports whether there was one.
+func AskTrainingMax(
+	bot sender.Sender,
+	states *state.Store,
+	locale string,
+	user *models.User,
+	chatID int64,
+	enrollment *models.ProgramEnrollment,
+	db *gorm.DB,
+) (bool, error) {
+	program, ok := programs.Find(enrollment.Program)
+	if !ok {
+		return false, programs.ErrUnknown
+	}
+
+	maxes, err := TrainingMaxes(user.ID, db)
+	if err != nil {
+		return false, err
+	}
+	for _, slug := range program.Maxes() {
+		if maxes[slug] > 0 {
+			continue
+		}
+		return true, AskExerciseMax(bot, states, locale, user, chatID, slug, db)
+	}
+	return false, nil
+}
+
+// AskExerciseMax asks for the training max of the exercise with the slug.
+func AskExerciseMax(
+	bot sender.Sender,
+	states *state.Store,
+	locale string,
+	user *models.User,
+	chatID int64,
+	slug string,
+	db *gorm.DB,
+) error {
+	exercises, err := database.ListExercisesBySlugs([]string{slug}, db)
+	if err != nil {
+		return err
+	}
+	exercise, ok := exercises[slug]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	states.Set(user.TelegramID, ProgramMaxState, map[string]string{"exercise": slug})
+	_, err = bot.Send(tgbotapi.NewMessage(chatID, i18n.T(
+		locale, "programs.ask_max", views.ExerciseName(locale, &exercise),
+	)))
+	return err
+}
+
+// ProgramView renders the enrollment with the sets of the next day.
+func ProgramView(
+	locale string,
+	user *models.User,
+	enrollment *models.ProgramEnrollment,
+	db *gorm.DB,
+) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	program, ok := programs.Find(enrollment.Program)
+	if !ok {
+		return "", tgbotapi.InlineKeyboardMarkup{}, programs.ErrUnknown
+	}
+
+	maxes, err := TrainingMaxes(user.ID, db)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	prescriptions := program.Prescribe(enrollment.Week, enrollment.Day, maxes)
+
+	exercises, err := database.ListExercisesBySlugs(prescribedSlugs(prescriptions), db)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := views.Enrollment(locale, program, enrollment, prescriptions, exercises)
+	return text, keyboards.CreateEnrollmentKeyboard(locale), nil
+}
+
+// StartProgramDay starts a session with the sets the program prescribes
+// for the enrollment's current day.
+func StartProgramDay(
+	locale string,
+	user *models.User,
+	enrollment *models.ProgramEnrollment,
+	db *gorm.DB,
+) (*models.WorkoutSession, error) {
+	program, ok := programs.Find(enrollment.Program)
+	if !ok {
+		return nil, programs.ErrUnknown
+	}
+
+	maxes, err := TrainingMaxes(user.ID, db)
+	if err != nil {
+		return nil, err
+	}
+	prescriptions := program.Prescribe(enrollment.Week, enrollment.Day, maxes)
+
+	exercises, err := database.ListExercisesBySlugs(prescribedSlugs(prescriptions), db)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollmentID := enrollment.ID
+	day := program.Days[enrollment.Day%len(program.Days)]
+	session := &models.WorkoutSession{
+		UserID:       user.ID,
+		EnrollmentID: &enrollmentID,
+		Name: i18n.T(
+			locale, "programs.session_name",
+			views.ProgramName(locale, program.Slug), views.ProgramDay(locale, day.Name),
+		),
+	}
+	for _, prescription := range prescriptions {
+		exercise, ok := exercises[prescription.Exercise]
+		if !ok || len(prescription.Sets) == 0 {
+			continue
+		}
+
+		var heaviest float64
+		for _, set := range prescription.Sets {
+			heaviest = max(heaviest, set.Weight)
+		}
+		session.Exercises = append(session.Exercises, models.SessionExercise{
+			ExerciseID:   exercise.ID,
+			Position:     len(session.Exercises) + 1,
+			TargetSets:   len(prescription.Sets),
+			TargetReps:   prescription.Sets[0].Reps,
+			TargetWeight: heaviest,
+			RestSeconds:  prescription.Rest,
+			Plan:         prescription.Sets,
+		})
+	}
+
+	if err := database.StartSession(session, db); err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":       user.TelegramID,
+			"enrollment_id": enrollment.ID,
+			"error":         err,
+		}).Error("Failed to start program day")
+		return nil, err
+	}
+	return session, nil
+}
+
+func prescribedSlugs(prescriptions []programs.Prescription) []string {
+	slugs := make([]string, len(prescriptions))
+	for i, prescription := range prescriptions {
+		slugs[i] = prescription.Exercise
+	}
+	return slugs
+}
@@ -0,0 +1,121 @@
+package messages
+
+import (
+	"context"
+	"strings"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/services/media"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const ProgressPhotoState = "progress_photo"
+
+// ProgressHandler opens the progress photo menu and receives the photo
+// once the user has picked a pose.
+type ProgressHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+	media    *media.Service
+}
+
+func NewProgressHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	states *state.Store,
+	mediaService *media.Service,
+) *ProgressHandler {
+	return &ProgressHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+		media:    mediaService,
+	}
+}
+
+func (h *ProgressHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	if current, ok := h.states.Get(message.From.ID); ok && current.Name == ProgressPhotoState {
+		return h.savePhoto(locale, message, current.Data["pose"])
+	}
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "progress.title"))
+	msg.ReplyMarkup = keyboards.CreateProgressKeyboard(locale)
+
+	_, err := h.bot.Send(msg)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to send progress menu")
+	}
+	return err
+}
+
+func (h *ProgressHandler) savePhoto(locale string, message *tgbotapi.Message, pose string) error {
+	chatID := message.Chat.ID
+	userID := message.From.ID
+
+	fileID := progressFileID(message)
+	if fileID == "" {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.progress_photo"))
+		return nil
+	}
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	photo, err := h.media.StoreProgressPhoto(ctx, user, pose, fileID)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to store progress photo")
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.progress_save"))
+		return nil
+	}
+	h.states.Clear(userID)
+
+	logger.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"photo_id": photo.ID,
+		"pose":     pose,
+	}).Info("Progress photo stored")
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "progress.saved", views.Pose(locale, pose)))
+	msg.ReplyMarkup = keyboards.CreatePoseKeyboard(locale, "pose")
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// progressFileID accepts compressed photos and JPEG files sent as documents.
+func progressFileID(message *tgbotapi.Message) string {
+	switch {
+	case len(message.Photo) > 0:
+		return message.Photo[len(message.Photo)-1].FileID
+	case message.Document != nil && strings.EqualFold(message.Document.MimeType, "image/jpeg"):
+		return message.Document.FileID
+	default:
+		return ""
+	}
+}
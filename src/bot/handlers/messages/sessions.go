@@ -0,0 +1,220 @@
+package messages
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const SessionSetState = "session_set"
+
+var ErrInvalidSet = errors.New("invalid set")
+
+// setPattern accepts "8", "8 62.5" and "8 62.5 @9"; the weight may use a
+// decimal comma and the RPE may come without the weight.
+var setPattern = regexp.MustCompile(`^(\d+)(?:\s+(\d+(?:[.,]\d+)?))?(?:\s*@\s*(\d+(?:[.,]5)?))?$`)
+
+// SessionSetHandler logs a set with the reps, weight and RPE the user typed
+// instead of the targets, a drop set or a rest-pause set extending the
+// last working set, or a cardio activity, then refreshes the session
+// message.
+type SessionSetHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+	codec    *callbackdata.Codec
+}
+
+func NewSessionSetHandler(bot sender.Sender, database *gorm.DB, states *state.Store, codec *callbackdata.Codec) *SessionSetHandler {
+	return &SessionSetHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+		codec:    codec,
+	}
+}
+
+func (h *SessionSetHandler) Handle(update tgbotapi.Update) error {
+	message := update.Message
+	chatID := message.Chat.ID
+	userID := message.From.ID
+	locale := handlers.Locale(message.From, h.database)
+
+	current, _ := h.states.Get(userID)
+
+	var set *models.SessionSet
+	var entry *models.CardioEntry
+	var err error
+	if current.Data["cardio"] == "true" {
+		entry, err = ParseCardio(message.Text)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.cardio_format"))
+			return nil
+		}
+	} else {
+		set, err = ParseSet(message.Text)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.set_format"))
+			return nil
+		}
+	}
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	exerciseID, _ := uuid.Parse(current.Data["session_exercise_id"])
+	number, _ := strconv.Atoi(current.Data["number"])
+	messageID, _ := strconv.Atoi(current.Data["message_id"])
+	kind := current.Data["kind"]
+	h.states.Clear(userID)
+
+	exercise, session, err := database.GetSessionExercise(exerciseID, user.ID, h.database)
+	if err != nil || session.FinishedAt != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+		return nil
+	}
+
+	logged := true
+	switch {
+	case entry != nil:
+		if number == exercise.Logged()+1 && !exercise.Done() {
+			entry.UserID = user.ID
+			logged, err = database.LogSessionCardio(exercise, &models.SessionSet{}, entry, h.database)
+			if err != nil {
+				handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+				return err
+			}
+		}
+	case kind != "":
+		if parent := exercise.LastWorkSet(); parent != nil && set.Reps > 0 {
+			if set.Weight < 0 {
+				set.Weight = SubSetWeight(exercise, parent, kind)
+			}
+			set.Kind = kind
+			if err := database.LogSubSet(exercise, parent, set, h.database); err != nil {
+				handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+				return err
+			}
+		}
+	case number == exercise.Logged()+1 && !exercise.Done():
+		target := exercise.Target(number)
+		if set.Weight < 0 {
+			set.Weight = target.Weight
+		}
+		set.Warmup = target.Warmup
+		if exercise.Intervals() {
+			set.Seconds = exercise.WorkSeconds
+		}
+		logged, err = database.LogSessionSet(exercise, set, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+			return err
+		}
+	}
+	// A concurrent press logged the set first; show it as saved.
+	if !logged {
+		session, err = database.GetWorkoutSession(session.ID, user.ID, h.database)
+		if err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_not_found"))
+			return err
+		}
+	}
+
+	// The set may have finished the exercise and brought up the next one.
+	training.PrepareWarmups(user, session.Current(), h.database)
+
+	text, keyboard, err := SessionView(locale, user, session, h.codec, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.session_save"))
+		return err
+	}
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	editMsg.ReplyMarkup = &keyboard
+	if _, err := h.bot.Send(editMsg); err == nil {
+		return nil
+	}
+
+	// The session message may be too old to edit; send a fresh one.
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// SubSetWeight is the weight a drop set or a rest-pause set gets when the
+// user does not type one: a drop set goes lighter, a rest-pause set keeps
+// the weight.
+func SubSetWeight(exercise *models.SessionExercise, parent *models.SessionSet, kind string) float64 {
+	if kind == models.SetKindDrop {
+		return training.DropWeight(&exercise.Exercise, parent.Weight)
+	}
+	return parent.Weight
+}
+
+// ParseSet reads "reps [weight] [@rpe]". A missing weight is returned as -1
+// so the caller can fill in the target.
+func ParseSet(text string) (*models.SessionSet, error) {
+	match := setPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return nil, ErrInvalidSet
+	}
+
+	reps, _ := strconv.Atoi(match[1])
+	if reps < 0 || reps > 100 {
+		return nil, ErrInvalidSet
+	}
+	set := &models.SessionSet{Reps: reps, Weight: -1}
+
+	if match[2] != "" {
+		weight, err := strconv.ParseFloat(strings.ReplaceAll(match[2], ",", "."), 64)
+		if err != nil || weight > 1000 {
+			return nil, ErrInvalidSet
+		}
+		set.Weight = weight
+	}
+	if match[3] != "" {
+		rpe, err := strconv.ParseFloat(strings.ReplaceAll(match[3], ",", "."), 64)
+		if err != nil || rpe < 6 || rpe > 10 {
+			return nil, ErrInvalidSet
+		}
+		set.RPE = rpe
+	}
+	return set, nil
+}
+
+// SessionView renders the session with the recommendation for the
+// current exercise, if it has one. It writes nothing: warm-ups are planned
+// by the actions that bring an exercise up.
+func SessionView(
+	locale string,
+	user *models.User,
+	session *models.WorkoutSession,
+	codec *callbackdata.Codec,
+	db *gorm.DB,
+) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	recommendation := training.RecommendFor(user, session.Current(), db)
+	keyboard, err := keyboards.CreateSessionKeyboard(locale, session, user.Progression, recommendation != nil, codec)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	return views.WorkoutSession(locale, session, recommendation), keyboard, nil
+}
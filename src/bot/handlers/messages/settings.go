@@ -1,11 +1,14 @@
 package messages
 
 import (
-	"fmt"
 	"workouts_bot/src/bot/handlers"
 	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/views"
 	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
 	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
@@ -13,11 +16,11 @@ import (
 )
 
 type SettingsHandler struct {
-	bot      *tgbotapi.BotAPI
+	bot      sender.Sender
 	database *gorm.DB
 }
 
-func NewSettingsHandler(bot *tgbotapi.BotAPI, database *gorm.DB) *SettingsHandler {
+func NewSettingsHandler(bot sender.Sender, database *gorm.DB) *SettingsHandler {
 	return &SettingsHandler{
 		bot:      bot,
 		database: database,
@@ -40,30 +43,76 @@ func (handler *SettingsHandler) Handle(update tgbotapi.Update) error {
 			"user_id": userID,
 			"error":   err,
 		}).Error("Failed to get user by telegram ID")
-		handlers.SendErrorMessage(handler.bot, chatID, "Пользователь не найден")
+		locale := i18n.Resolve(update.Message.From.LanguageCode)
+		handlers.SendErrorMessage(handler.bot, chatID, i18n.T(locale, "error.user_not_found"))
 		return nil
 	}
 
-	message := "⚙️ Ваши настройки:\n\n"
-	message += fmt.Sprintf("📈 Уровень опыта: %s\n\n", getExperienceLevel(user.Experience))
-	message += "Используйте кнопки ниже для изменения настроек:"
+	locale := i18n.UserLocale(user)
+	message := i18n.T(locale, "settings.summary_title")
+	message += i18n.T(locale, "settings.summary_goal", getGoalName(locale, user.Goal))
+	message += i18n.T(locale, "settings.summary_experience", getExperienceLevel(locale, user.Experience))
+	message += i18n.T(locale, "settings.summary_progression", getProgressionName(locale, user.Progression))
+	message += i18n.T(
+		locale, "settings.summary_equipment",
+		i18n.T(locale, "equipment.unit_"+user.PlateUnit), views.Warmups(locale, user.Warmups),
+	)
+	message += i18n.T(locale, "settings.summary_language", getLanguageName(locale, user.Language))
+	message += i18n.T(locale, "settings.summary_hint")
 
 	msg := tgbotapi.NewMessage(chatID, message)
-	msg.ReplyMarkup = keyboards.CreateSettingsKeyboard()
+	msg.ReplyMarkup = keyboards.CreateSettingsKeyboard(locale)
 
 	_, err = handler.bot.Send(msg)
 	return err
 }
 
-func getExperienceLevel(experience int) string {
+func getExperienceLevel(locale string, experience int) string {
 	switch {
 	case experience < 1:
-		return "🟢 Начинающий"
+		return i18n.T(locale, keyboards.ExpBeginner)
 	case experience < 3:
-		return "🟡 Средний"
+		return i18n.T(locale, keyboards.ExpIntermediate)
 	case experience < 5:
-		return "🟠 Продвинутый"
+		return i18n.T(locale, keyboards.ExpAdvanced)
 	default:
-		return "🔴 Эксперт"
+		return i18n.T(locale, keyboards.ExpExpert)
+	}
+}
+
+func getGoalName(locale string, goal string) string {
+	switch goal {
+	case models.GoalMuscleGain:
+		return i18n.T(locale, keyboards.GoalMuscleGain)
+	case models.GoalStrength:
+		return i18n.T(locale, keyboards.GoalStrength)
+	case models.GoalEndurance:
+		return i18n.T(locale, keyboards.GoalEndurance)
+	case models.GoalWeightLoss:
+		return i18n.T(locale, keyboards.GoalWeightLoss)
+	default:
+		return i18n.T(locale, "settings.goal_none")
+	}
+}
+
+func getProgressionName(locale string, progression string) string {
+	switch progression {
+	case models.ProgressionLinear:
+		return i18n.T(locale, keyboards.ProgressionLinear)
+	case models.ProgressionRPE:
+		return i18n.T(locale, keyboards.ProgressionRPE)
+	default:
+		return i18n.T(locale, keyboards.ProgressionDouble)
+	}
+}
+
+func getLanguageName(locale string, language string) string {
+	switch language {
+	case i18n.RU:
+		return i18n.T(locale, keyboards.LanguageRU)
+	case i18n.EN:
+		return i18n.T(locale, keyboards.LanguageEN)
+	default:
+		return i18n.T(locale, keyboards.LanguageAuto)
 	}
 }
@@ -3,41 +3,39 @@ package messages
 import (
 	"workouts_bot/src/bot/handlers"
 	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
 	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
 	"workouts_bot/src/logger"
 	"workouts_bot/src/models"
+	"workouts_bot/src/services/coaching"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
-const (
-	helloMessage = "Привет! Я бот для тренировок 🏋️\n\n" +
-		"Я помогу тебе:\n" +
-		"• Подобрать упражнения\n" +
-		"• Составить программу тренировок\n" +
-		"• Отслеживать прогресс\n" +
-		"• Записывать подходы и веса\n\n" +
-		"Выбери действие:"
-)
-
 const (
 	StartCommand = "/start"
 )
 
+// StartHandler registers the user. A /start payload carrying a coach's
+// invite token goes on to the coach invite.
 type StartHandler struct {
-	bot      *tgbotapi.BotAPI
+	bot      sender.Sender
 	database *gorm.DB
+	coaches  *CoachHandler
 }
 
 func NewStartHandler(
-	bot *tgbotapi.BotAPI,
+	bot sender.Sender,
 	database *gorm.DB,
+	coaches *CoachHandler,
 ) *StartHandler {
 	return &StartHandler{
 		bot:      bot,
 		database: database,
+		coaches:  coaches,
 	}
 }
 
@@ -56,22 +54,24 @@ func (startHandler *StartHandler) Handle(
 	}).Info("New user started bot")
 
 	user := &models.User{
-		TelegramID: userID,
-		Username:   userName,
-		FirstName:  firstName,
+		TelegramID:   userID,
+		Username:     userName,
+		FirstName:    firstName,
+		LanguageCode: update.Message.From.LanguageCode,
 	}
 
 	if err := database.UpsertUser(user, startHandler.database); err != nil {
 		logger.Error("Failed to create or update user:", err)
 		handlers.SendErrorMessage(
 			startHandler.bot, chatID,
-			"Ошибка при получении пользователя",
+			i18n.T(i18n.Resolve(user.LanguageCode), "error.user_fetch"),
 		)
 		return err
 	}
 
-	msg := tgbotapi.NewMessage(chatID, helloMessage)
-	msg.ReplyMarkup = keyboards.CreateMainMenu()
+	locale := handlers.Locale(update.Message.From, startHandler.database)
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "start.hello"))
+	msg.ReplyMarkup = keyboards.CreateMainMenu(locale)
 
 	_, err := startHandler.bot.Send(msg)
 	if err != nil {
@@ -82,16 +82,26 @@ func (startHandler *StartHandler) Handle(
 		}).Error("Failed to send start message")
 		return err
 	}
+
+	if token, ok := coaching.InviteToken(update.Message.CommandArguments()); ok {
+		// UpsertUser leaves the ID unset for returning users.
+		user, err := database.GetUserByTelegramID(userID, startHandler.database)
+		if err != nil {
+			return err
+		}
+		return startHandler.coaches.Invite(locale, user, chatID, token)
+	}
 	return nil
 }
 
 func (startHandler *StartHandler) MainMenu(
+	locale string,
 	userID int64,
 	chatID int64,
 	messageID int,
 ) error {
-	msg := tgbotapi.NewMessage(chatID, "Главное меню")
-	msg.ReplyMarkup = keyboards.CreateMainMenu()
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "menu.main"))
+	msg.ReplyMarkup = keyboards.CreateMainMenu(locale)
 	_, err := startHandler.bot.Send(msg)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
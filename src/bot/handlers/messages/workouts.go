@@ -0,0 +1,416 @@
+package messages
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/handlers"
+	"workouts_bot/src/bot/keyboards"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/bot/state"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	WorkoutNameState     = "workout_name"
+	WorkoutRenameState   = "workout_rename"
+	WorkoutExerciseState = "workout_exercise"
+	WorkoutTargetsState  = "workout_targets"
+
+	DeleteWorkoutAction = "delete_workout"
+)
+
+const (
+	maxWorkoutNameLength = 64
+	exerciseSearchLimit  = 10
+)
+
+var ErrInvalidTargets = errors.New("invalid targets")
+
+// targetsPattern accepts "4x8", "4x8 60" and "4x8 60 90"; the weight may use
+// a comma and the separator may be a Latin or Cyrillic x or an asterisk.
+var targetsPattern = regexp.MustCompile(`^(\d+)\s*[xх×*]\s*(\d+)(?:\s+(\d+(?:[.,]\d+)?))?(?:\s+(\d+))?$`)
+
+// intervalsPattern accepts "8x40/20": rounds of 40 seconds of work and 20
+// seconds of rest.
+var intervalsPattern = regexp.MustCompile(`^(\d+)\s*[xх×*]\s*(\d+)\s*/\s*(\d+)$`)
+
+type WorkoutsHandler struct {
+	bot      sender.Sender
+	database *gorm.DB
+	states   *state.Store
+	codec    *callbackdata.Codec
+}
+
+func NewWorkoutsHandler(
+	bot sender.Sender,
+	database *gorm.DB,
+	states *state.Store,
+	codec *callbackdata.Codec,
+) *WorkoutsHandler {
+	return &WorkoutsHandler{
+		bot:      bot,
+		database: database,
+		states:   states,
+		codec:    codec,
+	}
+}
+
+func (h *WorkoutsHandler) Handle(update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	locale := handlers.Locale(update.Message.From, h.database)
+
+	user, err := database.GetUserByTelegramID(userID, h.database)
+	if err != nil {
+		h.states.Clear(userID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.user_not_found"))
+		return nil
+	}
+
+	current, ok := h.states.Get(userID)
+	if !ok {
+		return h.list(locale, user, chatID)
+	}
+
+	switch current.Name {
+	case WorkoutNameState:
+		return h.create(locale, user, chatID, update.Message.Text)
+	case WorkoutRenameState:
+		return h.rename(locale, user, chatID, current.Data["template_id"], update.Message.Text)
+	case WorkoutExerciseState:
+		return h.search(locale, user, chatID, current.Data["template_id"], update.Message.Text)
+	case WorkoutTargetsState:
+		return h.targets(locale, user, chatID, current.Data["item_id"], update.Message.Text)
+	default:
+		return h.list(locale, user, chatID)
+	}
+}
+
+func (h *WorkoutsHandler) list(locale string, user *models.User, chatID int64) error {
+	templates, err := database.ListWorkoutTemplates(user.ID, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return err
+	}
+
+	session, err := database.FindActiveSession(user.ID, h.database)
+	if err != nil {
+		return err
+	}
+	keyboard, err := keyboards.CreateWorkoutListKeyboard(locale, templates, session, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, WorkoutListText(locale, templates))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+func (h *WorkoutsHandler) create(locale string, user *models.User, chatID int64, text string) error {
+	name, ok := workoutName(text)
+	if !ok {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_name"))
+		return nil
+	}
+	h.states.Clear(user.TelegramID)
+
+	template := &models.WorkoutTemplate{
+		ID:     uuid.New(),
+		UserID: user.ID,
+		Name:   name,
+		Type:   models.WorkoutTypeCustom,
+	}
+	if err := database.CreateWorkoutTemplate(template, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":     user.TelegramID,
+		"template_id": template.ID,
+	}).Info("Workout template created")
+
+	return h.sendEditor(locale, template, chatID)
+}
+
+func (h *WorkoutsHandler) rename(
+	locale string,
+	user *models.User,
+	chatID int64,
+	templateID string,
+	text string,
+) error {
+	template, err := h.template(user, templateID)
+	if err != nil {
+		h.states.Clear(user.TelegramID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return nil
+	}
+
+	name, ok := workoutName(text)
+	if !ok {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_name"))
+		return nil
+	}
+	h.states.Clear(user.TelegramID)
+
+	if err := database.RenameWorkoutTemplate(template, name, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+	template.Name = name
+
+	return h.sendEditor(locale, template, chatID)
+}
+
+// search looks the text up in the exercise catalog. A single match is added
+// right away; several are offered as buttons.
+func (h *WorkoutsHandler) search(
+	locale string,
+	user *models.User,
+	chatID int64,
+	templateID string,
+	text string,
+) error {
+	template, err := h.template(user, templateID)
+	if err != nil {
+		h.states.Clear(user.TelegramID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return nil
+	}
+
+	exercises, err := database.SearchExercises(text, exerciseSearchLimit, h.database)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	switch len(exercises) {
+	case 0:
+		msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "workouts.exercise_not_found", text))
+		_, err := h.bot.Send(msg)
+		return err
+	case 1:
+		h.states.Clear(user.TelegramID)
+		if _, err := AddExerciseToTemplate(user, template, &exercises[0], h.database); err != nil {
+			handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+			return err
+		}
+		template, err = database.GetWorkoutTemplate(template.ID, user.ID, h.database)
+		if err != nil {
+			return err
+		}
+		return h.sendEditor(locale, template, chatID)
+	}
+
+	keyboard, err := keyboards.CreateExerciseChoiceKeyboard(locale, template.ID, exercises, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "workouts.exercise_choose"))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+func (h *WorkoutsHandler) targets(
+	locale string,
+	user *models.User,
+	chatID int64,
+	itemID string,
+	text string,
+) error {
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		h.states.Clear(user.TelegramID)
+		return err
+	}
+
+	item, template, err := database.GetTemplateExercise(id, user.ID, h.database)
+	if err != nil {
+		h.states.Clear(user.TelegramID)
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_not_found"))
+		return nil
+	}
+
+	if err := ParseTargets(text, item); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.targets_format"))
+		return nil
+	}
+	h.states.Clear(user.TelegramID)
+
+	if err := database.UpdateTemplateExercise(item, h.database); err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+	keyboard, err := keyboards.CreateTemplateItemKeyboard(locale, item, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, views.TemplateItem(locale, template, item))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+func (h *WorkoutsHandler) sendEditor(locale string, template *models.WorkoutTemplate, chatID int64) error {
+	keyboard, err := keyboards.CreateWorkoutEditKeyboard(locale, template, h.codec)
+	if err != nil {
+		handlers.SendErrorMessage(h.bot, chatID, i18n.T(locale, "error.workout_save"))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, WorkoutEditorText(locale, template))
+	msg.ReplyMarkup = keyboard
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+func (h *WorkoutsHandler) template(user *models.User, templateID string) (*models.WorkoutTemplate, error) {
+	id, err := uuid.Parse(templateID)
+	if err != nil {
+		return nil, err
+	}
+	return database.GetWorkoutTemplate(id, user.ID, h.database)
+}
+
+func WorkoutListText(locale string, templates []models.WorkoutTemplate) string {
+	if len(templates) == 0 {
+		return i18n.T(locale, "workouts.list_empty")
+	}
+	return i18n.T(locale, "workouts.list_title")
+}
+
+func WorkoutEditorText(locale string, template *models.WorkoutTemplate) string {
+	text := views.WorkoutTemplate(locale, template)
+	if len(template.Exercises) > 0 {
+		text += i18n.T(locale, "workouts.edit_hint")
+	}
+	return text
+}
+
+// AddExerciseToTemplate appends the exercise with targets suited to the
+// user's goal and experience.
+func AddExerciseToTemplate(
+	user *models.User,
+	template *models.WorkoutTemplate,
+	exercise *models.Exercise,
+	db *gorm.DB,
+) (*models.TemplateExercise, error) {
+	targets := training.DefaultTargets(user, exercise)
+	item := &models.TemplateExercise{
+		TemplateID:  template.ID,
+		ExerciseID:  exercise.ID,
+		TargetSets:  targets.Sets,
+		TargetReps:  targets.Reps,
+		RestSeconds: targets.RestSeconds,
+		WorkSeconds: targets.WorkSeconds,
+	}
+	if err := database.AddTemplateExercise(item, db); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// AddExerciseToSession appends the exercise to an unfinished session with
+// targets suited to the user's goal and experience.
+func AddExerciseToSession(
+	user *models.User,
+	session *models.WorkoutSession,
+	exercise *models.Exercise,
+	db *gorm.DB,
+) (*models.SessionExercise, error) {
+	targets := training.DefaultTargets(user, exercise)
+	item := &models.SessionExercise{
+		SessionID:   session.ID,
+		ExerciseID:  exercise.ID,
+		TargetSets:  targets.Sets,
+		TargetReps:  targets.Reps,
+		RestSeconds: targets.RestSeconds,
+		WorkSeconds: targets.WorkSeconds,
+	}
+	if err := database.AddSessionExercise(item, db); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// ParseTargets reads "sets x reps [weight] [rest]" or intervals as
+// "rounds x work/rest" into the item. Omitted weight and rest keep their
+// current values.
+func ParseTargets(text string, item *models.TemplateExercise) error {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if match := intervalsPattern.FindStringSubmatch(text); match != nil {
+		return parseIntervals(match, item)
+	}
+
+	match := targetsPattern.FindStringSubmatch(text)
+	if match == nil {
+		return ErrInvalidTargets
+	}
+
+	sets, _ := strconv.Atoi(match[1])
+	reps, _ := strconv.Atoi(match[2])
+	if sets < 1 || sets > 20 || reps < 1 || reps > 100 {
+		return ErrInvalidTargets
+	}
+	item.TargetSets = sets
+	item.TargetReps = reps
+	item.WorkSeconds = 0
+
+	if match[3] != "" {
+		weight, err := strconv.ParseFloat(strings.ReplaceAll(match[3], ",", "."), 64)
+		if err != nil || weight > 1000 {
+			return ErrInvalidTargets
+		}
+		item.TargetWeight = weight
+	}
+	if match[4] != "" {
+		rest, err := strconv.Atoi(match[4])
+		if err != nil || rest > 3600 {
+			return ErrInvalidTargets
+		}
+		item.RestSeconds = rest
+	}
+	return nil
+}
+
+func parseIntervals(match []string, item *models.TemplateExercise) error {
+	rounds, _ := strconv.Atoi(match[1])
+	work, _ := strconv.Atoi(match[2])
+	rest, _ := strconv.Atoi(match[3])
+	if rounds < 1 || rounds > 50 || work < 5 || work > 600 || rest > 600 {
+		return ErrInvalidTargets
+	}
+	item.TargetSets = rounds
+	item.WorkSeconds = work
+	item.RestSeconds = rest
+	return nil
+}
+
+func workoutName(text string) (string, bool) {
+	name := strings.TrimSpace(text)
+	length := utf8.RuneCountInString(name)
+	return name, length > 0 && length <= maxWorkoutNameLength
+}
@@ -0,0 +1,27 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const DeleteAccountMessage = "/delete_me"
+
+// CreateDeleteAccountKeyboard confirms deleting the account through the
+// pending action, offering every export and the photos first.
+func CreateDeleteAccountKeyboard(locale string, action string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(append(exportRows(locale),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, AccountDelete),
+				callbackdata.New(callbackdata.TypeConfirm, action, "yes").String(),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavNo),
+				callbackdata.New(callbackdata.TypeConfirm, action, "no").String(),
+			),
+		),
+	)...)
+}
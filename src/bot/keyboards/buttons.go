@@ -1,81 +1,177 @@
 package keyboards
 
+// Button labels are catalog keys; use i18n.T to get the text.
 const (
 	// Workout buttons
-	WorkoutStart   = "▶️ Начать"
-	WorkoutEdit    = "✏️ Редактировать"
-	WorkoutDelete  = "🗑️ Удалить"
-	WorkoutRefresh = "🔄 Обновить"
-	WorkoutStats   = "📊 Статистика"
+	WorkoutStart   = "button.workout_start"
+	WorkoutEdit    = "button.workout_edit"
+	WorkoutDelete  = "button.workout_delete"
+	WorkoutRefresh = "button.workout_refresh"
+	WorkoutStats   = "button.workout_stats"
+	StatsReset     = "button.stats_reset"
+
+	// Workout template editing buttons
+	WorkoutRename      = "button.workout_rename"
+	WorkoutAddExercise = "button.workout_add_exercise"
+	WorkoutDuplicate   = "button.workout_duplicate"
+	ItemUp             = "button.item_up"
+	ItemDown           = "button.item_down"
+	ItemTargets        = "button.item_targets"
+	ItemLink           = "button.item_link"
+	ItemUnlink         = "button.item_unlink"
+	ItemRemove         = "button.item_remove"
+	WorkoutFinish      = "button.workout_finish"
 
 	// Exercise buttons
-	ExerciseDetails = "📖 Подробнее"
-	ExerciseVideo   = "🎥 Видео"
-	ExerciseAdd     = "➕ Добавить в тренировку"
+	ExerciseDetails = "button.exercise_details"
+	ExerciseVideo   = "button.exercise_video"
+	ExerciseAdd     = "button.exercise_add"
+	ExerciseUpload  = "button.exercise_upload"
+	ExerciseMax     = "button.exercise_max"
+
+	// Progress photo buttons
+	ProgressAdd          = "button.progress_add"
+	ProgressHistory      = "button.progress_history"
+	ProgressCompare      = "button.progress_compare"
+	ProgressMeasurements = "button.progress_measurements"
+	PoseFront            = "button.pose_front"
+	PoseSide             = "button.pose_side"
+	PoseBack             = "button.pose_back"
+
+	// Program buttons
+	Programs       = "button.programs"
+	ProgramEnroll  = "button.program_enroll"
+	ProgramCurrent = "button.program_current"
+	ProgramStart   = "button.program_start"
+	ProgramMaxes   = "button.program_maxes"
+	ProgramLeave   = "button.program_leave"
 
 	// Set buttons
-	SetComplete = "✅ Завершить подход"
-	SetSkip     = "⏭️ Пропустить"
-	SetPause    = "⏸️ Пауза"
+	SetComplete  = "button.set_complete"
+	SetSkip      = "button.set_skip"
+	SetPause     = "button.set_pause"
+	SetOther     = "button.set_other"
+	SetAccept    = "button.set_accept"
+	SetDrop      = "button.set_drop"
+	SetRestPause = "button.set_rest_pause"
+	SetActivity  = "button.set_activity"
+
+	// Import buttons
+	ImportSkip = "button.import_skip"
+
+	// Export buttons
+	ExportCSV    = "button.export_csv"
+	ExportJSON   = "button.export_json"
+	ExportXLSX   = "button.export_xlsx"
+	ExportPhotos = "button.export_photos"
+
+	// Account buttons
+	AccountDelete = "button.account_delete"
+
+	// Calendar buttons
+	CalendarEnable = "button.calendar_enable"
+	CalendarRotate = "button.calendar_rotate"
+	CalendarRevoke = "button.calendar_revoke"
+
+	// Group chat buttons
+	GroupPrivacyPublic     = "button.group_privacy_public"
+	GroupPrivacyHideVolume = "button.group_privacy_hide_volume"
+	GroupPrivacyAnonymous  = "button.group_privacy_anonymous"
+	ChallengeJoin          = "button.challenge_join"
+	ChallengeStart         = "button.challenge_start"
+
+	// Coach buttons
+	CoachInvite        = "button.coach_invite"
+	CoachAssignProgram = "button.coach_assign_program"
+	CoachRemoveClient  = "button.coach_remove_client"
+	CoachComment       = "button.coach_comment"
+	CoachClients       = "button.coach_clients"
+	CoachLeave         = "button.coach_leave"
+	CoachOpenProgram   = "button.coach_open_program"
+	CoachOpenSession   = "button.coach_open_session"
+	CoachOpenClient    = "button.coach_open_client"
 
 	// Goal buttons
-	GoalMuscleGain = "💪 Набор массы"
-	GoalStrength   = "🏋️ Сила"
-	GoalEndurance  = "🏃 Выносливость"
-	GoalWeightLoss = "🔥 Похудение"
+	GoalMuscleGain = "button.goal_muscle_gain"
+	GoalStrength   = "button.goal_strength"
+	GoalEndurance  = "button.goal_endurance"
+	GoalWeightLoss = "button.goal_weight_loss"
 
 	// Equipment buttons
-	EquipmentHome   = "🏠 Домашний зал"
-	EquipmentGym    = "🏋️ Тренажерный зал"
-	EquipmentNone   = "🚫 Без оборудования"
-	EquipmentCustom = "⚙️ Настроить"
+	EquipmentHome   = "button.equipment_home"
+	EquipmentGym    = "button.equipment_gym"
+	EquipmentNone   = "button.equipment_none"
+	EquipmentCustom = "button.equipment_custom"
 
 	// Workout type buttons
-	WorkoutTypeSplit    = "🏋️ Классический сплит"
-	WorkoutTypePushPull = "🔄 Push/Pull/Legs"
-	WorkoutTypeFullBody = "💪 Фулбади"
-	WorkoutTypeCustom   = "🎯 Кастомная"
+	WorkoutTypeSplit    = "button.workout_type_split"
+	WorkoutTypePushPull = "button.workout_type_push_pull"
+	WorkoutTypeFullBody = "button.workout_type_full_body"
+	WorkoutTypeCustom   = "button.workout_type_custom"
 
 	// Exercise category buttons
-	ExerciseCompound   = "🏋️ Базовые"
-	ExerciseIsolation  = "🎯 Изолированные"
-	ExerciseStrength   = "💪 Силовые"
-	ExerciseCardio     = "🏃 Кардио"
-	ExerciseBodyweight = "🤸 С собственным весом"
-	ExerciseHIIT       = "⚡ HIIT"
-	ExerciseEndurance  = "🔄 Выносливость"
+	ExerciseCompound   = "button.exercise_compound"
+	ExerciseIsolation  = "button.exercise_isolation"
+	ExerciseStrength   = "button.exercise_strength"
+	ExerciseCardio     = "button.exercise_cardio"
+	ExerciseBodyweight = "button.exercise_bodyweight"
+	ExerciseHIIT       = "button.exercise_hiit"
+	ExerciseEndurance  = "button.exercise_endurance"
 
 	// Settings buttons
-	SettingsGoals       = "🎯 Цели"
-	SettingsEquipment   = "🏋️ Оборудование"
-	SettingsExperience  = "📈 Уровень опыта"
-	SettingsLimitations = "⚠️ Ограничения"
+	SettingsGoals       = "button.settings_goals"
+	SettingsEquipment   = "button.settings_equipment"
+	SettingsExperience  = "button.settings_experience"
+	SettingsLimitations = "button.settings_limitations"
+	SettingsLanguage    = "button.settings_language"
+	SettingsProgression = "button.settings_progression"
+	SettingsCalendar    = "button.settings_calendar"
+	SettingsGroups      = "button.settings_groups"
+
+	// Equipment settings buttons
+	EquipmentPlates     = "button.equipment_plates"
+	EquipmentWarmupsOn  = "button.equipment_warmups_on"
+	EquipmentWarmupsOff = "button.equipment_warmups_off"
 
 	// Experience level buttons
-	ExpBeginner     = "🟢 Начинающий"
-	ExpIntermediate = "🟡 Средний"
-	ExpAdvanced     = "🟠 Продвинутый"
-	ExpExpert       = "🔴 Эксперт"
+	ExpBeginner     = "button.exp_beginner"
+	ExpIntermediate = "button.exp_intermediate"
+	ExpAdvanced     = "button.exp_advanced"
+	ExpExpert       = "button.exp_expert"
+
+	// Progression scheme buttons
+	ProgressionLinear = "button.progression_linear"
+	ProgressionDouble = "button.progression_double"
+	ProgressionRPE    = "button.progression_rpe"
+
+	// Language buttons
+	LanguageRU   = "button.language_ru"
+	LanguageEN   = "button.language_en"
+	LanguageAuto = "button.language_auto"
 
 	// Muscle group buttons
-	MuscleChest     = "💪 Грудь"
-	MuscleBack      = "🏋️ Спина"
-	MuscleShoulders = "🦾 Плечи"
-	MuscleBiceps    = "💪 Бицепс"
-	MuscleTriceps   = "💪 Трицепс"
-	MuscleLegs      = "🦵 Ноги"
-	MuscleGlutes    = "🍑 Ягодицы"
-	MuscleAbs       = "💪 Пресс"
+	MuscleChest     = "button.muscle_chest"
+	MuscleBack      = "button.muscle_back"
+	MuscleShoulders = "button.muscle_shoulders"
+	MuscleBiceps    = "button.muscle_biceps"
+	MuscleTriceps   = "button.muscle_triceps"
+	MuscleLegs      = "button.muscle_legs"
+	MuscleGlutes    = "button.muscle_glutes"
+	MuscleAbs       = "button.muscle_abs"
 
 	// Duration buttons
-	Duration30 = "⏰ 30 минут"
-	Duration45 = "⏰ 45 минут"
-	Duration60 = "⏰ 60 минут"
-	Duration90 = "⏰ 90 минут"
+	Duration30 = "button.duration_30"
+	Duration45 = "button.duration_45"
+	Duration60 = "button.duration_60"
+	Duration90 = "button.duration_90"
 
 	// Navigation buttons
-	NavMainMenu = "🏠 Главное меню"
-	NavBack     = "🔙 Назад"
-	NavYes      = "✅ Да"
-	NavNo       = "❌ Нет"
+	NavMainMenu = "button.nav_main_menu"
+	NavBack     = "button.nav_back"
+	NavYes      = "button.nav_yes"
+	NavNo       = "button.nav_no"
+
+	// Pagination buttons
+	PagePrev = "button.page_prev"
+	PageNext = "button.page_next"
 )
@@ -0,0 +1,39 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// CreateCalendarKeyboard turns the calendar feed on, or replaces or
+// revokes its link when it is on.
+func CreateCalendarKeyboard(locale string, enabled bool) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if enabled {
+		rows = append(rows,
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, CalendarRotate), calendarData("rotate")),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, CalendarRevoke), calendarData("revoke")),
+			),
+		)
+	} else {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, CalendarEnable), calendarData("rotate")),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack),
+			callbackdata.New(callbackdata.TypeSettings, "back").String(),
+		),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func calendarData(action string) string {
+	return callbackdata.New(callbackdata.TypeCalendar, action).String()
+}
@@ -0,0 +1,49 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	CardioMessage = "/cardio"
+	StatsMessage  = "/stats"
+	ImportMessage = "/import"
+)
+
+// CreateStatsKeyboard offers to reset the statistics.
+func CreateStatsKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, StatsReset),
+				callbackdata.New(callbackdata.TypeWorkout, "reset").String(),
+			),
+		),
+	)
+}
+
+// CreateCardioKeyboard offers the activities to log, two per row.
+func CreateCardioKeyboard(
+	locale string,
+	exercises []models.Exercise,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, (len(exercises)+1)/2)
+	for i := 0; i < len(exercises); i += 2 {
+		row := tgbotapi.NewInlineKeyboardRow()
+		for _, exercise := range exercises[i:min(i+2, len(exercises))] {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+				views.ExerciseName(locale, &exercise),
+				p.data(callbackdata.TypeCardio, "log", exercise.ID.String()),
+			))
+		}
+		rows = append(rows, row)
+	}
+	return p.markup(rows)
+}
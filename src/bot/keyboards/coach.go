@@ -0,0 +1,173 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/programs"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+)
+
+const CoachMessage = "/coach"
+
+// CreateCoachKeyboard opens each client of the coach and makes a new
+// invite link.
+func CreateCoachKeyboard(
+	locale string,
+	links []models.CoachClient,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(links)+1)
+	for i := range links {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.ClientName(&links[i].Client),
+				p.data(callbackdata.TypeCoach, "client", links[i].ClientID.String()),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, CoachInvite), coachData("invite")),
+	))
+	return p.markup(rows)
+}
+
+// CreateClientKeyboard opens the client's last sessions, assigns a program
+// or ends the coaching.
+func CreateClientKeyboard(
+	locale string,
+	clientID uuid.UUID,
+	sessions []models.WorkoutSession,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(sessions)+3)
+	for i := range sessions {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.Date(locale, sessions[i].StartedAt)+" · "+sessions[i].Name,
+				p.data(callbackdata.TypeCoach, "session", sessions[i].ID.String()),
+			),
+		))
+	}
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, CoachAssignProgram), p.data(callbackdata.TypeCoach, "programs", clientID.String()),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, CoachRemoveClient), p.data(callbackdata.TypeCoach, "remove", clientID.String()),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, CoachClients), coachData("clients")),
+		),
+	)
+	return p.markup(rows)
+}
+
+// CreateAssignProgramKeyboard lists the programs to assign to a client.
+func CreateAssignProgramKeyboard(
+	locale string,
+	clientID uuid.UUID,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(programs.Library)+1)
+	for _, program := range programs.Library {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.ProgramName(locale, program.Slug),
+				p.data(callbackdata.TypeCoach, "assign", clientID.String(), program.Slug),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack), p.data(callbackdata.TypeCoach, "client", clientID.String()),
+		),
+	))
+	return p.markup(rows)
+}
+
+// CreateClientSessionKeyboard comments on a client's session.
+func CreateClientSessionKeyboard(
+	locale string,
+	session *models.WorkoutSession,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	return p.markup([][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, CoachComment), p.data(callbackdata.TypeCoach, "comment", session.ID.String()),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack), p.data(callbackdata.TypeCoach, "client", session.UserID.String()),
+			),
+		),
+	})
+}
+
+// CreateClientCoachKeyboard lets a client stop sharing with their coach.
+func CreateClientCoachKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, CoachLeave), coachData("leave")),
+		),
+	)
+}
+
+// CreateAssignedProgramKeyboard opens the program a coach assigned.
+func CreateAssignedProgramKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, CoachOpenProgram), programData("current")),
+		),
+	)
+}
+
+// CreateCoachSessionLinkKeyboard opens a client's session from a
+// notification.
+func CreateCoachSessionLinkKeyboard(
+	locale string,
+	sessionID uuid.UUID,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	return p.markup([][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, CoachOpenSession), p.data(callbackdata.TypeCoach, "session", sessionID.String()),
+			),
+		),
+	})
+}
+
+// CreateCoachClientLinkKeyboard opens a client's card from a notification.
+func CreateCoachClientLinkKeyboard(
+	locale string,
+	clientID uuid.UUID,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	return p.markup([][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, CoachOpenClient), p.data(callbackdata.TypeCoach, "client", clientID.String()),
+			),
+		),
+	})
+}
+
+func coachData(action string, args ...string) string {
+	return callbackdata.New(callbackdata.TypeCoach, action, args...).String()
+}
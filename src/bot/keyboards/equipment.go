@@ -0,0 +1,47 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// CreateEquipmentKeyboard switches the plate unit, edits the plates and
+// toggles warm-up sets. The current unit is marked.
+func CreateEquipmentKeyboard(locale string, user *models.User) tgbotapi.InlineKeyboardMarkup {
+	units := tgbotapi.NewInlineKeyboardRow()
+	for _, unit := range []string{models.UnitKg, models.UnitLb} {
+		label := i18n.T(locale, "equipment.unit_"+unit)
+		if unit == user.PlateUnit {
+			label = "✅ " + label
+		}
+		units = append(units, tgbotapi.NewInlineKeyboardButtonData(label, equipmentData("unit", unit)))
+	}
+
+	warmups := EquipmentWarmupsOn
+	if user.Warmups {
+		warmups = EquipmentWarmupsOff
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		units,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, EquipmentPlates), equipmentData("plates")),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, warmups), equipmentData("warmups")),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				callbackdata.New(callbackdata.TypeSettings, "back").String(),
+			),
+		),
+	)
+}
+
+func equipmentData(action string, args ...string) string {
+	return callbackdata.New(callbackdata.TypeEquipment, action, args...).String()
+}
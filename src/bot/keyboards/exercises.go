@@ -0,0 +1,182 @@
+package keyboards
+
+import (
+	"strconv"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+)
+
+// Categories lists catalog categories in the order they are offered.
+var Categories = []string{
+	models.CategoryCompound,
+	models.CategoryIsolation,
+	models.CategoryStrength,
+	models.CategoryBodyweight,
+	models.CategoryCardio,
+	models.CategoryHIIT,
+	models.CategoryEndurance,
+}
+
+func CreateCategoryKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, (len(Categories)+1)/2)
+	for i := 0; i < len(Categories); i += 2 {
+		row := tgbotapi.NewInlineKeyboardRow()
+		for _, category := range Categories[i:min(i+2, len(Categories))] {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+				views.Category(locale, category),
+				categoryData(category, 0),
+			))
+		}
+		rows = append(rows, row)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func CreateExerciseListKeyboard(
+	locale string,
+	category string,
+	exercises []models.Exercise,
+	page int,
+	pages int,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(exercises)+2)
+	for i := range exercises {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.ExerciseName(locale, &exercises[i]),
+				exerciseData(p, "card", exercises[i].ID),
+			),
+		))
+	}
+	if row := paginationRow(locale, page, pages, func(page int) string {
+		return categoryData(category, page)
+	}); row != nil {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack),
+			callbackdata.New(callbackdata.TypeExercise, "categories").String(),
+		),
+	))
+
+	return p.markup(rows)
+}
+
+// CreateExerciseCardKeyboard shows the exercise actions; weighted exercises
+// also get a button to set the training max and admins one to upload
+// technique media.
+func CreateExerciseCardKeyboard(
+	locale string,
+	exercise *models.Exercise,
+	admin bool,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExerciseDetails),
+				exerciseData(p, "details", exercise.ID),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExerciseVideo),
+				exerciseData(p, "video", exercise.ID),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExerciseAdd),
+				p.data(callbackdata.TypeExercise, "add", exercise.ID.String(), "0"),
+			),
+		),
+	}
+	if training.WeightStep(exercise) > 0 {
+		rows[1] = append(rows[1], tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, ExerciseMax),
+			programData("max", exercise.Slug),
+		))
+	}
+	if admin {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExerciseUpload),
+				exerciseData(p, "upload", exercise.ID),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack),
+			categoryData(exercise.Category, 0),
+		),
+	))
+
+	return p.markup(rows)
+}
+
+// CreateAddTargetKeyboard offers the active session, if any, and a page of
+// the user's templates as places to add the exercise to.
+func CreateAddTargetKeyboard(
+	locale string,
+	exercise *models.Exercise,
+	session *models.WorkoutSession,
+	templates []models.WorkoutTemplate,
+	page int,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	pages := Pages(len(templates), ChoicePageSize)
+	page = ClampPage(page, pages)
+
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, ChoicePageSize+3)
+	if session != nil && page == 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, "sessions.continue", session.Name),
+				p.data(callbackdata.TypeExercise, "to_session", exercise.ID.String(), session.ID.String()),
+			),
+		))
+	}
+
+	start := page * ChoicePageSize
+	for _, template := range templates[start:min(start+ChoicePageSize, len(templates))] {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				template.Name,
+				p.data(callbackdata.TypeExercise, "to_template", exercise.ID.String(), template.ID.String()),
+			),
+		))
+	}
+
+	if row := paginationRow(locale, page, pages, func(page int) string {
+		return p.data(callbackdata.TypeExercise, "add", exercise.ID.String(), strconv.Itoa(page))
+	}); row != nil {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack),
+			exerciseData(p, "card", exercise.ID),
+		),
+	))
+
+	return p.markup(rows)
+}
+
+func categoryData(category string, page int) string {
+	return callbackdata.New(callbackdata.TypeExercise, "list", category, strconv.Itoa(page)).String()
+}
+
+func exerciseData(p *payloads, action string, id uuid.UUID) string {
+	return p.data(callbackdata.TypeExercise, action, id.String())
+}
@@ -0,0 +1,47 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/services/export"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const ExportMessage = "/export"
+
+// ExportPhotosAction exports the progress photos, which are not part of
+// any format.
+const ExportPhotosAction = "photos"
+
+// exportButtons are the button labels of the export formats.
+var exportButtons = map[string]string{
+	export.FormatCSV:  ExportCSV,
+	export.FormatJSON: ExportJSON,
+	export.FormatXLSX: ExportXLSX,
+}
+
+// CreateExportKeyboard offers the formats the data can be exported in, and
+// the progress photos.
+func CreateExportKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(exportRows(locale)...)
+}
+
+func exportRows(locale string) [][]tgbotapi.InlineKeyboardButton {
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(export.Formats))
+	for _, format := range export.Formats {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, exportButtons[format]),
+			callbackdata.New(callbackdata.TypeExport, format).String(),
+		))
+	}
+	return [][]tgbotapi.InlineKeyboardButton{
+		row,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExportPhotos),
+				callbackdata.New(callbackdata.TypeExport, ExportPhotosAction).String(),
+			),
+		),
+	}
+}
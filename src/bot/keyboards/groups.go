@@ -0,0 +1,81 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/groups"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var groupPrivacyButtons = map[string]string{
+	models.GroupPrivacyPublic:     GroupPrivacyPublic,
+	models.GroupPrivacyHideVolume: GroupPrivacyHideVolume,
+	models.GroupPrivacyAnonymous:  GroupPrivacyAnonymous,
+}
+
+// CreateGroupPrivacyKeyboard offers how the user appears in groups,
+// marking the current choice.
+func CreateGroupPrivacyKeyboard(locale string, current string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, privacy := range models.GroupPrivacies {
+		label := i18n.T(locale, groupPrivacyButtons[privacy])
+		if privacy == current {
+			label = "✅ " + label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, groupData("privacy", privacy)),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack),
+			callbackdata.New(callbackdata.TypeSettings, "back").String(),
+		),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// CreateChallengesKeyboard joins the group's running challenges and starts
+// the kinds that are not running.
+func CreateChallengesKeyboard(
+	locale string,
+	running []models.Challenge,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	active := make(map[string]bool, len(running))
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i := range running {
+		challenge, ok := groups.Find(running[i].Kind)
+		if !ok {
+			continue
+		}
+		active[challenge.Kind] = true
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ChallengeJoin, views.ChallengeName(locale, challenge)),
+				p.data(callbackdata.TypeGroup, "join", running[i].ID.String()),
+			),
+		))
+	}
+	for i := range groups.Challenges {
+		challenge := &groups.Challenges[i]
+		if active[challenge.Kind] {
+			continue
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ChallengeStart, views.ChallengeName(locale, challenge)),
+				groupData("start", challenge.Kind),
+			),
+		))
+	}
+	return p.markup(rows)
+}
+
+func groupData(action string, args ...string) string {
+	return callbackdata.New(callbackdata.TypeGroup, action, args...).String()
+}
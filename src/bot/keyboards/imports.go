@@ -0,0 +1,36 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// CreateImportAliasKeyboard offers catalog exercises for a name from
+// another app and a button to leave the name out.
+func CreateImportAliasKeyboard(
+	locale string,
+	exercises []models.Exercise,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(exercises)+1)
+	for i := range exercises {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.ExerciseName(locale, &exercises[i]),
+				p.data(callbackdata.TypeImport, "map", exercises[i].ID.String()),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, ImportSkip),
+			callbackdata.New(callbackdata.TypeImport, "skip").String(),
+		),
+	))
+	return p.markup(rows)
+}
@@ -1,17 +1,122 @@
 package keyboards
 
 import (
-	"fmt"
+	"strconv"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-func CreateSettingsKeyboard() tgbotapi.InlineKeyboardMarkup {
+func CreateSettingsKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				SettingsExperience,
-				"settings:experience",
+				i18n.T(locale, SettingsGoals),
+				callbackdata.New(callbackdata.TypeSettings, "goal").String(),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, SettingsExperience),
+				callbackdata.New(callbackdata.TypeSettings, "experience").String(),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, SettingsProgression),
+				callbackdata.New(callbackdata.TypeSettings, "progression").String(),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, SettingsEquipment),
+				equipmentData("menu"),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, SettingsCalendar),
+				calendarData("menu"),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, SettingsGroups),
+				groupData("privacy"),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, SettingsLanguage),
+				callbackdata.New(callbackdata.TypeSettings, "language").String(),
+			),
+		),
+	)
+
+	return keyboard
+}
+
+func CreateExperienceLevelKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExpBeginner),
+				experienceData(0),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExpIntermediate),
+				experienceData(1),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExpAdvanced),
+				experienceData(3),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ExpExpert),
+				experienceData(5),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				callbackdata.New(callbackdata.TypeSettings, "back").String(),
+			),
+		),
+	)
+
+	return keyboard
+}
+
+func CreateGoalKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, GoalMuscleGain),
+				goalData(models.GoalMuscleGain),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, GoalStrength),
+				goalData(models.GoalStrength),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, GoalEndurance),
+				goalData(models.GoalEndurance),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, GoalWeightLoss),
+				goalData(models.GoalWeightLoss),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				callbackdata.New(callbackdata.TypeSettings, "back").String(),
 			),
 		),
 	)
@@ -19,32 +124,59 @@ func CreateSettingsKeyboard() tgbotapi.InlineKeyboardMarkup {
 	return keyboard
 }
 
-func CreateExperienceLevelKeyboard() tgbotapi.InlineKeyboardMarkup {
+func CreateProgressionKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				ExpBeginner,
-				"experience:0",
+				i18n.T(locale, ProgressionLinear),
+				progressionData(models.ProgressionLinear),
 			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				ExpIntermediate,
-				"experience:1",
+				i18n.T(locale, ProgressionDouble),
+				progressionData(models.ProgressionDouble),
 			),
 		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				ExpAdvanced,
-				"experience:3",
+				i18n.T(locale, ProgressionRPE),
+				progressionData(models.ProgressionRPE),
 			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				ExpExpert,
-				"experience:5",
+				i18n.T(locale, NavBack),
+				callbackdata.New(callbackdata.TypeSettings, "back").String(),
 			),
 		),
+	)
+
+	return keyboard
+}
+
+func CreateLanguageKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				NavBack,
-				"settings:experience_back",
+				i18n.T(locale, LanguageRU),
+				callbackdata.New(callbackdata.TypeLanguage, "set", i18n.RU).String(),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, LanguageEN),
+				callbackdata.New(callbackdata.TypeLanguage, "set", i18n.EN).String(),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, LanguageAuto),
+				callbackdata.New(callbackdata.TypeLanguage, "set", "").String(),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				callbackdata.New(callbackdata.TypeSettings, "back").String(),
 			),
 		),
 	)
@@ -52,16 +184,16 @@ func CreateExperienceLevelKeyboard() tgbotapi.InlineKeyboardMarkup {
 	return keyboard
 }
 
-func CreateConfirmationKeyboard(action string) tgbotapi.InlineKeyboardMarkup {
+func CreateConfirmationKeyboard(locale string, action string) tgbotapi.InlineKeyboardMarkup {
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				NavYes,
-				fmt.Sprintf("confirm:%s:yes", action),
+				i18n.T(locale, NavYes),
+				callbackdata.New(callbackdata.TypeConfirm, action, "yes").String(),
 			),
 			tgbotapi.NewInlineKeyboardButtonData(
-				NavNo,
-				fmt.Sprintf("confirm:%s:no", action),
+				i18n.T(locale, NavNo),
+				callbackdata.New(callbackdata.TypeConfirm, action, "no").String(),
 			),
 		),
 	)
@@ -69,11 +201,11 @@ func CreateConfirmationKeyboard(action string) tgbotapi.InlineKeyboardMarkup {
 	return keyboard
 }
 
-func CreateBackKeyboard(callbackData string) tgbotapi.InlineKeyboardMarkup {
+func CreateBackKeyboard(locale string, callbackData string) tgbotapi.InlineKeyboardMarkup {
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				NavBack,
+				i18n.T(locale, NavBack),
 				callbackData,
 			),
 		),
@@ -81,3 +213,15 @@ func CreateBackKeyboard(callbackData string) tgbotapi.InlineKeyboardMarkup {
 
 	return keyboard
 }
+
+func goalData(goal string) string {
+	return callbackdata.New(callbackdata.TypeGoal, "set", goal).String()
+}
+
+func progressionData(scheme string) string {
+	return callbackdata.New(callbackdata.TypeProgression, "set", scheme).String()
+}
+
+func experienceData(level int) string {
+	return callbackdata.New(callbackdata.TypeExperience, "set", strconv.Itoa(level)).String()
+}
@@ -1,16 +1,30 @@
 package keyboards
 
-import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"workouts_bot/src/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
 
 const (
-	StartMessage    = "/start"
-	SettingsMessage = "⚙️ Настройки"
+	StartMessage     = "/start"
+	BroadcastMessage = "/broadcast"
+	SettingsMessage  = "button.settings"
+	WorkoutsMessage  = "button.my_workouts"
+	ExercisesMessage = "button.exercises"
+	ProgressMessage  = "button.progress"
+	ProfileMessage   = "/profile"
 )
 
-func CreateMainMenu() tgbotapi.ReplyKeyboardMarkup {
+func CreateMainMenu(locale string) tgbotapi.ReplyKeyboardMarkup {
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(SettingsMessage),
+			tgbotapi.NewKeyboardButton(i18n.T(locale, WorkoutsMessage)),
+			tgbotapi.NewKeyboardButton(i18n.T(locale, ExercisesMessage)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(locale, ProgressMessage)),
+			tgbotapi.NewKeyboardButton(i18n.T(locale, SettingsMessage)),
 		),
 	)
 
@@ -0,0 +1,77 @@
+package keyboards
+
+import (
+	"strconv"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/body"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Quick-entry commands: "/weight 82.5" logs a value, the bare command
+// shows the history.
+const (
+	MeasurementsMessage = "/measurements"
+	WeightMessage       = "/weight"
+	BodyFatMessage      = "/bodyfat"
+	WaistMessage        = "/waist"
+	ChestMessage        = "/chest"
+	ArmsMessage         = "/arms"
+	ThighsMessage       = "/thighs"
+)
+
+// MeasurementCommands maps quick-entry commands to measurement kinds.
+var MeasurementCommands = map[string]string{
+	WeightMessage:  models.MeasurementWeight,
+	BodyFatMessage: models.MeasurementBodyFat,
+	WaistMessage:   models.MeasurementWaist,
+	ChestMessage:   models.MeasurementChest,
+	ArmsMessage:    models.MeasurementArms,
+	ThighsMessage:  models.MeasurementThighs,
+}
+
+// CreateMeasurementsKeyboard opens the history of each kind.
+func CreateMeasurementsKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, (len(body.Kinds)+1)/2)
+	for i := 0; i < len(body.Kinds); i += 2 {
+		row := tgbotapi.NewInlineKeyboardRow()
+		for _, kind := range body.Kinds[i:min(i+2, len(body.Kinds))] {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+				views.MeasurementName(locale, kind),
+				measurementHistoryData(kind, 0),
+			))
+		}
+		rows = append(rows, row)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func CreateMeasurementHistoryKeyboard(
+	locale string,
+	kind string,
+	page int,
+	pages int,
+) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, 2)
+	if row := paginationRow(locale, page, pages, func(page int) string {
+		return measurementHistoryData(kind, page)
+	}); row != nil {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack),
+			callbackdata.New(callbackdata.TypeMeasurement, "summary").String(),
+		),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func measurementHistoryData(kind string, page int) string {
+	return callbackdata.New(callbackdata.TypeMeasurement, "history", kind, strconv.Itoa(page)).String()
+}
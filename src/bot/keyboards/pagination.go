@@ -0,0 +1,55 @@
+package keyboards
+
+import (
+	"fmt"
+	"workouts_bot/src/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	CatalogPageSize = 8
+	ChoicePageSize  = 5
+)
+
+// Pages returns the number of pages needed for total items, at least one.
+func Pages(total int, size int) int {
+	if total <= 0 {
+		return 1
+	}
+	return (total + size - 1) / size
+}
+
+// ClampPage keeps page within [0, pages).
+func ClampPage(page int, pages int) int {
+	return max(0, min(page, pages-1))
+}
+
+// paginationRow builds "◀️ n/N ▶️" for multi-page lists; pageData returns
+// the callback payload that opens the given page.
+func paginationRow(
+	locale string,
+	page int,
+	pages int,
+	pageData func(page int) string,
+) []tgbotapi.InlineKeyboardButton {
+	if pages <= 1 {
+		return nil
+	}
+
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 3)
+	if page > 0 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, PagePrev), pageData(page-1),
+		))
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+		fmt.Sprintf("%d/%d", page+1, pages), pageData(page),
+	))
+	if page < pages-1 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, PageNext), pageData(page+1),
+		))
+	}
+	return row
+}
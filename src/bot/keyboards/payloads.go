@@ -0,0 +1,35 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// payloads encodes the payloads of one keyboard that carry IDs or user
+// input. The codec stores those that do not fit into callback_data on the
+// server. Only the first error is kept; a keyboard with an error must not
+// be sent.
+type payloads struct {
+	codec *callbackdata.Codec
+	err   error
+}
+
+func (p *payloads) data(callbackType string, action string, args ...string) string {
+	if p.err != nil {
+		return ""
+	}
+	encoded, err := p.codec.Encode(callbackdata.New(callbackType, action, args...))
+	if err != nil {
+		p.err = err
+	}
+	return encoded
+}
+
+// markup returns the keyboard, or the first encoding error.
+func (p *payloads) markup(rows [][]tgbotapi.InlineKeyboardButton) (tgbotapi.InlineKeyboardMarkup, error) {
+	if p.err != nil {
+		return tgbotapi.InlineKeyboardMarkup{}, p.err
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+}
@@ -0,0 +1,127 @@
+package keyboards
+
+import (
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/programs"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	ProgramsMessage  = "/programs"
+	OneRepMaxMessage = "/1rm"
+)
+
+// CreateProgramListKeyboard lists the program library, with the program
+// the user follows, if any, on top.
+func CreateProgramListKeyboard(
+	locale string,
+	enrollment *models.ProgramEnrollment,
+) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(programs.Library)+1)
+	if enrollment != nil {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgramCurrent, views.ProgramName(locale, enrollment.Program)),
+				programData("current"),
+			),
+		))
+	}
+	for _, program := range programs.Library {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.ProgramName(locale, program.Slug),
+				programData("view", program.Slug),
+			),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func CreateProgramKeyboard(locale string, slug string) tgbotapi.InlineKeyboardMarkup {
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgramEnroll),
+				programData("enroll", slug),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				programData("list"),
+			),
+		),
+	)
+
+	return keyboard
+}
+
+func CreateEnrollmentKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgramStart),
+				programData("start"),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgramMaxes),
+				programData("maxes"),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, Programs),
+				programData("list"),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgramLeave),
+				programData("leave"),
+			),
+		),
+	)
+
+	return keyboard
+}
+
+// CreateTrainingMaxesKeyboard has a button to change each training max the
+// program uses. exercises and maxes are keyed by slug.
+func CreateTrainingMaxesKeyboard(
+	locale string,
+	program *programs.Program,
+	exercises map[string]models.Exercise,
+	maxes map[string]float64,
+) tgbotapi.InlineKeyboardMarkup {
+	slugs := program.Maxes()
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(slugs)+1)
+	for _, slug := range slugs {
+		exercise, ok := exercises[slug]
+		if !ok {
+			continue
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.TrainingMax(locale, &exercise, maxes[slug]),
+				programData("max", slug),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack),
+			programData("current"),
+		),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func programData(action string, args ...string) string {
+	return callbackdata.New(callbackdata.TypeProgram, action, args...).String()
+}
@@ -0,0 +1,150 @@
+package keyboards
+
+import (
+	"strconv"
+	"time"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Poses lists progress photo poses in the order they are offered.
+var Poses = []string{
+	models.PoseFront,
+	models.PoseSide,
+	models.PoseBack,
+}
+
+func CreateProgressKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgressAdd),
+				progressData("add"),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgressHistory),
+				progressData("history", "0"),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgressCompare),
+				progressData("compare"),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ProgressMeasurements),
+				callbackdata.New(callbackdata.TypeMeasurement, "summary").String(),
+			),
+		),
+	)
+
+	return keyboard
+}
+
+// CreatePoseKeyboard offers the poses; action receives the pose followed
+// by extra args.
+func CreatePoseKeyboard(locale string, action string, extra ...string) tgbotapi.InlineKeyboardMarkup {
+	row := tgbotapi.NewInlineKeyboardRow()
+	for _, pose := range Poses {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			views.Pose(locale, pose),
+			progressData(action, append([]string{pose}, extra...)...),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				progressData("menu"),
+			),
+		),
+	)
+}
+
+func CreateProgressHistoryKeyboard(
+	locale string,
+	dates []time.Time,
+	page int,
+	pages int,
+) tgbotapi.InlineKeyboardMarkup {
+	return progressDatesKeyboard(
+		locale, dates, page, pages,
+		func(day string) string { return progressData("day", day) },
+		func(page int) string { return progressData("history", strconv.Itoa(page)) },
+		progressData("menu"),
+	)
+}
+
+// CreateCompareFromKeyboard picks the earlier day of the comparison.
+func CreateCompareFromKeyboard(
+	locale string,
+	pose string,
+	dates []time.Time,
+	page int,
+	pages int,
+) tgbotapi.InlineKeyboardMarkup {
+	return progressDatesKeyboard(
+		locale, dates, page, pages,
+		func(day string) string { return progressData("to", pose, day, "0") },
+		func(page int) string { return progressData("from", pose, strconv.Itoa(page)) },
+		progressData("compare"),
+	)
+}
+
+// CreateCompareToKeyboard picks the later day; from is the earlier day in
+// views.DateLayout.
+func CreateCompareToKeyboard(
+	locale string,
+	pose string,
+	from string,
+	dates []time.Time,
+	page int,
+	pages int,
+) tgbotapi.InlineKeyboardMarkup {
+	return progressDatesKeyboard(
+		locale, dates, page, pages,
+		func(day string) string { return progressData("collage", pose, from, day) },
+		func(page int) string { return progressData("to", pose, from, strconv.Itoa(page)) },
+		progressData("from", pose, "0"),
+	)
+}
+
+func progressDatesKeyboard(
+	locale string,
+	dates []time.Time,
+	page int,
+	pages int,
+	dayData func(day string) string,
+	pageData func(page int) string,
+	back string,
+) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(dates)+2)
+	for _, day := range dates {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.Date(locale, day),
+				dayData(day.Format(views.DateLayout)),
+			),
+		))
+	}
+	if row := paginationRow(locale, page, pages, pageData); row != nil {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, NavBack), back),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func progressData(action string, args ...string) string {
+	return callbackdata.New(callbackdata.TypeProgress, action, args...).String()
+}
@@ -0,0 +1,102 @@
+package keyboards
+
+import (
+	"strconv"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// RPEOptions are the efforts offered when sets are rated, the same range
+// typed sets accept.
+var RPEOptions = []string{"6", "7", "8", "9", "10"}
+
+// CreateSessionKeyboard controls the current set. Set buttons carry the set
+// number they log, so a repeated press does not log the set twice. With the
+// RPE scheme working sets other than intervals are completed by rating
+// them; recommend adds a button to accept the recommended targets. The last
+// logged set can be extended with a drop set or a rest-pause set. Cardio
+// exercises are logged as an activity instead of sets.
+func CreateSessionKeyboard(
+	locale string,
+	session *models.WorkoutSession,
+	scheme string,
+	recommend bool,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, 4)
+	if current := session.Current(); current != nil {
+		id := current.ID.String()
+		number := strconv.Itoa(current.Logged() + 1)
+
+		if recommend {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					i18n.T(locale, SetAccept),
+					p.data(callbackdata.TypeSession, "accept", id),
+				),
+			))
+		}
+
+		other := tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, SetOther),
+			p.data(callbackdata.TypeSession, "other", id, number),
+		)
+		skip := tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, SetSkip),
+			p.data(callbackdata.TypeSession, "skip", id, number),
+		)
+
+		rated := !current.Target(current.Logged()+1).Warmup && !current.Intervals()
+		switch {
+		case current.Exercise.Cardio():
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					i18n.T(locale, SetActivity),
+					p.data(callbackdata.TypeSession, "cardio", id, number),
+				),
+				skip,
+			))
+		case scheme == models.ProgressionRPE && rated:
+			row := tgbotapi.NewInlineKeyboardRow()
+			for _, rpe := range RPEOptions {
+				row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+					i18n.T(locale, "sessions.rpe", rpe),
+					p.data(callbackdata.TypeSession, "complete", id, number, rpe),
+				))
+			}
+			rows = append(rows, row, tgbotapi.NewInlineKeyboardRow(other, skip))
+		default:
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					i18n.T(locale, SetComplete),
+					p.data(callbackdata.TypeSession, "complete", id, number),
+				),
+			), tgbotapi.NewInlineKeyboardRow(other, skip))
+		}
+	}
+	if exercise := session.LastExercise(); exercise != nil && exercise.LastWorkSet() != nil {
+		id := exercise.ID.String()
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, SetDrop),
+				p.data(callbackdata.TypeSession, "sub", id, models.SetKindDrop),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, SetRestPause),
+				p.data(callbackdata.TypeSession, "sub", id, models.SetKindRestPause),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, WorkoutFinish),
+			p.data(callbackdata.TypeSession, "finish", session.ID.String()),
+		),
+	))
+
+	return p.markup(rows)
+}
@@ -0,0 +1,208 @@
+package keyboards
+
+import (
+	"fmt"
+	"workouts_bot/src/bot/callbackdata"
+	"workouts_bot/src/bot/views"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+)
+
+// CreateWorkoutListKeyboard lists the user's templates, with the active
+// session, if any, on top.
+func CreateWorkoutListKeyboard(
+	locale string,
+	templates []models.WorkoutTemplate,
+	session *models.WorkoutSession,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(templates)+2)
+	if session != nil {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, "sessions.continue", session.Name),
+				p.data(callbackdata.TypeSession, "open", session.ID.String()),
+			),
+		))
+	}
+	for _, template := range templates {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				template.Name,
+				workoutData(p, "open", template.ID),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, WorkoutTypeCustom),
+			callbackdata.New(callbackdata.TypeWorkout, "create").String(),
+		),
+	), tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, Programs),
+			programData("list"),
+		),
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, WorkoutStats),
+			callbackdata.New(callbackdata.TypeWorkout, "stats").String(),
+		),
+	))
+
+	return p.markup(rows)
+}
+
+func CreateWorkoutKeyboard(
+	locale string,
+	templateID uuid.UUID,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	return p.markup([][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, WorkoutStart),
+				p.data(callbackdata.TypeSession, "start", templateID.String()),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, WorkoutEdit),
+				workoutData(p, "edit", templateID),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, WorkoutDuplicate),
+				workoutData(p, "duplicate", templateID),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, WorkoutDelete),
+				workoutData(p, "delete", templateID),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				callbackdata.New(callbackdata.TypeWorkout, "list").String(),
+			),
+		),
+	})
+}
+
+func CreateWorkoutEditKeyboard(
+	locale string,
+	template *models.WorkoutTemplate,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(template.Exercises)+2)
+	for i := range template.Exercises {
+		item := &template.Exercises[i]
+		label := fmt.Sprintf("%d. %s", i+1, views.ExerciseName(locale, &item.Exercise))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, workoutData(p, "item", item.ID)),
+		))
+	}
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, WorkoutAddExercise),
+				workoutData(p, "add", template.ID),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, WorkoutRename),
+				workoutData(p, "rename", template.ID),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				workoutData(p, "open", template.ID),
+			),
+		),
+	)
+
+	return p.markup(rows)
+}
+
+// CreateTemplateItemKeyboard edits one exercise of the template; the link
+// button joins it with the next exercise into a superset or splits them.
+func CreateTemplateItemKeyboard(
+	locale string,
+	item *models.TemplateExercise,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	link := ItemLink
+	if item.Linked {
+		link = ItemUnlink
+	}
+
+	return p.markup([][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ItemUp),
+				workoutData(p, "up", item.ID),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ItemDown),
+				workoutData(p, "down", item.ID),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ItemTargets),
+				workoutData(p, "targets", item.ID),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, ItemRemove),
+				workoutData(p, "remove", item.ID),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, link), workoutData(p, "link", item.ID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, NavBack),
+				workoutData(p, "edit", item.TemplateID),
+			),
+		),
+	})
+}
+
+// CreateExerciseChoiceKeyboard lists search results to add to the template.
+func CreateExerciseChoiceKeyboard(
+	locale string,
+	templateID uuid.UUID,
+	exercises []models.Exercise,
+	codec *callbackdata.Codec,
+) (tgbotapi.InlineKeyboardMarkup, error) {
+	p := &payloads{codec: codec}
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(exercises)+1)
+	for i := range exercises {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				views.ExerciseName(locale, &exercises[i]),
+				p.data(callbackdata.TypeWorkout, "pick", templateID.String(), exercises[i].ID.String()),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, NavBack),
+			workoutData(p, "edit", templateID),
+		),
+	))
+
+	return p.markup(rows)
+}
+
+func workoutData(p *payloads, action string, id uuid.UUID) string {
+	return p.data(callbackdata.TypeWorkout, action, id.String())
+}
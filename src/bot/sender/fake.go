@@ -0,0 +1,78 @@
+package sender
+
+import (
+	"context"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Fake records outgoing calls instead of sending them, for testing
+// handlers. Sent messages get increasing message IDs. When Err is set,
+// every call fails with it.
+type Fake struct {
+	Err error
+
+	mu    sync.Mutex
+	calls []tgbotapi.Chattable
+}
+
+func (f *Fake) Send(chattable tgbotapi.Chattable) (tgbotapi.Message, error) {
+	return f.SendContext(context.Background(), chattable)
+}
+
+func (f *Fake) Request(chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	return f.RequestContext(context.Background(), chattable)
+}
+
+func (f *Fake) SendContext(ctx context.Context, chattable tgbotapi.Chattable) (tgbotapi.Message, error) {
+	if err := f.record(ctx, chattable); err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return tgbotapi.Message{
+		MessageID: len(f.calls),
+		Chat:      &tgbotapi.Chat{ID: chatIDOf(chattable)},
+	}, nil
+}
+
+func (f *Fake) RequestContext(ctx context.Context, chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	if err := f.record(ctx, chattable); err != nil {
+		return nil, err
+	}
+	return &tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func (f *Fake) record(ctx context.Context, chattable tgbotapi.Chattable) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if f.Err != nil {
+		return f.Err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, chattable)
+	return nil
+}
+
+// Calls returns the calls made so far, oldest first.
+func (f *Fake) Calls() []tgbotapi.Chattable {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]tgbotapi.Chattable(nil), f.calls...)
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (f *Fake) Messages() []tgbotapi.MessageConfig {
+	var messages []tgbotapi.MessageConfig
+	for _, call := range f.Calls() {
+		if message, ok := call.(tgbotapi.MessageConfig); ok {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
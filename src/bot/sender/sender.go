@@ -0,0 +1,371 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+	"workouts_bot/src/logger"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// Telegram allows about 30 messages per second overall, one message per
+// second to the same private chat and 20 messages per minute to a group.
+const (
+	globalInterval  = time.Second / 30
+	privateInterval = time.Second
+	groupInterval   = time.Minute / 20
+
+	maxAttempts    = 5
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 30 * time.Second
+)
+
+var ErrClosed = errors.New("sender is closed")
+
+// Sender is the subset of the Telegram API used by handlers. Send and
+// Request give up when the sender is closed; the Context variants also
+// give up when ctx is done.
+type Sender interface {
+	Send(chattable tgbotapi.Chattable) (tgbotapi.Message, error)
+	Request(chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+	SendContext(ctx context.Context, chattable tgbotapi.Chattable) (tgbotapi.Message, error)
+	RequestContext(ctx context.Context, chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+}
+
+// botAPI is the part of tgbotapi.BotAPI the calls go through.
+type botAPI interface {
+	Send(chattable tgbotapi.Chattable) (tgbotapi.Message, error)
+	Request(chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+}
+
+// Telegram wraps a BotAPI, spacing outgoing calls to stay within the
+// Telegram limits and retrying rate-limited or transient failures.
+type Telegram struct {
+	api botAPI
+
+	// backoff is the wait before the first retry of a transient failure;
+	// it doubles with each attempt up to maxBackoff.
+	backoff time.Duration
+
+	// ctx is cancelled when Close gives up waiting, so that calls sleeping
+	// on a rate limit or a backoff return instead of holding up shutdown.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu         sync.Mutex
+	globalNext time.Time
+	chatNext   map[int64]time.Time
+	closed     bool
+	pending    sync.WaitGroup
+	inQueue    int
+}
+
+func NewTelegram(api *tgbotapi.BotAPI) *Telegram {
+	return newTelegram(api)
+}
+
+func newTelegram(api botAPI) *Telegram {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Telegram{
+		api:      api,
+		backoff:  initialBackoff,
+		ctx:      ctx,
+		cancel:   cancel,
+		chatNext: make(map[int64]time.Time),
+	}
+}
+
+func (t *Telegram) Send(chattable tgbotapi.Chattable) (tgbotapi.Message, error) {
+	return t.SendContext(t.ctx, chattable)
+}
+
+func (t *Telegram) Request(chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	return t.RequestContext(t.ctx, chattable)
+}
+
+func (t *Telegram) SendContext(ctx context.Context, chattable tgbotapi.Chattable) (tgbotapi.Message, error) {
+	var message tgbotapi.Message
+	err := t.do(ctx, chattable, func() error {
+		var err error
+		message, err = t.api.Send(chattable)
+		return err
+	})
+	return message, err
+}
+
+func (t *Telegram) RequestContext(ctx context.Context, chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	var response *tgbotapi.APIResponse
+	err := t.do(ctx, chattable, func() error {
+		var err error
+		response, err = t.api.Request(chattable)
+		return err
+	})
+	return response, err
+}
+
+// Close stops accepting new calls and waits for queued ones to finish.
+// When ctx expires it cancels the calls still waiting and returns how
+// many were pending.
+func (t *Telegram) Close(ctx context.Context) (int, error) {
+	t.mu.Lock()
+	t.closed = true
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return 0, nil
+	case <-ctx.Done():
+		t.cancel()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return t.inQueue, ctx.Err()
+	}
+}
+
+func (t *Telegram) do(ctx context.Context, chattable tgbotapi.Chattable, call func() error) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return ErrClosed
+	}
+	t.pending.Add(1)
+	t.inQueue++
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		t.inQueue--
+		t.mu.Unlock()
+		t.pending.Done()
+	}()
+
+	chatID := chatIDOf(chattable)
+	backoff := t.backoff
+	uploads, rewindable := rewinder(chattable)
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err := t.wait(ctx, chatID); err != nil {
+			if t.ctx.Err() != nil {
+				return ErrClosed
+			}
+			return fmt.Errorf("telegram %T: %w", chattable, err)
+		}
+		if attempt > 1 {
+			if err := uploads.rewind(); err != nil {
+				return fmt.Errorf("telegram %T: %w", chattable, err)
+			}
+		}
+
+		err = call()
+		if err == nil {
+			return nil
+		}
+
+		delay, retry := retryDelay(err, backoff)
+		if !retry || !rewindable || attempt == maxAttempts {
+			break
+		}
+
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"request": fmt.Sprintf("%T", chattable),
+			"attempt": attempt,
+			"delay":   delay.String(),
+			"error":   err,
+		}).Warn("Telegram request failed, retrying")
+
+		t.delay(chatID, delay)
+		backoff = min(backoff*2, maxBackoff)
+	}
+
+	return fmt.Errorf("telegram %T: %w", chattable, err)
+}
+
+// wait blocks until both the global and the per-chat slot are free and
+// reserves them for this call, or until ctx is done.
+func (t *Telegram) wait(ctx context.Context, chatID int64) error {
+	t.mu.Lock()
+	now := time.Now()
+	slot := now
+	if t.globalNext.After(slot) {
+		slot = t.globalNext
+	}
+	if chatID != 0 {
+		if next := t.chatNext[chatID]; next.After(slot) {
+			slot = next
+		}
+		t.chatNext[chatID] = slot.Add(chatInterval(chatID))
+	}
+	t.globalNext = slot.Add(globalInterval)
+	t.pruneLocked(now)
+	t.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(slot))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// delay pushes the next slot for the chat (or all chats when the chat is
+// unknown) at least d into the future.
+func (t *Telegram) delay(chatID int64, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if chatID == 0 {
+		if t.globalNext.Before(until) {
+			t.globalNext = until
+		}
+		return
+	}
+	if t.chatNext[chatID].Before(until) {
+		t.chatNext[chatID] = until
+	}
+}
+
+func (t *Telegram) pruneLocked(now time.Time) {
+	for chatID, next := range t.chatNext {
+		if next.Before(now) {
+			delete(t.chatNext, chatID)
+		}
+	}
+}
+
+// IsBlocked reports whether err means the user blocked the bot or the chat
+// is otherwise unreachable for good.
+func IsBlocked(err error) bool {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Code == http.StatusForbidden
+}
+
+func retryDelay(err error, backoff time.Duration) (time.Duration, bool) {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) {
+		// Network failures and undecodable responses are transient.
+		return backoff, true
+	}
+
+	switch {
+	case apiErr.Code == http.StatusTooManyRequests:
+		if apiErr.RetryAfter > 0 {
+			return time.Duration(apiErr.RetryAfter) * time.Second, true
+		}
+		return backoff, true
+	case apiErr.Code >= http.StatusInternalServerError:
+		return backoff, true
+	default:
+		return 0, false
+	}
+}
+
+// readers are the streamed uploads of a call with the offsets they start
+// at, so that a retry sends them from the beginning again.
+type readers map[io.Seeker]int64
+
+func (r readers) rewind() error {
+	for seeker, offset := range r {
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewinder collects the streamed uploads of the call. It reports false
+// when one of them cannot be read again, and the call must not be retried
+// since a retry would send what is left of an already consumed reader.
+// Bytes, paths, URLs and file IDs are re-read on every attempt.
+func rewinder(chattable tgbotapi.Chattable) (readers, bool) {
+	uploads := readers{}
+	for _, file := range filesOf(chattable) {
+		reader, ok := file.(tgbotapi.FileReader)
+		if !ok {
+			continue
+		}
+		seeker, ok := reader.Reader.(io.Seeker)
+		if !ok {
+			return nil, false
+		}
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, false
+		}
+		uploads[seeker] = offset
+	}
+	return uploads, true
+}
+
+func filesOf(chattable tgbotapi.Chattable) []tgbotapi.RequestFileData {
+	switch c := chattable.(type) {
+	case tgbotapi.PhotoConfig:
+		return []tgbotapi.RequestFileData{c.File, c.Thumb}
+	case tgbotapi.DocumentConfig:
+		return []tgbotapi.RequestFileData{c.File, c.Thumb}
+	case tgbotapi.VideoConfig:
+		return []tgbotapi.RequestFileData{c.File, c.Thumb}
+	case tgbotapi.AnimationConfig:
+		return []tgbotapi.RequestFileData{c.File, c.Thumb}
+	case tgbotapi.AudioConfig:
+		return []tgbotapi.RequestFileData{c.File, c.Thumb}
+	case tgbotapi.VoiceConfig:
+		return []tgbotapi.RequestFileData{c.File, c.Thumb}
+	case tgbotapi.VideoNoteConfig:
+		return []tgbotapi.RequestFileData{c.File, c.Thumb}
+	case tgbotapi.StickerConfig:
+		return []tgbotapi.RequestFileData{c.File}
+	default:
+		return nil
+	}
+}
+
+func chatInterval(chatID int64) time.Duration {
+	if chatID < 0 {
+		return groupInterval
+	}
+	return privateInterval
+}
+
+func chatIDOf(chattable tgbotapi.Chattable) int64 {
+	switch c := chattable.(type) {
+	case tgbotapi.MessageConfig:
+		return c.ChatID
+	case tgbotapi.EditMessageTextConfig:
+		return c.ChatID
+	case tgbotapi.EditMessageReplyMarkupConfig:
+		return c.ChatID
+	case tgbotapi.PhotoConfig:
+		return c.ChatID
+	case tgbotapi.DocumentConfig:
+		return c.ChatID
+	case tgbotapi.VideoConfig:
+		return c.ChatID
+	case tgbotapi.AnimationConfig:
+		return c.ChatID
+	case tgbotapi.DeleteMessageConfig:
+		return c.ChatID
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,212 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"workouts_bot/src/logger"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestWaitCancelled(t *testing.T) {
+	telegram := NewTelegram(nil)
+	telegram.delay(42, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	if err := telegram.wait(ctx, 42); err == nil {
+		t.Fatal("wait returned nil for a slot a minute away")
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("wait took %s after ctx expired", elapsed)
+	}
+}
+
+func TestRewinder(t *testing.T) {
+	tests := []struct {
+		name       string
+		chattable  tgbotapi.Chattable
+		rewindable bool
+	}{
+		{"text", tgbotapi.NewMessage(1, "hi"), true},
+		{"bytes", tgbotapi.NewPhoto(1, tgbotapi.FileBytes{Name: "a.jpg", Bytes: []byte("a")}), true},
+		{"file id", tgbotapi.NewPhoto(1, tgbotapi.FileID("id")), true},
+		{"seeker", tgbotapi.NewDocument(1, tgbotapi.FileReader{Name: "a", Reader: bytes.NewReader([]byte("a"))}), true},
+		{"stream", tgbotapi.NewDocument(1, tgbotapi.FileReader{Name: "a", Reader: io.NopCloser(strings.NewReader("a"))}), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, rewindable := rewinder(test.chattable); rewindable != test.rewindable {
+				t.Errorf("rewindable = %v, want %v", rewindable, test.rewindable)
+			}
+		})
+	}
+}
+
+func TestRewind(t *testing.T) {
+	reader := bytes.NewReader([]byte("0123456789"))
+	_, _ = reader.Seek(3, io.SeekStart)
+
+	uploads, ok := rewinder(tgbotapi.NewDocument(1, tgbotapi.FileReader{Name: "a", Reader: reader}))
+	if !ok {
+		t.Fatal("seekable reader is not rewindable")
+	}
+	_, _ = io.ReadAll(reader)
+
+	if err := uploads.rewind(); err != nil {
+		t.Fatal(err)
+	}
+	if rest, _ := io.ReadAll(reader); string(rest) != "3456789" {
+		t.Errorf("read %q after rewind, want %q", rest, "3456789")
+	}
+}
+
+// scriptedAPI answers calls with its errors in turn, then succeeds.
+type scriptedAPI struct {
+	errors []error
+	calls  int
+}
+
+func (a *scriptedAPI) Send(chattable tgbotapi.Chattable) (tgbotapi.Message, error) {
+	_, err := a.Request(chattable)
+	return tgbotapi.Message{}, err
+}
+
+func (a *scriptedAPI) Request(tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	a.calls++
+	if a.calls <= len(a.errors) {
+		return nil, a.errors[a.calls-1]
+	}
+	return &tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func repeat(err error, n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+func TestDoRetries(t *testing.T) {
+	logger.InitSimple("panic")
+	network := errors.New("connection reset by peer")
+	badGateway := &tgbotapi.Error{Code: http.StatusBadGateway, Message: "Bad Gateway"}
+	badRequest := &tgbotapi.Error{Code: http.StatusBadRequest, Message: "Bad Request: message is not modified"}
+	blocked := &tgbotapi.Error{Code: http.StatusForbidden, Message: "Forbidden: bot was blocked by the user"}
+	stream := tgbotapi.NewDocument(1, tgbotapi.FileReader{Name: "a", Reader: io.NopCloser(strings.NewReader("a"))})
+
+	tests := []struct {
+		name      string
+		chattable tgbotapi.Chattable
+		errors    []error
+		wantCalls int
+		wantErr   error
+	}{
+		{"success", tgbotapi.NewCallback("1", ""), nil, 1, nil},
+		{"network errors are retried", tgbotapi.NewCallback("1", ""), repeat(network, 2), 3, nil},
+		{"server errors are retried", tgbotapi.NewCallback("1", ""), repeat(badGateway, 4), 5, nil},
+		{"gives up after max attempts", tgbotapi.NewCallback("1", ""), repeat(badGateway, maxAttempts), maxAttempts, badGateway},
+		{"bad request is not retried", tgbotapi.NewCallback("1", ""), []error{badRequest}, 1, badRequest},
+		{"blocked is not retried", tgbotapi.NewCallback("1", ""), []error{blocked}, 1, blocked},
+		{"consumed upload is not retried", stream, []error{badGateway}, 1, badGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &scriptedAPI{errors: tt.errors}
+			telegram := newTelegram(api)
+			telegram.backoff = time.Millisecond
+
+			_, err := telegram.Request(tt.chattable)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Request error = %v, want %v", err, tt.wantErr)
+			}
+			if api.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", api.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDoHonorsRetryAfter(t *testing.T) {
+	logger.InitSimple("panic")
+	limited := &tgbotapi.Error{
+		Code:               http.StatusTooManyRequests,
+		Message:            "Too Many Requests: retry after 30",
+		ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 30},
+	}
+	api := &scriptedAPI{errors: []error{limited}}
+	telegram := newTelegram(api)
+	telegram.backoff = time.Millisecond
+
+	// The retry waits for the chat's slot 30 seconds away; ctx gives up
+	// long before.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err := telegram.SendContext(ctx, tgbotapi.NewMessage(42, "hi"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendContext error = %v, want the deadline", err)
+	}
+	if api.calls != 1 {
+		t.Errorf("calls = %d, want 1 before retry_after passes", api.calls)
+	}
+	if next := telegram.chatNext[42]; next.Before(started.Add(29 * time.Second)) {
+		t.Errorf("next slot in %s, want at least retry_after", next.Sub(started))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	backoff := 2 * time.Second
+	tests := []struct {
+		name      string
+		err       error
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{"network", errors.New("timeout"), backoff, true},
+		{"retry after", &tgbotapi.Error{Code: 429, ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 7}}, 7 * time.Second, true},
+		{"rate limited without retry after", &tgbotapi.Error{Code: 429}, backoff, true},
+		{"server error", &tgbotapi.Error{Code: 500}, backoff, true},
+		{"bad request", &tgbotapi.Error{Code: 400}, 0, false},
+		{"forbidden", &tgbotapi.Error{Code: 403}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := retryDelay(tt.err, backoff)
+			if delay != tt.wantDelay || retry != tt.wantRetry {
+				t.Errorf("retryDelay = %s, %v, want %s, %v", delay, retry, tt.wantDelay, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestDelay(t *testing.T) {
+	telegram := NewTelegram(nil)
+	started := time.Now()
+
+	telegram.delay(42, time.Minute)
+	telegram.delay(42, time.Second)
+	if next := telegram.chatNext[42]; next.Before(started.Add(time.Minute)) {
+		t.Errorf("chat slot in %s, want a shorter delay not to bring it forward", next.Sub(started))
+	}
+	if !telegram.globalNext.IsZero() {
+		t.Error("a chat's delay held up every chat")
+	}
+
+	telegram.delay(0, time.Minute)
+	if telegram.globalNext.Before(started.Add(time.Minute)) {
+		t.Errorf("global slot in %s, want a minute", telegram.globalNext.Sub(started))
+	}
+}
@@ -0,0 +1,62 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the step of a multi-message dialog a user is currently in.
+type State struct {
+	Name      string
+	Data      map[string]string
+	ExpiresAt time.Time
+}
+
+// Store keeps dialog states in memory, keyed by Telegram user ID.
+type Store struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	states map[int64]State
+}
+
+func NewStore(ttl time.Duration) *Store {
+	return &Store{
+		ttl:    ttl,
+		states: make(map[int64]State),
+	}
+}
+
+func (s *Store) Set(userID int64, name string, data map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if data == nil {
+		data = make(map[string]string)
+	}
+	s.states[userID] = State{
+		Name:      name,
+		Data:      data,
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+}
+
+func (s *Store) Get(userID int64) (State, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.states[userID]
+	if !ok {
+		return State{}, false
+	}
+	if time.Now().After(current.ExpiresAt) {
+		delete(s.states, userID)
+		return State{}, false
+	}
+	return current, true
+}
+
+func (s *Store) Clear(userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, userID)
+}
@@ -0,0 +1,107 @@
+package views
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/achievements"
+)
+
+// nextGoals is how many unearned achievements the profile shows progress
+// towards.
+const nextGoals = 3
+
+func AchievementName(locale string, rule *achievements.Rule) string {
+	return rule.Badge + " " + i18n.T(locale, "achievement."+rule.Slug)
+}
+
+// NewAchievements lists achievements earned by a finished session.
+func NewAchievements(locale string, rules []achievements.Rule) string {
+	if len(rules) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(rules))
+	for i := range rules {
+		lines[i] = AchievementName(locale, &rules[i])
+	}
+	return i18n.T(locale, "achievements.new", strings.Join(lines, "\n"))
+}
+
+// Profile shows the user's totals, streaks, earned badges and progress
+// towards the next achievements.
+func Profile(
+	locale string,
+	user *models.User,
+	facts *achievements.Facts,
+	earned []models.Achievement,
+	now time.Time,
+) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "profile.title", user.FirstName))
+	builder.WriteString(i18n.T(locale, "profile.totals", facts.Sessions, facts.Volume/1000))
+	builder.WriteString(i18n.T(
+		locale, "profile.week_streak",
+		achievements.WeekStreak(facts.Days, achievements.WeeklySessions, now), achievements.WeeklySessions,
+	))
+	if len(facts.Schedule) > 0 {
+		builder.WriteString(i18n.T(
+			locale, "profile.planned_streak", achievements.PlannedStreak(facts.Days, facts.Schedule, now),
+		))
+	}
+
+	builder.WriteString(i18n.T(locale, "profile.badges", len(earned), len(achievements.Rules)))
+	if len(earned) == 0 {
+		builder.WriteString(i18n.T(locale, "profile.badges_empty"))
+	}
+	slugs := make([]string, 0, len(earned))
+	for _, achievement := range earned {
+		slugs = append(slugs, achievement.Slug)
+		rule, ok := achievements.Find(achievement.Slug)
+		if !ok {
+			continue
+		}
+		builder.WriteString(i18n.T(
+			locale, "profile.badge_line", AchievementName(locale, rule), Date(locale, achievement.EarnedAt),
+		))
+	}
+
+	next := NextAchievements(facts, slugs, now)
+	if len(next) > 0 {
+		builder.WriteString(i18n.T(locale, "profile.next"))
+	}
+	for i := range next {
+		builder.WriteString(i18n.T(
+			locale, "profile.next_line",
+			AchievementName(locale, &next[i]), Weight(math.Round(next[i].Value(facts, now)*10)/10), Weight(next[i].Goal),
+		))
+	}
+	return builder.String()
+}
+
+// NextAchievements picks the next step of each rule ladder, such as the
+// next session count, closest to being earned first.
+func NextAchievements(facts *achievements.Facts, earned []string, now time.Time) []achievements.Rule {
+	var next []achievements.Rule
+	seen := make(map[string]bool)
+	for _, rule := range achievements.Rules {
+		ladder := rule.Metric + ":" + rule.Exercise
+		if seen[ladder] || slices.Contains(earned, rule.Slug) {
+			continue
+		}
+		seen[ladder] = true
+		next = append(next, rule)
+	}
+
+	progress := func(rule *achievements.Rule) float64 {
+		return rule.Value(facts, now) / rule.Goal
+	}
+	slices.SortStableFunc(next, func(a, b achievements.Rule) int {
+		return cmp.Compare(progress(&b), progress(&a))
+	})
+	return next[:min(nextGoals, len(next))]
+}
@@ -0,0 +1,101 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+)
+
+// Duration formats minutes and seconds, with hours when there are any:
+// 25:30, 1:02:03.
+func Duration(duration time.Duration) string {
+	seconds := int(duration.Round(time.Second).Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// Distance formats meters as kilometres to two decimals, or meters under a
+// kilometre.
+func Distance(locale string, meters float64) string {
+	if meters < 1000 {
+		return i18n.T(locale, "cardio.meters", int(meters+0.5))
+	}
+	km := strconv.FormatFloat(float64(int(meters/10+0.5))/100, 'f', -1, 64)
+	return i18n.T(locale, "cardio.km", km)
+}
+
+// Pace is the entry's pace in the unit usual for the activity, or its speed.
+func Pace(locale string, entry *models.CardioEntry) string {
+	if entry.DistanceMeters <= 0 {
+		return ""
+	}
+	meters := training.PaceMeters(&entry.Exercise)
+	if meters == 0 {
+		speed := strconv.FormatFloat(float64(int(training.Speed(entry)*10+0.5))/10, 'f', -1, 64)
+		return i18n.T(locale, "cardio.speed", speed)
+	}
+	per := Distance(locale, meters)
+	if meters == 1000 {
+		per = i18n.T(locale, "cardio.per_km")
+	}
+	return i18n.T(locale, "cardio.pace", Duration(training.Pace(entry, meters)), per)
+}
+
+// CardioEntry is a one-line summary of the activity.
+func CardioEntry(locale string, entry *models.CardioEntry) string {
+	parts := []string{ExerciseName(locale, &entry.Exercise), Duration(entry.Duration())}
+	if entry.DistanceMeters > 0 {
+		parts = append(parts, Distance(locale, entry.DistanceMeters), Pace(locale, entry))
+	}
+	if entry.HeartRate > 0 {
+		parts = append(parts, i18n.T(locale, "cardio.heart_rate", entry.HeartRate))
+	}
+	if entry.Effort > 0 {
+		parts = append(parts, i18n.T(locale, "cardio.effort", entry.Effort))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// CardioLog lists recent activities.
+func CardioLog(locale string, entries []models.CardioEntry) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "cardio.title"))
+	if len(entries) == 0 {
+		builder.WriteString(i18n.T(locale, "cardio.empty"))
+		return builder.String()
+	}
+	for i := range entries {
+		builder.WriteString(i18n.T(
+			locale, "cardio.line",
+			Date(locale, entries[i].StartedAt), CardioEntry(locale, &entries[i]),
+		))
+	}
+	return builder.String()
+}
+
+// ImportedActivity details an activity read from a file.
+func ImportedActivity(locale string, entry *models.CardioEntry) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "import.summary_title", ExerciseName(locale, &entry.Exercise)))
+	builder.WriteString(i18n.T(locale, "import.summary_date", Date(locale, entry.StartedAt)))
+
+	line := []string{Duration(entry.Duration())}
+	if entry.DistanceMeters > 0 {
+		line = append(line, Distance(locale, entry.DistanceMeters), Pace(locale, entry))
+	}
+	builder.WriteString(i18n.T(locale, "import.summary_time", strings.Join(line, " · ")))
+
+	if entry.ElevationGain > 0 {
+		builder.WriteString(i18n.T(locale, "import.summary_elevation", int(entry.ElevationGain+0.5)))
+	}
+	if entry.HeartRate > 0 {
+		builder.WriteString(i18n.T(locale, "import.summary_heart_rate", entry.HeartRate, entry.MaxHeartRate))
+	}
+	return builder.String()
+}
@@ -0,0 +1,135 @@
+package views
+
+import (
+	"strings"
+	"time"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/coaching"
+)
+
+// ClientName is how a coach sees a client: the first name with the
+// username, if any.
+func ClientName(user *models.User) string {
+	name := user.FirstName
+	if user.Username != "" {
+		name += " (@" + user.Username + ")"
+	}
+	if name == "" {
+		return "—"
+	}
+	return name
+}
+
+// CoachClients lists the coach's clients with the invite link.
+func CoachClients(locale string, links []models.CoachClient, invite string) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "coach.title", len(links)))
+	if len(links) == 0 {
+		builder.WriteString(i18n.T(locale, "coach.empty"))
+	}
+	if invite != "" {
+		builder.WriteString(i18n.T(locale, "coach.invite", invite))
+	} else {
+		builder.WriteString(i18n.T(locale, "coach.invite_none"))
+	}
+	return builder.String()
+}
+
+// ClientCard shows a client's program, adherence and last sessions.
+func ClientCard(
+	locale string,
+	client *models.User,
+	enrollment *models.ProgramEnrollment,
+	adherence coaching.Adherence,
+	sessions []models.WorkoutSession,
+) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "coach.client", ClientName(client)))
+	if enrollment != nil {
+		builder.WriteString(i18n.T(
+			locale, "coach.client_program",
+			ProgramName(locale, enrollment.Program), enrollment.Cycle, enrollment.Week+1, enrollment.Day+1,
+		))
+	} else {
+		builder.WriteString(i18n.T(locale, "coach.client_no_program"))
+	}
+	builder.WriteString(i18n.T(locale, "coach.client_week", adherence.Week, adherence.PerWeek))
+	builder.WriteString(i18n.T(
+		locale, "coach.client_adherence",
+		coaching.Weeks, adherence.Done, adherence.Planned, adherence.Percent(),
+	))
+	if len(sessions) == 0 {
+		builder.WriteString(i18n.T(locale, "coach.client_no_sessions"))
+	} else {
+		builder.WriteString(i18n.T(locale, "coach.client_sessions"))
+	}
+	return builder.String()
+}
+
+// ClientSession shows a client's finished session set by set, with the
+// coach's comments.
+func ClientSession(
+	locale string,
+	client *models.User,
+	session *models.WorkoutSession,
+	comments []models.SessionComment,
+) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(
+		locale, "coach.session",
+		ClientName(client), session.Name, Date(locale, session.StartedAt),
+	))
+	for _, exercise := range session.Exercises {
+		sets := make([]string, 0, len(exercise.Sets))
+		for i := range exercise.Sets {
+			set := &exercise.Sets[i]
+			switch {
+			case set.Warmup || set.ParentID != nil:
+				continue
+			case set.Skipped:
+				sets = append(sets, i18n.T(locale, "coach.set_skipped"))
+			case set.Seconds > 0:
+				sets = append(sets, Duration(time.Duration(set.Seconds)*time.Second))
+			default:
+				sets = append(sets, loggedSet(locale, set))
+			}
+		}
+		builder.WriteString(i18n.T(
+			locale, "coach.session_exercise",
+			ExerciseName(locale, &exercise.Exercise), strings.Join(sets, ", "),
+		))
+	}
+	builder.WriteString(i18n.T(locale, "coach.session_volume", Weight(session.Volume())))
+
+	for _, comment := range comments {
+		builder.WriteString(i18n.T(locale, "coach.comment_line", Date(locale, comment.CreatedAt), comment.Text))
+	}
+	return builder.String()
+}
+
+// SessionFinishedNotice tells a coach that a client finished a session.
+func SessionFinishedNotice(locale string, client *models.User, session *models.WorkoutSession) string {
+	done, skipped := 0, 0
+	for _, exercise := range session.Exercises {
+		for _, set := range exercise.WorkSets() {
+			if set.ParentID != nil {
+				continue
+			}
+			if set.Skipped {
+				skipped++
+			} else {
+				done++
+			}
+		}
+	}
+
+	text := i18n.T(
+		locale, "coach.notify_finished",
+		ClientName(client), session.Name, done, Weight(session.Volume()),
+	)
+	if skipped > 0 {
+		text += i18n.T(locale, "coach.notify_skipped_sets", skipped)
+	}
+	return text
+}
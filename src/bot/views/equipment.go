@@ -0,0 +1,35 @@
+package views
+
+import (
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+)
+
+// Loadout describes the user's loadout and whether warm-ups are on.
+func Loadout(locale string, user *models.User) string {
+	loadout := training.LoadoutFor(user)
+	unit := i18n.T(locale, "equipment.unit_"+loadout.Unit)
+
+	plates := training.FormatPlates(loadout.Plates)
+	if user.Plates == "" {
+		plates = i18n.T(locale, "equipment.plates_standard", plates)
+	}
+
+	return i18n.T(
+		locale, "equipment.title",
+		unit,
+		plates,
+		Weight(loadout.Bar), unit,
+		Weight(loadout.DumbbellStep), unit,
+		Warmups(locale, user.Warmups),
+	)
+}
+
+// Warmups names the warm-up setting.
+func Warmups(locale string, enabled bool) string {
+	if enabled {
+		return i18n.T(locale, "equipment.warmups_on")
+	}
+	return i18n.T(locale, "equipment.warmups_off")
+}
@@ -0,0 +1,42 @@
+package views
+
+import (
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+)
+
+var categoryKeys = map[string]string{
+	models.CategoryCompound:   "button.exercise_compound",
+	models.CategoryIsolation:  "button.exercise_isolation",
+	models.CategoryStrength:   "button.exercise_strength",
+	models.CategoryCardio:     "button.exercise_cardio",
+	models.CategoryBodyweight: "button.exercise_bodyweight",
+	models.CategoryHIIT:       "button.exercise_hiit",
+	models.CategoryEndurance:  "button.exercise_endurance",
+}
+
+func Category(locale string, category string) string {
+	if key, ok := categoryKeys[category]; ok {
+		return i18n.T(locale, key)
+	}
+	return category
+}
+
+// Exercise cards reuse the muscle group button labels.
+func MuscleGroup(locale string, group string) string {
+	return i18n.T(locale, "button.muscle_"+group)
+}
+
+func Equipment(locale string, equipment string) string {
+	return i18n.T(locale, "equipment."+equipment)
+}
+
+func ExerciseCard(locale string, exercise *models.Exercise) string {
+	return i18n.T(
+		locale, "exercises.card",
+		ExerciseName(locale, exercise),
+		Category(locale, exercise.Category),
+		MuscleGroup(locale, exercise.MuscleGroup),
+		Equipment(locale, exercise.Equipment),
+	)
+}
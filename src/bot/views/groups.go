@@ -0,0 +1,117 @@
+package views
+
+import (
+	"strings"
+	"time"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/achievements"
+	"workouts_bot/src/services/groups"
+	"workouts_bot/src/services/training"
+)
+
+// MemberName is how a user appears in a group: the first name, or a
+// numbered athlete for users who stay anonymous.
+func MemberName(locale string, user *models.User, place int) string {
+	if user.GroupPrivacy == models.GroupPrivacyAnonymous || user.FirstName == "" {
+		return i18n.T(locale, "group.anonymous", place)
+	}
+	return user.FirstName
+}
+
+func ChallengeName(locale string, challenge *groups.Challenge) string {
+	return challenge.Badge + " " + i18n.T(locale, "challenge."+challenge.Kind)
+}
+
+// ChallengeAmount formats reps, or seconds for a timed challenge.
+func ChallengeAmount(locale string, challenge *groups.Challenge, amount int) string {
+	if challenge.Timed {
+		return i18n.T(locale, "challenge.seconds", amount)
+	}
+	return i18n.T(locale, "challenge.reps", amount)
+}
+
+// Leaderboard shows the week's standings of a group. Volume is left out
+// for members who hide it.
+func Leaderboard(locale string, standings []groups.Standing, now time.Time) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "group.leaderboard_title", Date(locale, training.WeekStart(now))))
+	if len(standings) == 0 {
+		builder.WriteString(i18n.T(locale, "group.leaderboard_empty"))
+		return builder.String()
+	}
+
+	for i := range standings {
+		standing := &standings[i]
+		builder.WriteString(i18n.T(
+			locale, "group.leaderboard_line",
+			i+1, MemberName(locale, standing.User, i+1), standing.Sessions,
+		))
+		if standing.User.GroupPrivacy != models.GroupPrivacyHideVolume && standing.Volume > 0 {
+			builder.WriteString(i18n.T(locale, "group.leaderboard_volume", Weight(float64(int(standing.Volume)))))
+		}
+		if standing.Streak > 0 {
+			builder.WriteString(i18n.T(locale, "group.leaderboard_streak", standing.Streak))
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString(i18n.T(locale, "group.leaderboard_footer", achievements.WeeklySessions))
+	return builder.String()
+}
+
+// ActiveChallenges lists the group's running challenges with their dates.
+func ActiveChallenges(locale string, challenges []models.Challenge) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "group.challenges_title"))
+	if len(challenges) == 0 {
+		builder.WriteString(i18n.T(locale, "group.challenges_empty"))
+	}
+	for i := range challenges {
+		challenge, ok := groups.Find(challenges[i].Kind)
+		if !ok {
+			continue
+		}
+		builder.WriteString(i18n.T(
+			locale, "group.challenge_line",
+			ChallengeName(locale, challenge), ChallengeAmount(locale, challenge, challenge.Goal),
+			Date(locale, challenges[i].StartsOn), Date(locale, challenges[i].EndsOn),
+		))
+	}
+	builder.WriteString(i18n.T(locale, "group.challenges_hint"))
+	return builder.String()
+}
+
+// ChallengeDay is the daily progress post of a challenge, or its results
+// on the last day.
+func ChallengeDay(
+	locale string,
+	challenge *groups.Challenge,
+	running *models.Challenge,
+	day time.Time,
+	scores []groups.Score,
+) string {
+	var builder strings.Builder
+	number := groups.DayNumber(running, day)
+	if number >= challenge.Days {
+		builder.WriteString(i18n.T(locale, "challenge.results", ChallengeName(locale, challenge)))
+	} else {
+		builder.WriteString(i18n.T(
+			locale, "challenge.day", ChallengeName(locale, challenge), number, challenge.Days,
+		))
+	}
+	builder.WriteString(i18n.T(locale, "challenge.goal", ChallengeAmount(locale, challenge, challenge.Goal)))
+
+	for i := range scores {
+		score := &scores[i]
+		mark := "▫️"
+		if score.Today >= challenge.Goal {
+			mark = "✅"
+		}
+		builder.WriteString(i18n.T(
+			locale, "challenge.line",
+			mark, MemberName(locale, score.User, i+1), ChallengeAmount(locale, challenge, score.Today),
+			score.DaysMet, number, ChallengeAmount(locale, challenge, score.Total),
+		))
+	}
+	return builder.String()
+}
@@ -0,0 +1,90 @@
+package views
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/body"
+)
+
+func MeasurementName(locale string, kind string) string {
+	return i18n.T(locale, "measurements.kind_"+kind)
+}
+
+// MeasurementValue formats the value with its unit, to one decimal place.
+func MeasurementValue(locale string, kind string, value float64) string {
+	return i18n.T(locale, "measurements.unit_"+kind, Weight(math.Round(value*10)/10))
+}
+
+// MeasurementTrend describes the smoothed average, the weekly change and
+// how it relates to the user's goal.
+func MeasurementTrend(locale string, kind string, trend body.Trend) string {
+	text := i18n.T(
+		locale, "measurements.trend_"+trend.Direction,
+		MeasurementValue(locale, kind, trend.Average),
+		i18n.T(locale, "measurements.unit_"+kind, fmt.Sprintf("%+.1f", trend.WeeklyRate)),
+	)
+	if trend.Verdict != "" {
+		text += i18n.T(locale, "measurements.verdict_"+trend.Verdict)
+	}
+	return text
+}
+
+// MeasurementsSummary lists the latest value of every kind and the trend
+// of those that have one.
+func MeasurementsSummary(
+	locale string,
+	latest map[string]models.BodyMeasurement,
+	trends map[string]body.Trend,
+) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "measurements.title"))
+	if len(latest) == 0 {
+		builder.WriteString(i18n.T(locale, "measurements.empty"))
+	}
+	for _, kind := range body.Kinds {
+		measurement, ok := latest[kind]
+		if !ok {
+			continue
+		}
+		builder.WriteString(i18n.T(
+			locale, "measurements.line",
+			MeasurementName(locale, kind),
+			MeasurementValue(locale, kind, measurement.Value),
+			Date(locale, measurement.MeasuredOn),
+		))
+		if trend, ok := trends[kind]; ok {
+			builder.WriteString(MeasurementTrend(locale, kind, trend))
+		}
+	}
+	builder.WriteString(i18n.T(locale, "measurements.hint"))
+	return builder.String()
+}
+
+// MeasurementHistory lists values newest first with the moving average of
+// each day, keyed by the day in DateLayout.
+func MeasurementHistory(
+	locale string,
+	kind string,
+	measurements []models.BodyMeasurement,
+	averages map[string]float64,
+) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "measurements.history_title", MeasurementName(locale, kind)))
+	if len(measurements) == 0 {
+		builder.WriteString(i18n.T(locale, "measurements.history_empty"))
+		return builder.String()
+	}
+
+	for _, measurement := range measurements {
+		builder.WriteString(i18n.T(
+			locale, "measurements.history_line",
+			Date(locale, measurement.MeasuredOn),
+			MeasurementValue(locale, kind, measurement.Value),
+			MeasurementValue(locale, kind, averages[measurement.MeasuredOn.Format(DateLayout)]),
+		))
+	}
+	return builder.String()
+}
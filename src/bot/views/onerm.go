@@ -0,0 +1,88 @@
+package views
+
+import (
+	"strings"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+)
+
+// tablePercents are the shares of the 1RM shown in the percentage table.
+var tablePercents = []int{100, 95, 90, 85, 80, 75, 70, 65, 60, 55, 50}
+
+// OneRepMax shows the 1RM estimated from weight × reps by every formula
+// and the weights at common percentages of their average. rpe is 0 when
+// the set was not rated.
+func OneRepMax(locale string, weight float64, reps int, rpe float64) string {
+	var builder strings.Builder
+	if rpe > 0 {
+		builder.WriteString(i18n.T(locale, "onerm.title_rpe", Weight(weight), reps, Weight(rpe)))
+	} else {
+		builder.WriteString(i18n.T(locale, "onerm.title", Weight(weight), reps))
+	}
+
+	estimates := training.EstimateAll(weight, reps, rpe)
+	for _, formula := range training.Formulas {
+		builder.WriteString(i18n.T(
+			locale, "onerm.formula_line",
+			i18n.T(locale, "onerm.formula_"+formula),
+			Weight(training.RoundWeight(estimates[formula], 0.5)),
+		))
+	}
+
+	oneRepMax := training.Average(estimates)
+	builder.WriteString(i18n.T(
+		locale, "onerm.average",
+		Weight(training.RoundWeight(oneRepMax, 0.5)), Weight(training.TrainingMax(oneRepMax)),
+	))
+
+	builder.WriteString(i18n.T(locale, "onerm.table_title"))
+	for _, percent := range tablePercents {
+		share := float64(percent) / 100
+		builder.WriteString(i18n.T(
+			locale, "onerm.table_line",
+			percent, Weight(training.RoundWeight(oneRepMax*share, 2.5)), training.RepsAt(share),
+		))
+	}
+	return builder.String()
+}
+
+// TrainingMaxes lists the user's training maxes with the known 1RMs. The
+// maxes must be loaded with their exercises.
+func TrainingMaxes(locale string, maxes []models.TrainingMax) string {
+	if len(maxes) == 0 {
+		return i18n.T(locale, "onerm.maxes_empty")
+	}
+
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "onerm.maxes_title"))
+	for i := range maxes {
+		trainingMax := &maxes[i]
+		oneRepMax := i18n.T(locale, "onerm.unknown")
+		if trainingMax.OneRepMax > 0 {
+			oneRepMax = i18n.T(locale, "onerm.weight", Weight(trainingMax.OneRepMax))
+		}
+		builder.WriteString(i18n.T(
+			locale, "onerm.maxes_line",
+			ExerciseName(locale, &trainingMax.Exercise), Weight(trainingMax.Weight), oneRepMax,
+			i18n.T(locale, "onerm.source_"+trainingMax.Source),
+		))
+	}
+	return builder.String()
+}
+
+// NewOneRepMaxes lists one-rep maxes raised by a finished session.
+func NewOneRepMaxes(locale string, maxes []models.TrainingMax) string {
+	if len(maxes) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(maxes))
+	for i := range maxes {
+		lines[i] = i18n.T(
+			locale, "onerm.record_line",
+			ExerciseName(locale, &maxes[i].Exercise), Weight(maxes[i].OneRepMax),
+		)
+	}
+	return i18n.T(locale, "onerm.records", strings.Join(lines, "\n"))
+}
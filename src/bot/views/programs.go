@@ -0,0 +1,115 @@
+package views
+
+import (
+	"strings"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/programs"
+)
+
+func ProgramName(locale string, slug string) string {
+	return i18n.T(locale, "programs.name_"+slug)
+}
+
+func ProgramDay(locale string, day string) string {
+	return i18n.T(locale, "programs.day_"+day)
+}
+
+// Program describes a program from the library. exercises are keyed by
+// slug.
+func Program(locale string, program *programs.Program, exercises map[string]models.Exercise) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "programs.title", ProgramName(locale, program.Slug)))
+	builder.WriteString(i18n.T(locale, "programs.about_"+program.Slug))
+	builder.WriteString(i18n.T(locale, "programs.schedule", len(program.Weeks), len(program.Days)))
+
+	names := make([]string, 0, len(program.Maxes()))
+	for _, slug := range program.Maxes() {
+		if exercise, ok := exercises[slug]; ok {
+			names = append(names, ExerciseName(locale, &exercise))
+		}
+	}
+	builder.WriteString(i18n.T(locale, "programs.maxes_needed", strings.Join(names, ", ")))
+	return builder.String()
+}
+
+// Enrollment shows where the user is in the program and the sets of the
+// next day.
+func Enrollment(
+	locale string,
+	program *programs.Program,
+	enrollment *models.ProgramEnrollment,
+	prescriptions []programs.Prescription,
+	exercises map[string]models.Exercise,
+) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "programs.title", ProgramName(locale, program.Slug)))
+	builder.WriteString(i18n.T(
+		locale, "programs.position",
+		enrollment.Cycle, enrollment.Week+1, len(program.Weeks),
+	))
+	if program.Weeks[enrollment.Week%len(program.Weeks)].Deload {
+		builder.WriteString(i18n.T(locale, "programs.deload"))
+	}
+
+	day := program.Days[enrollment.Day%len(program.Days)]
+	builder.WriteString(i18n.T(locale, "programs.next_day", ProgramDay(locale, day.Name)))
+	for _, prescription := range prescriptions {
+		exercise, ok := exercises[prescription.Exercise]
+		if !ok {
+			continue
+		}
+		builder.WriteString(i18n.T(
+			locale, "programs.lift_line",
+			ExerciseName(locale, &exercise), PlannedSets(locale, prescription.Sets),
+		))
+	}
+	return builder.String()
+}
+
+// PlannedSets lists the sets, merging runs of identical ones: 5 × 10 × 50 kg.
+func PlannedSets(locale string, sets []models.PlannedSet) string {
+	var parts []string
+	for i := 0; i < len(sets); {
+		count := 1
+		for i+count < len(sets) && sets[i+count] == sets[i] {
+			count++
+		}
+
+		set := sets[i]
+		reps := i18n.T(locale, "programs.reps", set.Reps)
+		if set.AMRAP {
+			reps = i18n.T(locale, "programs.reps_amrap", set.Reps)
+		}
+		if set.Weight > 0 {
+			parts = append(parts, i18n.T(locale, "programs.sets_weight", count, reps, Weight(set.Weight)))
+		} else {
+			parts = append(parts, i18n.T(locale, "programs.sets", count, reps))
+		}
+		i += count
+	}
+	return strings.Join(parts, ", ")
+}
+
+// TrainingMax labels a training max; 0 means it is not set yet.
+func TrainingMax(locale string, exercise *models.Exercise, weight float64) string {
+	if weight <= 0 {
+		return i18n.T(locale, "programs.max_missing", ExerciseName(locale, exercise))
+	}
+	return i18n.T(locale, "programs.max", ExerciseName(locale, exercise), Weight(weight))
+}
+
+// ProgramAdvanced tells what comes next after a program day is finished.
+func ProgramAdvanced(
+	locale string,
+	program *programs.Program,
+	enrollment *models.ProgramEnrollment,
+	cycled bool,
+) string {
+	day := program.Days[enrollment.Day%len(program.Days)]
+	text := i18n.T(locale, "programs.advanced", ProgramDay(locale, day.Name))
+	if cycled {
+		text = i18n.T(locale, "programs.cycle_done", enrollment.Cycle) + text
+	}
+	return text
+}
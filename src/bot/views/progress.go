@@ -0,0 +1,18 @@
+package views
+
+import (
+	"time"
+	"workouts_bot/src/i18n"
+)
+
+// DateLayout is how days travel in callback data.
+const DateLayout = "2006-01-02"
+
+// Pose names reuse the pose button labels.
+func Pose(locale string, pose string) string {
+	return i18n.T(locale, "button.pose_"+pose)
+}
+
+func Date(locale string, day time.Time) string {
+	return day.Format(i18n.T(locale, "format.date"))
+}
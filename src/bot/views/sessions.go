@@ -0,0 +1,230 @@
+package views
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+)
+
+// SetTarget formats the reps and weight expected in the next set, or the
+// timing of the next interval.
+func SetTarget(locale string, exercise *models.SessionExercise) string {
+	if exercise.Intervals() {
+		return i18n.T(locale, "sessions.set_target_interval", exercise.WorkSeconds, exercise.RestSeconds)
+	}
+	target := exercise.Target(exercise.Logged() + 1)
+
+	text := i18n.T(locale, "sessions.set_target_reps", target.Reps)
+	switch {
+	case target.Weight > 0 && target.Unit == models.UnitLb:
+		// Rounded to cents of a pound so that the loaded weight shows as is.
+		pounds := math.Round(training.FromKg(target.Weight, models.UnitLb)*100) / 100
+		text = i18n.T(locale, "sessions.target_weight_lb", target.Reps, Weight(pounds))
+	case target.Weight > 0:
+		text = i18n.T(locale, "sessions.set_target_weight", target.Reps, Weight(target.Weight))
+	}
+	if target.AMRAP {
+		text += i18n.T(locale, "sessions.set_amrap")
+	}
+	return text
+}
+
+// WorkoutSession shows progress through the session and the next set.
+// recommendation, if any, is for the current exercise.
+func WorkoutSession(
+	locale string,
+	session *models.WorkoutSession,
+	recommendation *training.Recommendation,
+) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "sessions.title", session.Name))
+
+	if len(session.Exercises) == 0 {
+		builder.WriteString(i18n.T(locale, "sessions.empty"))
+		return builder.String()
+	}
+
+	current := session.Current()
+	for i := range session.Exercises {
+		exercise := &session.Exercises[i]
+		key := "sessions.line_pending"
+		switch {
+		case exercise.Done():
+			key = "sessions.line_done"
+		case exercise == current:
+			key = "sessions.line_current"
+		}
+		builder.WriteString(i18n.T(
+			locale, key,
+			i+1, ExerciseName(locale, &exercise.Exercise), max(exercise.Round(), 0), exercise.TargetSets,
+		))
+		if exercise.Linked && i < len(session.Exercises)-1 {
+			builder.WriteString(i18n.T(locale, "sessions.line_linked"))
+		}
+	}
+
+	builder.WriteString(extendedSet(locale, session))
+
+	if current == nil {
+		builder.WriteString(i18n.T(locale, "sessions.all_done"))
+		return builder.String()
+	}
+
+	number := current.Logged() + 1
+	key := "sessions.next_set"
+	if current.Intervals() {
+		key = "sessions.next_interval"
+	}
+	switch {
+	case current.Exercise.Cardio():
+		builder.WriteString(i18n.T(locale, "sessions.next_activity", ExerciseName(locale, &current.Exercise)))
+	case current.Target(number).Warmup:
+		builder.WriteString(i18n.T(
+			locale, "sessions.next_warmup",
+			ExerciseName(locale, &current.Exercise),
+			number, len(current.Warmup),
+			SetTarget(locale, current),
+		))
+	default:
+		builder.WriteString(i18n.T(
+			locale, key,
+			ExerciseName(locale, &current.Exercise),
+			number-len(current.Warmup), current.TargetSets,
+			SetTarget(locale, current),
+		))
+	}
+	builder.WriteString(rest(locale, session, current))
+	if recommendation != nil {
+		builder.WriteString(Recommendation(locale, recommendation))
+	}
+	return builder.String()
+}
+
+// extendedSet shows the last working set with its drop and rest-pause
+// sets, if it has any.
+func extendedSet(locale string, session *models.WorkoutSession) string {
+	exercise := session.LastExercise()
+	if exercise == nil {
+		return ""
+	}
+	parent := exercise.LastWorkSet()
+	if parent == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+	for _, set := range exercise.Sets {
+		if set.ParentID != nil && *set.ParentID == parent.ID {
+			builder.WriteString(i18n.T(locale, "sessions.sub_"+set.Kind, loggedSet(locale, &set)))
+		}
+	}
+	if builder.Len() == 0 {
+		return ""
+	}
+	return i18n.T(
+		locale, "sessions.extended_set",
+		ExerciseName(locale, &exercise.Exercise), loggedSet(locale, parent)+builder.String(),
+	)
+}
+
+func loggedSet(locale string, set *models.SessionSet) string {
+	if set.Weight > 0 {
+		return i18n.T(locale, "sessions.set_target_weight", set.Reps, Weight(set.Weight))
+	}
+	return i18n.T(locale, "sessions.set_target_reps", set.Reps)
+}
+
+// rest tells how long to rest after the current set. Within a superset or
+// circuit the rest comes after the last exercise of the round; intervals
+// carry their rest in the target.
+func rest(locale string, session *models.WorkoutSession, current *models.SessionExercise) string {
+	if current.Intervals() {
+		return ""
+	}
+	for i := range session.Exercises {
+		if &session.Exercises[i] != current {
+			continue
+		}
+		first, last := session.Group(i)
+		if first == last {
+			break
+		}
+		key := "sessions.superset"
+		if last-first > 1 {
+			key = "sessions.circuit"
+		}
+		text := i18n.T(locale, key, max(current.Round(), 0)+1, current.TargetSets)
+		if next := nextInRound(session, i, last); next != nil {
+			return text + i18n.T(locale, "sessions.rest_next", ExerciseName(locale, &next.Exercise))
+		}
+		break
+	}
+	if current.RestSeconds > 0 {
+		return i18n.T(locale, "sessions.rest", current.RestSeconds)
+	}
+	return ""
+}
+
+// nextInRound returns the exercise of the group that follows index in the
+// current round, or nil when index closes the round.
+func nextInRound(session *models.WorkoutSession, index int, last int) *models.SessionExercise {
+	round := session.Exercises[index].Round()
+	for j := index + 1; j <= last; j++ {
+		exercise := &session.Exercises[j]
+		if !exercise.Done() && exercise.Round() <= round {
+			return exercise
+		}
+	}
+	return nil
+}
+
+// Recommendation explains the suggested targets and what they are based on.
+func Recommendation(locale string, recommendation *training.Recommendation) string {
+	text := i18n.T(
+		locale, "progression.recommendation",
+		i18n.T(locale, "progression.scheme_"+recommendation.Scheme),
+		i18n.T(locale, "progression.action_"+recommendation.Action),
+		targets(locale, recommendation.Sets, recommendation.Reps, recommendation.Weight, 0),
+	)
+	if len(recommendation.LastReps) > 0 {
+		reps := make([]string, len(recommendation.LastReps))
+		for i, value := range recommendation.LastReps {
+			reps[i] = strconv.Itoa(value)
+		}
+		last := strings.Join(reps, ", ")
+		if recommendation.LastWeight > 0 {
+			last = i18n.T(locale, "progression.last_weight", last, Weight(recommendation.LastWeight))
+		}
+		text += i18n.T(locale, "progression.last", last)
+	}
+	return text
+}
+
+// SessionSummary is shown once the session is finished.
+func SessionSummary(locale string, session *models.WorkoutSession) string {
+	finishedAt := time.Now()
+	if session.FinishedAt != nil {
+		finishedAt = *session.FinishedAt
+	}
+
+	sets := 0
+	for _, exercise := range session.Exercises {
+		for _, set := range exercise.WorkSets() {
+			if !set.Skipped && set.ParentID == nil {
+				sets++
+			}
+		}
+	}
+
+	return i18n.T(
+		locale, "sessions.finished",
+		session.Name,
+		int(finishedAt.Sub(session.StartedAt).Minutes()),
+		sets,
+		Weight(session.Volume()),
+	)
+}
@@ -0,0 +1,30 @@
+package views
+
+import (
+	"strings"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/services/training"
+)
+
+// WeeklyStats shows the training of the last weeks, newest first.
+func WeeklyStats(locale string, weeks []training.Week) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "stats.title"))
+	for _, week := range weeks {
+		builder.WriteString(i18n.T(locale, "stats.week", Date(locale, week.Start)))
+		if week.Sessions == 0 && week.Cardio == 0 {
+			builder.WriteString(i18n.T(locale, "stats.week_empty"))
+			continue
+		}
+		if week.Sessions > 0 {
+			builder.WriteString(i18n.T(locale, "stats.sessions", week.Sessions, Weight(float64(int(week.Volume)))))
+		}
+		if week.Cardio > 0 {
+			builder.WriteString(i18n.T(
+				locale, "stats.cardio",
+				week.Cardio, Duration(week.Duration), Distance(locale, week.Distance),
+			))
+		}
+	}
+	return builder.String()
+}
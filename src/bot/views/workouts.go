@@ -0,0 +1,83 @@
+package views
+
+import (
+	"strconv"
+	"strings"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+)
+
+func ExerciseName(locale string, exercise *models.Exercise) string {
+	if locale == i18n.EN && exercise.NameEN != "" {
+		return exercise.NameEN
+	}
+	return exercise.NameRU
+}
+
+// Weight formats kilograms without trailing zeros: 60, 62.5.
+func Weight(weight float64) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64)
+}
+
+func Targets(locale string, item *models.TemplateExercise) string {
+	if item.WorkSeconds > 0 {
+		return intervals(locale, item.TargetSets, item.WorkSeconds, item.RestSeconds)
+	}
+	return targets(locale, item.TargetSets, item.TargetReps, item.TargetWeight, item.RestSeconds)
+}
+
+func SessionTargets(locale string, exercise *models.SessionExercise) string {
+	if exercise.Intervals() {
+		return intervals(locale, exercise.TargetSets, exercise.WorkSeconds, exercise.RestSeconds)
+	}
+	return targets(locale, exercise.TargetSets, exercise.TargetReps, exercise.TargetWeight, exercise.RestSeconds)
+}
+
+// intervals formats rounds of timed work and rest.
+func intervals(locale string, rounds int, work int, rest int) string {
+	return i18n.T(locale, "workouts.intervals", rounds, work, rest)
+}
+
+func targets(locale string, sets int, reps int, weight float64, rest int) string {
+	text := i18n.T(locale, "workouts.targets", sets, reps)
+	if weight > 0 {
+		text += i18n.T(locale, "workouts.targets_weight", Weight(weight))
+	}
+	if rest > 0 {
+		text += i18n.T(locale, "workouts.targets_rest", rest)
+	}
+	return text
+}
+
+func WorkoutTemplate(locale string, template *models.WorkoutTemplate) string {
+	var builder strings.Builder
+	builder.WriteString(i18n.T(locale, "workouts.template_title", template.Name))
+
+	if len(template.Exercises) == 0 {
+		builder.WriteString(i18n.T(locale, "workouts.template_empty"))
+		return builder.String()
+	}
+
+	for i := range template.Exercises {
+		item := &template.Exercises[i]
+		builder.WriteString(i18n.T(
+			locale, "workouts.item_line",
+			i+1, ExerciseName(locale, &item.Exercise), Targets(locale, item),
+		))
+		if item.Linked && i < len(template.Exercises)-1 {
+			builder.WriteString(i18n.T(locale, "workouts.item_linked"))
+		}
+	}
+	return builder.String()
+}
+
+func TemplateItem(locale string, template *models.WorkoutTemplate, item *models.TemplateExercise) string {
+	text := i18n.T(
+		locale, "workouts.item_title",
+		template.Name, item.Position, ExerciseName(locale, &item.Exercise), Targets(locale, item),
+	)
+	if item.Linked {
+		text += i18n.T(locale, "workouts.item_linked_next")
+	}
+	return text
+}
@@ -0,0 +1,121 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"workouts_bot/src/config"
+
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+)
+
+const testBucket = "workouts"
+
+// newTestS3 starts an in-memory S3 server with the bucket and returns the
+// config pointing at it.
+func newTestS3(t *testing.T) (*config.S3Config, *s3mem.Backend) {
+	t.Helper()
+	backend := s3mem.New()
+	if err := backend.CreateBucket(testBucket); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(gofakes3.New(backend).Server())
+	t.Cleanup(server.Close)
+
+	return &config.S3Config{
+		Endpoint:        server.URL,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		BucketName:      testBucket,
+		Region:          "us-east-1",
+	}, backend
+}
+
+func get(t *testing.T, url string) (int, string, string) {
+	t.Helper()
+	response, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.StatusCode, string(body), response.Header.Get("Content-Type")
+}
+
+func TestS3Client(t *testing.T) {
+	cfg, backend := newTestS3(t)
+	client, err := NewS3Client(cfg)
+	if err != nil {
+		t.Fatalf("NewS3Client: %v", err)
+	}
+	ctx := context.Background()
+
+	url, err := client.UploadFile(ctx, "users/1/progress", "front.jpg", strings.NewReader("jpeg bytes"), "image/jpeg")
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	object, err := backend.HeadObject(testBucket, "users/1/progress/front.jpg")
+	if err != nil {
+		t.Fatalf("uploaded object not found: %v", err)
+	}
+	if object.Size != int64(len("jpeg bytes")) {
+		t.Errorf("uploaded %d bytes, want %d", object.Size, len("jpeg bytes"))
+	}
+
+	status, body, contentType := get(t, url)
+	if status != http.StatusOK || body != "jpeg bytes" {
+		t.Errorf("upload URL served %d %q, want the object", status, body)
+	}
+	if contentType != "image/jpeg" {
+		t.Errorf("content type = %q, want image/jpeg", contentType)
+	}
+
+	url, err = client.GetPresignedURL(ctx, "users/1/progress/front.jpg", time.Minute)
+	if err != nil {
+		t.Fatalf("GetPresignedURL: %v", err)
+	}
+	if status, body, _ := get(t, url); status != http.StatusOK || body != "jpeg bytes" {
+		t.Errorf("presigned URL served %d %q, want the object", status, body)
+	}
+
+	if err := client.DeleteFile(ctx, "users/1/progress/front.jpg"); err != nil {
+		t.Fatalf("DeleteFile: %v", err)
+	}
+	if status, _, _ := get(t, url); status != http.StatusNotFound {
+		t.Errorf("deleted object served %d, want %d", status, http.StatusNotFound)
+	}
+	if err := client.DeleteFile(ctx, "users/1/progress/front.jpg"); err != nil {
+		t.Errorf("deleting a missing object: %v", err)
+	}
+}
+
+func TestS3ClientWithoutBucket(t *testing.T) {
+	cfg, _ := newTestS3(t)
+	cfg.BucketName = "missing"
+	client, err := NewS3Client(cfg)
+	if err != nil {
+		t.Fatalf("NewS3Client: %v", err)
+	}
+
+	_, err = client.UploadFile(context.Background(), "users/1", "a.jpg", strings.NewReader("a"), "image/jpeg")
+	if err == nil {
+		t.Error("UploadFile succeeded without the bucket")
+	}
+}
+
+func TestNewS3ClientWithoutCredentials(t *testing.T) {
+	cfg, _ := newTestS3(t)
+	cfg.SecretAccessKey = ""
+
+	if _, err := NewS3Client(cfg); err == nil {
+		t.Error("NewS3Client succeeded without credentials")
+	}
+}
@@ -46,16 +46,32 @@ type S3Config struct {
 type Config struct {
 	BotToken string
 	Port     int
+	AdminIDs []int64
+	CoachIDs []int64
 	Logger   LoggerConfig
 	Database DatabaseConfig
 	Webhook  WebhookConfig
 	S3       S3Config
+
+	// PhotoRetentionDays is how long progress photos are kept; 0 keeps
+	// them until the user deletes their account.
+	PhotoRetentionDays int
+
+	// PublicURL is where the service is reachable from outside, for links
+	// such as calendar feeds; empty turns those links off.
+	PublicURL string
+
+	// DeletionSecret keys the hash of the Telegram ID kept when an account
+	// is deleted; empty keeps no ID at all.
+	DeletionSecret string
 }
 
 func Load() (*Config, error) {
 	config := &Config{
 		BotToken: getEnv("BOT_TOKEN", ""),
 		Port:     getEnvInt("PORT", 8080),
+		AdminIDs: getEnvInt64List("ADMIN_TELEGRAM_IDS"),
+		CoachIDs: getEnvInt64List("COACH_TELEGRAM_IDS"),
 		Logger: LoggerConfig{
 			Level:      getEnv("LOG_LEVEL", "info"),
 			FilePath:   getEnv("LOG_FILE_PATH", ""),
@@ -81,11 +97,38 @@ func Load() (*Config, error) {
 			BucketName:      getEnv("S3_BUCKET_NAME", ""),
 			Region:          getEnv("S3_REGION", "ru-central1"),
 		},
+		PhotoRetentionDays: getEnvInt("PROGRESS_PHOTO_RETENTION_DAYS", 0),
+		PublicURL:          strings.TrimSuffix(getEnv("PUBLIC_URL", ""), "/"),
+		DeletionSecret:     getEnv("ACCOUNT_DELETION_SECRET", ""),
 	}
 
 	return config, nil
 }
 
+// Enabled reports whether S3 credentials and a bucket are configured.
+func (c *S3Config) Enabled() bool {
+	return c.AccessKeyID != "" && c.SecretAccessKey != "" && c.BucketName != ""
+}
+
+func (c *Config) IsAdmin(telegramID int64) bool {
+	for _, adminID := range c.AdminIDs {
+		if adminID == telegramID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCoach reports whether the user may invite and manage clients.
+func (c *Config) IsCoach(telegramID int64) bool {
+	for _, coachID := range c.CoachIDs {
+		if coachID == telegramID {
+			return true
+		}
+	}
+	return false
+}
+
 func parseDatabaseConfig() DatabaseConfig {
 	if databaseURL := getEnv("DATABASE_URL", ""); databaseURL != "" {
 		if config, err := parseDatabaseURL(databaseURL); err == nil {
@@ -160,3 +203,17 @@ func getEnvBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getEnvInt64List(key string) []int64 {
+	var values []int64
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if intValue, err := strconv.ParseInt(item, 10, 64); err == nil {
+			values = append(values, intValue)
+		}
+	}
+	return values
+}
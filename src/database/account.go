@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// userRows are the tables holding rows of a user, with the condition that
+// picks them out. All of them go with the user by ON DELETE CASCADE; they
+// are counted for the audit record.
+var userRows = []struct {
+	table string
+	where string
+}{
+	{"workouts.workout_templates", "user_id = @user"},
+	{"workouts.workout_sessions", "user_id = @user"},
+	{"workouts.cardio_entries", "user_id = @user"},
+	{"workouts.body_measurements", "user_id = @user"},
+	{"workouts.progress_photos", "user_id = @user"},
+	{"workouts.training_maxes", "user_id = @user"},
+	{"workouts.program_enrollments", "user_id = @user"},
+	{"workouts.import_aliases", "user_id = @user"},
+	{"workouts.calendar_tokens", "user_id = @user"},
+	{"workouts.achievements", "user_id = @user"},
+	{"workouts.group_members", "user_id = @user"},
+	{"workouts.challenge_participants", "user_id = @user"},
+	{"workouts.broadcast_deliveries", "user_id = @user"},
+	{"workouts.coach_invites", "coach_id = @user"},
+	{"workouts.coach_clients", "coach_id = @user OR client_id = @user"},
+	{"workouts.session_comments", "coach_id = @user OR session_id IN " +
+		"(SELECT id FROM workouts.workout_sessions WHERE user_id = @user)"},
+}
+
+// DeleteUser removes the user with all their rows in one transaction and
+// records the deletion under the subject. Rows keyed by the Telegram ID
+// rather than the user are deleted too, and broadcasts the user sent as an
+// admin are kept with the sender cleared. Progress photos are left in
+// storage for the caller to remove once this has committed.
+func DeleteUser(user *models.User, subject string, photos int, db *gorm.DB) (*models.AccountDeletion, error) {
+	deletion := &models.AccountDeletion{
+		Subject: subject,
+		Deleted: make(map[string]int64, len(userRows)+2),
+		Photos:  photos,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, rows := range userRows {
+			var count int64
+			err := tx.Table(rows.table).Where(rows.where, sql.Named("user", user.ID)).Count(&count).Error
+			if err != nil {
+				return err
+			}
+			deletion.Deleted[rows.table] = count
+		}
+
+		result := tx.Where("telegram_id = ?", user.TelegramID).Delete(&models.PendingAction{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deletion.Deleted["workouts.pending_actions"] = result.RowsAffected
+
+		err := tx.Model(&models.Broadcast{}).
+			Where("admin_telegram_id = ?", user.TelegramID).
+			Update("admin_telegram_id", 0).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.User{}, "id = ?", user.ID).Error; err != nil {
+			return err
+		}
+		deletion.Deleted["workouts.users"] = 1
+
+		return tx.Create(deletion).Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"error":   err,
+		}).Error("Failed to delete user")
+		return nil, err
+	}
+	return deletion, nil
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListAchievements returns the user's achievements in the order earned.
+func ListAchievements(userID uuid.UUID, db *gorm.DB) ([]models.Achievement, error) {
+	var achievements []models.Achievement
+
+	err := db.Where("user_id = ?", userID).Order("earned_at, slug").Find(&achievements).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list achievements")
+	}
+	return achievements, err
+}
+
+// SaveAchievements records earned achievements, skipping ones the user
+// already has.
+func SaveAchievements(achievements []models.Achievement, db *gorm.DB) error {
+	if len(achievements) == 0 {
+		return nil
+	}
+	err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&achievements).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": achievements[0].UserID,
+			"error":   err,
+		}).Error("Failed to save achievements")
+	}
+	return err
+}
+
+// finishedSets selects the working sets of the user's finished sessions
+// that were not skipped, as counted by WorkoutSession.Volume.
+func finishedSets(userID uuid.UUID, db *gorm.DB) *gorm.DB {
+	return db.Table("workouts.session_sets AS s").
+		Joins("JOIN workouts.session_exercises AS e ON e.id = s.session_exercise_id").
+		Joins("JOIN workouts.workout_sessions AS w ON w.id = e.session_id").
+		Where("w.user_id = ? AND w.finished_at IS NOT NULL AND NOT s.warmup AND NOT s.skipped", userID)
+}
+
+// LifetimeVolume is the tonnage of all the user's finished sessions.
+func LifetimeVolume(userID uuid.UUID, db *gorm.DB) (float64, error) {
+	var volume float64
+
+	err := finishedSets(userID, db).
+		Select("COALESCE(SUM(s.reps * s.weight), 0)").
+		Scan(&volume).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to sum lifetime volume")
+	}
+	return volume, err
+}
+
+// HeaviestLifts returns the heaviest weight the user lifted for at least
+// one rep on each of the exercises, by slug. Exercises never lifted are
+// left out.
+func HeaviestLifts(userID uuid.UUID, slugs []string, db *gorm.DB) (map[string]float64, error) {
+	var rows []struct {
+		Slug   string
+		Weight float64
+	}
+
+	err := finishedSets(userID, db).
+		Joins("JOIN workouts.exercises AS x ON x.id = e.exercise_id").
+		Where("x.slug IN ? AND s.reps > 0", slugs).
+		Select("x.slug AS slug, MAX(s.weight) AS weight").
+		Group("x.slug").
+		Scan(&rows).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to get heaviest lifts")
+		return nil, err
+	}
+
+	heaviest := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		heaviest[row.Slug] = row.Weight
+	}
+	return heaviest, nil
+}
+
+// ListSessionStarts returns when the user's finished sessions started,
+// oldest first.
+func ListSessionStarts(userID uuid.UUID, db *gorm.DB) ([]time.Time, error) {
+	var starts []time.Time
+
+	err := db.Model(&models.WorkoutSession{}).
+		Where("user_id = ? AND finished_at IS NOT NULL", userID).
+		Order("started_at").
+		Pluck("started_at", &starts).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list session start times")
+	}
+	return starts, err
+}
+
+// ListSessionStartsByUser returns when the users' finished sessions
+// started, oldest first, by user. Users without sessions are left out.
+func ListSessionStartsByUser(userIDs []uuid.UUID, db *gorm.DB) (map[uuid.UUID][]time.Time, error) {
+	starts := make(map[uuid.UUID][]time.Time, len(userIDs))
+	if len(userIDs) == 0 {
+		return starts, nil
+	}
+
+	var rows []struct {
+		UserID    uuid.UUID
+		StartedAt time.Time
+	}
+	err := db.Model(&models.WorkoutSession{}).
+		Select("user_id, started_at").
+		Where("user_id IN ? AND finished_at IS NOT NULL", userIDs).
+		Order("started_at").
+		Scan(&rows).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"users": len(userIDs),
+			"error": err,
+		}).Error("Failed to list session start times")
+		return nil, err
+	}
+
+	for _, row := range rows {
+		starts[row.UserID] = append(starts[row.UserID], row.StartedAt)
+	}
+	return starts, nil
+}
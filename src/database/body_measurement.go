@@ -0,0 +1,114 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveBodyMeasurement stores the value, replacing one of the same kind
+// already entered that day.
+func SaveBodyMeasurement(measurement *models.BodyMeasurement, db *gorm.DB) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "kind"}, {Name: "measured_on"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(measurement).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": measurement.UserID,
+			"kind":    measurement.Kind,
+			"error":   err,
+		}).Error("Failed to save body measurement")
+	}
+	return err
+}
+
+// ListBodyMeasurements returns values of the kind measured on or after
+// since, oldest first.
+func ListBodyMeasurements(
+	userID uuid.UUID,
+	kind string,
+	since time.Time,
+	db *gorm.DB,
+) ([]models.BodyMeasurement, error) {
+	var measurements []models.BodyMeasurement
+
+	err := db.Where("user_id = ? AND kind = ? AND measured_on >= ?", userID, kind, since).
+		Order("measured_on").
+		Find(&measurements).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"kind":    kind,
+			"error":   err,
+		}).Error("Failed to list body measurements")
+	}
+	return measurements, err
+}
+
+// ListBodyMeasurementPage returns a page of values of the kind, newest
+// first, and the total number of values.
+func ListBodyMeasurementPage(
+	userID uuid.UUID,
+	kind string,
+	offset int,
+	limit int,
+	db *gorm.DB,
+) ([]models.BodyMeasurement, int, error) {
+	query := db.Model(&models.BodyMeasurement{}).Where("user_id = ? AND kind = ?", userID, kind)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"kind":    kind,
+			"error":   err,
+		}).Error("Failed to count body measurements")
+		return nil, 0, err
+	}
+
+	var measurements []models.BodyMeasurement
+	err := query.Order("measured_on DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&measurements).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"kind":    kind,
+			"error":   err,
+		}).Error("Failed to list body measurements")
+	}
+	return measurements, int(total), err
+}
+
+// LatestBodyMeasurements returns the most recent value of every kind the
+// user has measured, keyed by kind.
+func LatestBodyMeasurements(userID uuid.UUID, db *gorm.DB) (map[string]models.BodyMeasurement, error) {
+	var measurements []models.BodyMeasurement
+
+	latest := db.Model(&models.BodyMeasurement{}).
+		Select("kind, MAX(measured_on)").
+		Where("user_id = ?", userID).
+		Group("kind")
+	err := db.Where("user_id = ? AND (kind, measured_on) IN (?)", userID, latest).
+		Find(&measurements).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to get latest body measurements")
+		return nil, err
+	}
+
+	result := make(map[string]models.BodyMeasurement, len(measurements))
+	for _, measurement := range measurements {
+		result[measurement.Kind] = measurement
+	}
+	return result, nil
+}
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func CreateBroadcast(broadcast *models.Broadcast, db *gorm.DB) error {
+	err := db.Create(broadcast).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"admin_telegram_id": broadcast.AdminTelegramID,
+			"error":             err,
+		}).Error("Failed to create broadcast")
+	}
+	return err
+}
+
+func GetBroadcast(broadcastID uuid.UUID, db *gorm.DB) (*models.Broadcast, error) {
+	var broadcast models.Broadcast
+
+	err := db.Where("id = ?", broadcastID).First(&broadcast).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcastID,
+			"error":        err,
+		}).Error("Failed to get broadcast")
+		return nil, err
+	}
+	return &broadcast, nil
+}
+
+func GetSendingBroadcasts(db *gorm.DB) ([]models.Broadcast, error) {
+	var broadcasts []models.Broadcast
+
+	err := db.Where("status = ?", models.BroadcastStatusSending).Find(&broadcasts).Error
+	if err != nil {
+		logger.Error("Failed to get sending broadcasts:", err)
+	}
+	return broadcasts, err
+}
+
+func UpdateBroadcast(broadcast *models.Broadcast, db *gorm.DB) error {
+	broadcast.UpdatedAt = time.Now()
+	err := db.Save(broadcast).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcast.ID,
+			"error":        err,
+		}).Error("Failed to update broadcast")
+	}
+	return err
+}
+
+// FinishBroadcast stores the final status of the broadcast without
+// touching its counts.
+func FinishBroadcast(broadcast *models.Broadcast, db *gorm.DB) error {
+	broadcast.UpdatedAt = time.Now()
+	err := db.Model(broadcast).Select("status", "completed_at", "updated_at").Updates(broadcast).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcast.ID,
+			"status":       broadcast.Status,
+			"error":        err,
+		}).Error("Failed to finish broadcast")
+	}
+	return err
+}
+
+// QueueBroadcast creates a pending delivery for every user who has not
+// blocked the bot and switches the broadcast to sending.
+func QueueBroadcast(broadcast *models.Broadcast, db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var users []models.User
+		if err := tx.Where("blocked_at IS NULL").Find(&users).Error; err != nil {
+			return err
+		}
+
+		deliveries := make([]models.BroadcastDelivery, 0, len(users))
+		for _, user := range users {
+			deliveries = append(deliveries, models.BroadcastDelivery{
+				BroadcastID: broadcast.ID,
+				UserID:      user.ID,
+				TelegramID:  user.TelegramID,
+				Status:      models.DeliveryStatusPending,
+			})
+		}
+
+		if len(deliveries) > 0 {
+			if err := tx.CreateInBatches(deliveries, 500).Error; err != nil {
+				logger.WithFields(logrus.Fields{
+					"broadcast_id": broadcast.ID,
+					"error":        err,
+				}).Error("Failed to create broadcast deliveries")
+				return err
+			}
+		}
+
+		broadcast.Status = models.BroadcastStatusSending
+		broadcast.Total = len(deliveries)
+		broadcast.UpdatedAt = time.Now()
+		return tx.Save(broadcast).Error
+	})
+}
+
+func GetPendingDeliveries(broadcastID uuid.UUID, limit int, db *gorm.DB) ([]models.BroadcastDelivery, error) {
+	var deliveries []models.BroadcastDelivery
+
+	err := db.Where("broadcast_id = ? AND status = ?", broadcastID, models.DeliveryStatusPending).
+		Order("created_at").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcastID,
+			"error":        err,
+		}).Error("Failed to get pending deliveries")
+	}
+	return deliveries, err
+}
+
+func UpdateDelivery(delivery *models.BroadcastDelivery, db *gorm.DB) error {
+	delivery.UpdatedAt = time.Now()
+	err := db.Save(delivery).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"delivery_id": delivery.ID,
+			"error":       err,
+		}).Error("Failed to update broadcast delivery")
+	}
+	return err
+}
+
+// CountDeliveries returns the number of deliveries of the broadcast per status.
+func CountDeliveries(broadcastID uuid.UUID, db *gorm.DB) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+
+	err := db.Model(&models.BroadcastDelivery{}).
+		Select("status, COUNT(*) AS count").
+		Where("broadcast_id = ?", broadcastID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcastID,
+			"error":        err,
+		}).Error("Failed to count broadcast deliveries")
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"errors"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FindCalendarToken returns the user's calendar token, nil when the feed
+// is off.
+func FindCalendarToken(userID uuid.UUID, db *gorm.DB) (*models.CalendarToken, error) {
+	var token models.CalendarToken
+
+	err := db.Where("user_id = ?", userID).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to get calendar token")
+		return nil, err
+	}
+	return &token, nil
+}
+
+// SaveCalendarToken sets the hash of the user's calendar token, replacing
+// the one before so its URL stops working.
+func SaveCalendarToken(token *models.CalendarToken, db *gorm.DB) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "updated_at"}),
+	}).Create(token).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": token.UserID,
+			"error":   err,
+		}).Error("Failed to save calendar token")
+	}
+	return err
+}
+
+func DeleteCalendarToken(userID uuid.UUID, db *gorm.DB) error {
+	err := db.Where("user_id = ?", userID).Delete(&models.CalendarToken{}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to delete calendar token")
+	}
+	return err
+}
+
+// GetUserByCalendarTokenHash returns the user whose feed the token with
+// the hash opens.
+func GetUserByCalendarTokenHash(hash string, db *gorm.DB) (*models.User, error) {
+	var user models.User
+
+	err := db.Joins("JOIN workouts.calendar_tokens ON calendar_tokens.user_id = users.id").
+		Where("calendar_tokens.token_hash = ?", hash).
+		First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func CreateCallbackPayload(payload *models.CallbackPayload, db *gorm.DB) error {
+	err := db.Create(payload).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"key":   payload.Key,
+			"error": err,
+		}).Error("Failed to store callback payload")
+	}
+	return err
+}
+
+func GetCallbackPayload(key string, db *gorm.DB) (*models.CallbackPayload, error) {
+	var payload models.CallbackPayload
+
+	err := db.Where("key = ? AND expires_at > ?", key, time.Now()).First(&payload).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"key":   key,
+			"error": err,
+		}).Error("Failed to get callback payload")
+		return nil, err
+	}
+	return &payload, nil
+}
+
+func DeleteExpiredCallbackPayloads(db *gorm.DB) error {
+	err := db.Where("expires_at < ?", time.Now()).Delete(&models.CallbackPayload{}).Error
+	if err != nil {
+		logger.Error("Failed to delete expired callback payloads:", err)
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"errors"
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func SaveCardioEntry(entry *models.CardioEntry, db *gorm.DB) error {
+	err := db.Omit("Exercise").Create(entry).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":     entry.UserID,
+			"exercise_id": entry.ExerciseID,
+			"error":       err,
+		}).Error("Failed to save cardio entry")
+	}
+	return err
+}
+
+// ImportCardioEntry saves an imported activity unless the user already has
+// one that started within window of it. The existing entry is returned in
+// that case and nothing is saved.
+func ImportCardioEntry(
+	entry *models.CardioEntry,
+	window time.Duration,
+	db *gorm.DB,
+) (*models.CardioEntry, error) {
+	var duplicate *models.CardioEntry
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var existing models.CardioEntry
+		err := tx.Where(
+			"user_id = ? AND started_at BETWEEN ? AND ?",
+			entry.UserID, entry.StartedAt.Add(-window), entry.StartedAt.Add(window),
+		).Preload("Exercise").First(&existing).Error
+		if err == nil {
+			duplicate = &existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return tx.Omit("Exercise").Create(entry).Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":    entry.UserID,
+			"started_at": entry.StartedAt,
+			"error":      err,
+		}).Error("Failed to import cardio entry")
+	}
+	return duplicate, err
+}
+
+// LogSessionCardio records the activity together with the set that marks
+// the session exercise as done. It reports whether they were logged by
+// this call rather than by a concurrent one.
+func LogSessionCardio(
+	exercise *models.SessionExercise,
+	set *models.SessionSet,
+	entry *models.CardioEntry,
+	db *gorm.DB,
+) (bool, error) {
+	sessionID := exercise.SessionID
+	entry.SessionID = &sessionID
+	entry.ExerciseID = exercise.ExerciseID
+	set.SessionExerciseID = exercise.ID
+	set.Number = exercise.Logged() + 1
+	set.Seconds = entry.DurationSeconds
+
+	logged := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(set)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Omit("Exercise").Create(entry).Error; err != nil {
+			return err
+		}
+		logged = true
+		return nil
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_exercise_id": exercise.ID,
+			"error":               err,
+		}).Error("Failed to log session cardio")
+		return false, err
+	}
+	if logged {
+		exercise.Sets = append(exercise.Sets, *set)
+	}
+	return logged, nil
+}
+
+// ListCardioEntries returns the user's activities started on or after
+// since, newest first, with their exercises.
+func ListCardioEntries(userID uuid.UUID, since time.Time, db *gorm.DB) ([]models.CardioEntry, error) {
+	var entries []models.CardioEntry
+
+	err := db.Where("user_id = ? AND started_at >= ?", userID, since).
+		Order("started_at DESC").
+		Preload("Exercise").
+		Find(&entries).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list cardio entries")
+	}
+	return entries, err
+}
+
+// ListRecentCardioEntries returns the user's latest activities, newest
+// first, with their exercises.
+func ListRecentCardioEntries(userID uuid.UUID, limit int, db *gorm.DB) ([]models.CardioEntry, error) {
+	var entries []models.CardioEntry
+
+	err := db.Where("user_id = ?", userID).
+		Order("started_at DESC").
+		Limit(limit).
+		Preload("Exercise").
+		Find(&entries).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list recent cardio entries")
+	}
+	return entries, err
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"testing"
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// testDatabase is an in-memory database with the workouts schema and the
+// tables created by the statements.
+func testDatabase(t *testing.T, tables ...string) *gorm.DB {
+	t.Helper()
+	logger.InitSimple("panic")
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The attached schema lives in the one connection.
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	for _, statement := range append([]string{"ATTACH DATABASE ':memory:' AS workouts"}, tables...) {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func TestImportCardioEntry(t *testing.T) {
+	db := testDatabase(t,
+		`CREATE TABLE workouts.exercises (
+			id TEXT PRIMARY KEY,
+			slug TEXT NOT NULL
+		)`,
+		`CREATE TABLE workouts.cardio_entries (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			exercise_id TEXT NOT NULL,
+			session_id TEXT,
+			started_at DATETIME NOT NULL,
+			duration_seconds INTEGER NOT NULL,
+			distance_meters REAL NOT NULL DEFAULT 0,
+			heart_rate INTEGER NOT NULL DEFAULT 0,
+			effort INTEGER NOT NULL DEFAULT 0,
+			source TEXT NOT NULL DEFAULT '',
+			elevation_gain REAL NOT NULL DEFAULT 0,
+			max_heart_rate INTEGER NOT NULL DEFAULT 0,
+			heart_rates TEXT,
+			created_at DATETIME
+		)`,
+	)
+	running := uuid.New()
+	if err := db.Exec("INSERT INTO workouts.exercises (id, slug) VALUES (?, 'running')", running).Error; err != nil {
+		t.Fatal(err)
+	}
+	user, other := uuid.New(), uuid.New()
+	startedAt := time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)
+
+	entry := func(userID uuid.UUID, startedAt time.Time, source string) *models.CardioEntry {
+		return &models.CardioEntry{
+			ID:              uuid.New(),
+			UserID:          userID,
+			ExerciseID:      running,
+			StartedAt:       startedAt,
+			DurationSeconds: 1800,
+			DistanceMeters:  5000,
+			Source:          source,
+		}
+	}
+
+	first := entry(user, startedAt, "gpx")
+	if duplicate, err := ImportCardioEntry(first, time.Minute, db); err != nil || duplicate != nil {
+		t.Fatalf("first import = %v, %v, want it saved", duplicate, err)
+	}
+
+	tests := []struct {
+		name      string
+		entry     *models.CardioEntry
+		duplicate bool
+	}{
+		{"same start time", entry(user, startedAt, "gpx"), true},
+		{"same activity from another device", entry(user, startedAt.Add(20*time.Second), "fit"), true},
+		{"at the edge of the window", entry(user, startedAt.Add(-time.Minute), "tcx"), true},
+		{"outside the window", entry(user, startedAt.Add(time.Minute+time.Second), "gpx"), false},
+		{"another user", entry(other, startedAt, "gpx"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duplicate, err := ImportCardioEntry(tt.entry, time.Minute, db)
+			if err != nil {
+				t.Fatalf("ImportCardioEntry: %v", err)
+			}
+
+			var saved int64
+			if err := db.Model(&models.CardioEntry{}).Where("id = ?", tt.entry.ID).Count(&saved).Error; err != nil {
+				t.Fatal(err)
+			}
+			if !tt.duplicate {
+				if duplicate != nil || saved != 1 {
+					t.Errorf("import = duplicate %v, %d saved, want it saved", duplicate, saved)
+				}
+				return
+			}
+			if duplicate == nil || duplicate.ID != first.ID {
+				t.Fatalf("duplicate = %v, want the first import", duplicate)
+			}
+			if duplicate.Exercise.Slug != "running" {
+				t.Errorf("duplicate exercise = %q, want it loaded", duplicate.Exercise.Slug)
+			}
+			if saved != 0 {
+				t.Errorf("duplicate was saved")
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package database
+
+import (
+	"errors"
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FindCoachInvite returns the coach's invite, or nil when the coach has
+// not made one yet.
+func FindCoachInvite(coachID uuid.UUID, db *gorm.DB) (*models.CoachInvite, error) {
+	var invite models.CoachInvite
+
+	err := db.Where("coach_id = ?", coachID).First(&invite).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"coach_id": coachID,
+			"error":    err,
+		}).Error("Failed to find coach invite")
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// SaveCoachInvite stores the coach's invite token, replacing the old one
+// so that the old link stops working.
+func SaveCoachInvite(invite *models.CoachInvite, db *gorm.DB) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "coach_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "created_at"}),
+	}).Create(invite).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"coach_id": invite.CoachID,
+			"error":    err,
+		}).Error("Failed to save coach invite")
+	}
+	return err
+}
+
+// GetCoachByInvite returns the coach whose invite has the token.
+func GetCoachByInvite(token string, db *gorm.DB) (*models.User, error) {
+	var coach models.User
+
+	err := db.Joins("JOIN workouts.coach_invites AS i ON i.coach_id = workouts.users.id").
+		Where("i.token = ?", token).
+		First(&coach).Error
+	if err != nil {
+		return nil, err
+	}
+	return &coach, nil
+}
+
+// LinkCoachClient makes the coach the client's coach, replacing any coach
+// the client had. The replaced coach is returned so they can be told, nil
+// when the client had none or already had this coach.
+func LinkCoachClient(coachID, clientID uuid.UUID, db *gorm.DB) (*models.User, error) {
+	var replaced *models.User
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var current []models.CoachClient
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Coach").
+			Where("client_id = ?", clientID).
+			Limit(1).
+			Find(&current).Error
+		if err != nil {
+			return err
+		}
+		if len(current) > 0 && current[0].CoachID != coachID {
+			replaced = &current[0].Coach
+		}
+
+		link := models.CoachClient{CoachID: coachID, ClientID: clientID}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "client_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"coach_id", "checked_on", "created_at"}),
+		}).Create(&link).Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"coach_id":  coachID,
+			"client_id": clientID,
+			"error":     err,
+		}).Error("Failed to link coach and client")
+		return nil, err
+	}
+	return replaced, nil
+}
+
+// UnlinkCoachClient ends the coaching. It reports whether the client had
+// this coach.
+func UnlinkCoachClient(coachID, clientID uuid.UUID, db *gorm.DB) (bool, error) {
+	result := db.Where("coach_id = ? AND client_id = ?", coachID, clientID).Delete(&models.CoachClient{})
+	if result.Error != nil {
+		logger.WithFields(logrus.Fields{
+			"coach_id":  coachID,
+			"client_id": clientID,
+			"error":     result.Error,
+		}).Error("Failed to unlink coach and client")
+	}
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindClientCoach returns the client's link to their coach, with the
+// coach, or nil when the client has no coach.
+func FindClientCoach(clientID uuid.UUID, db *gorm.DB) (*models.CoachClient, error) {
+	var links []models.CoachClient
+
+	err := db.Preload("Coach").Where("client_id = ?", clientID).Limit(1).Find(&links).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"client_id": clientID,
+			"error":     err,
+		}).Error("Failed to find client's coach")
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+	return &links[0], nil
+}
+
+// GetCoachClient returns the client of the coach. Every coach access to a
+// client goes through it; a user who is not the coach's client is not
+// found.
+func GetCoachClient(coachID, clientID uuid.UUID, db *gorm.DB) (*models.CoachClient, error) {
+	var link models.CoachClient
+
+	err := db.Preload("Client").
+		Where("coach_id = ? AND client_id = ?", coachID, clientID).
+		First(&link).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"coach_id":  coachID,
+			"client_id": clientID,
+			"error":     err,
+		}).Warn("Coach client not found")
+		return nil, err
+	}
+	return &link, nil
+}
+
+// ListCoachClients returns the coach's clients in the order they joined.
+func ListCoachClients(coachID uuid.UUID, db *gorm.DB) ([]models.CoachClient, error) {
+	var links []models.CoachClient
+
+	err := db.Preload("Client").
+		Where("coach_id = ?", coachID).
+		Order("created_at").
+		Find(&links).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"coach_id": coachID,
+			"error":    err,
+		}).Error("Failed to list coach clients")
+	}
+	return links, err
+}
+
+// ListUncheckedCoachClients returns links, with the coach and client, not
+// yet checked for missed workouts on the given day.
+func ListUncheckedCoachClients(day time.Time, db *gorm.DB) ([]models.CoachClient, error) {
+	var links []models.CoachClient
+
+	err := db.Preload("Coach").Preload("Client").
+		Where("checked_on IS NULL OR checked_on < ?", day).
+		Find(&links).Error
+	if err != nil {
+		logger.WithField("error", err).Error("Failed to list unchecked coach clients")
+	}
+	return links, err
+}
+
+func MarkCoachClientChecked(linkID uuid.UUID, day time.Time, db *gorm.DB) error {
+	err := db.Model(&models.CoachClient{}).
+		Where("id = ?", linkID).
+		Update("checked_on", day).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"link_id": linkID,
+			"error":   err,
+		}).Error("Failed to mark coach client checked")
+	}
+	return err
+}
+
+// GetClientSession returns a finished session of one of the coach's
+// clients, with exercises and sets.
+func GetClientSession(sessionID, coachID uuid.UUID, db *gorm.DB) (*models.WorkoutSession, error) {
+	var session models.WorkoutSession
+
+	clients := db.Model(&models.CoachClient{}).Select("client_id").Where("coach_id = ?", coachID)
+	err := preloadSession(db).
+		Where("id = ? AND finished_at IS NOT NULL AND user_id IN (?)", sessionID, clients).
+		First(&session).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_id": sessionID,
+			"coach_id":   coachID,
+			"error":      err,
+		}).Warn("Client session not found")
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListRecentSessions returns the user's last finished sessions, newest
+// first, without exercises.
+func ListRecentSessions(userID uuid.UUID, limit int, db *gorm.DB) ([]models.WorkoutSession, error) {
+	var sessions []models.WorkoutSession
+
+	err := db.Where("user_id = ? AND finished_at IS NOT NULL", userID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&sessions).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list recent sessions")
+	}
+	return sessions, err
+}
+
+// CountSessionsStarted counts the user's sessions started from one time
+// until another, finished or not.
+func CountSessionsStarted(userID uuid.UUID, from time.Time, until time.Time, db *gorm.DB) (int64, error) {
+	var count int64
+
+	err := db.Model(&models.WorkoutSession{}).
+		Where("user_id = ? AND started_at >= ? AND started_at < ?", userID, from, until).
+		Count(&count).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to count started sessions")
+	}
+	return count, err
+}
+
+func CreateSessionComment(comment *models.SessionComment, db *gorm.DB) error {
+	err := db.Create(comment).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_id": comment.SessionID,
+			"coach_id":   comment.CoachID,
+			"error":      err,
+		}).Error("Failed to create session comment")
+	}
+	return err
+}
+
+// ListSessionComments returns the comments on a session, oldest first.
+func ListSessionComments(sessionID uuid.UUID, db *gorm.DB) ([]models.SessionComment, error) {
+	var comments []models.SessionComment
+
+	err := db.Where("session_id = ?", sessionID).Order("created_at").Find(&comments).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_id": sessionID,
+			"error":      err,
+		}).Error("Failed to list session comments")
+	}
+	return comments, err
+}
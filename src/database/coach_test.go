@@ -0,0 +1,66 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLinkCoachClient(t *testing.T) {
+	db := testDatabase(t,
+		`CREATE TABLE workouts.users (
+			id TEXT PRIMARY KEY,
+			telegram_id INTEGER NOT NULL,
+			first_name TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE TABLE workouts.coach_clients (
+			id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(16)))),
+			coach_id TEXT NOT NULL,
+			client_id TEXT NOT NULL UNIQUE,
+			checked_on DATE,
+			created_at DATETIME
+		)`,
+	)
+	first, second, client := uuid.New(), uuid.New(), uuid.New()
+	for i, id := range []uuid.UUID{first, second, client} {
+		if err := db.Exec("INSERT INTO workouts.users (id, telegram_id) VALUES (?, ?)", id, i+1).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	steps := []struct {
+		name     string
+		coach    uuid.UUID
+		replaced int64
+	}{
+		{"first coach", first, 0},
+		{"same coach again", first, 0},
+		{"another coach", second, 1},
+	}
+
+	for _, step := range steps {
+		replaced, err := LinkCoachClient(step.coach, client, db)
+		if err != nil {
+			t.Fatalf("%s: LinkCoachClient: %v", step.name, err)
+		}
+		switch {
+		case step.replaced == 0 && replaced != nil:
+			t.Errorf("%s: replaced coach %d, want none", step.name, replaced.TelegramID)
+		case step.replaced != 0 && (replaced == nil || replaced.TelegramID != step.replaced):
+			t.Errorf("%s: replaced coach %v, want %d", step.name, replaced, step.replaced)
+		}
+
+		link, err := FindClientCoach(client, db)
+		if err != nil || link == nil || link.CoachID != step.coach {
+			t.Fatalf("%s: client's coach = %v, %v, want %s", step.name, link, err, step.coach)
+		}
+	}
+
+	var links int64
+	if err := db.Table("workouts.coach_clients").Count(&links).Error; err != nil {
+		t.Fatal(err)
+	}
+	if links != 1 {
+		t.Errorf("client has %d coach links, want 1", links)
+	}
+}
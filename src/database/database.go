@@ -41,3 +41,18 @@ func Connect(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Today is the current date at midnight UTC, the value stored in DATE
+// columns.
+func Today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"strings"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func GetExercise(exerciseID uuid.UUID, db *gorm.DB) (*models.Exercise, error) {
+	var exercise models.Exercise
+
+	err := db.Where("id = ?", exerciseID).First(&exercise).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"exercise_id": exerciseID,
+			"error":       err,
+		}).Error("Failed to get exercise")
+		return nil, err
+	}
+	return &exercise, nil
+}
+
+// ListExercises returns the whole catalog.
+func ListExercises(db *gorm.DB) ([]models.Exercise, error) {
+	var exercises []models.Exercise
+
+	err := db.Order("slug").Find(&exercises).Error
+	if err != nil {
+		logger.WithField("error", err).Error("Failed to list exercises")
+	}
+	return exercises, err
+}
+
+// SearchExercises finds catalog exercises whose Russian or English name
+// contains the query, case-insensitively.
+func SearchExercises(query string, limit int, db *gorm.DB) ([]models.Exercise, error) {
+	var exercises []models.Exercise
+
+	pattern := "%" + strings.ToLower(strings.TrimSpace(query)) + "%"
+	err := db.Where("LOWER(name_ru) LIKE ? OR LOWER(name_en) LIKE ?", pattern, pattern).
+		Order("name_ru").
+		Limit(limit).
+		Find(&exercises).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"query": query,
+			"error": err,
+		}).Error("Failed to search exercises")
+	}
+	return exercises, err
+}
+
+// ListExercisesByCategory returns one page of the catalog category together
+// with the total number of exercises in it.
+func ListExercisesByCategory(
+	category string,
+	offset int,
+	limit int,
+	db *gorm.DB,
+) ([]models.Exercise, int, error) {
+	var exercises []models.Exercise
+	var total int64
+
+	query := db.Model(&models.Exercise{}).Where("category = ?", category)
+	if err := query.Count(&total).Error; err != nil {
+		logger.WithFields(logrus.Fields{
+			"category": category,
+			"error":    err,
+		}).Error("Failed to count exercises")
+		return nil, 0, err
+	}
+
+	err := query.Order("name_ru").Offset(offset).Limit(limit).Find(&exercises).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"category": category,
+			"error":    err,
+		}).Error("Failed to list exercises")
+		return nil, 0, err
+	}
+	return exercises, int(total), nil
+}
+
+// ListExercisesBySlugs returns the catalog exercises with the slugs, keyed
+// by slug. Unknown slugs are left out.
+func ListExercisesBySlugs(slugs []string, db *gorm.DB) (map[string]models.Exercise, error) {
+	var exercises []models.Exercise
+
+	err := db.Where("slug IN ?", slugs).Find(&exercises).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"slugs": slugs,
+			"error": err,
+		}).Error("Failed to list exercises by slugs")
+		return nil, err
+	}
+
+	bySlug := make(map[string]models.Exercise, len(exercises))
+	for _, exercise := range exercises {
+		bySlug[exercise.Slug] = exercise
+	}
+	return bySlug, nil
+}
+
+// ListCardioExercises returns the exercises logged as activities.
+func ListCardioExercises(db *gorm.DB) ([]models.Exercise, error) {
+	var exercises []models.Exercise
+
+	err := db.Where("category IN ?", []string{models.CategoryCardio, models.CategoryEndurance}).
+		Order("slug").
+		Find(&exercises).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("Failed to list cardio exercises")
+	}
+	return exercises, err
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func CreateExerciseMedia(media *models.ExerciseMedia, db *gorm.DB) error {
+	err := db.Create(media).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"exercise_id": media.ExerciseID,
+			"object_key":  media.ObjectKey,
+			"error":       err,
+		}).Error("Failed to create exercise media")
+	}
+	return err
+}
+
+// ListExerciseMedia returns the exercise's media of the given kinds in
+// upload order.
+func ListExerciseMedia(exerciseID uuid.UUID, kinds []string, db *gorm.DB) ([]models.ExerciseMedia, error) {
+	var media []models.ExerciseMedia
+
+	err := db.Where("exercise_id = ? AND kind IN ?", exerciseID, kinds).
+		Order("created_at").
+		Find(&media).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"exercise_id": exerciseID,
+			"error":       err,
+		}).Error("Failed to list exercise media")
+	}
+	return media, err
+}
+
+func UpdateExerciseMediaFileID(media *models.ExerciseMedia, fileID string, db *gorm.DB) error {
+	err := db.Model(media).Updates(map[string]any{
+		"telegram_file_id": fileID,
+		"updated_at":       time.Now(),
+	}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"media_id": media.ID,
+			"error":    err,
+		}).Error("Failed to cache media file ID")
+		return err
+	}
+	media.TelegramFileID = fileID
+	return nil
+}
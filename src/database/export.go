@@ -0,0 +1,128 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// EachSession passes the user's sessions, with exercises and sets, to fn
+// in batches of size, oldest first, so an export never holds the whole
+// history.
+func EachSession(
+	userID uuid.UUID,
+	size int,
+	fn func([]models.WorkoutSession) error,
+	db *gorm.DB,
+) error {
+	err := eachBatch(
+		func() *gorm.DB { return preloadSession(db).Where("user_id = ?", userID) },
+		size,
+		func(session *models.WorkoutSession) (time.Time, uuid.UUID) { return session.StartedAt, session.ID },
+		fn,
+	)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to read sessions")
+	}
+	return err
+}
+
+// EachCardioEntry passes the user's activities with their exercises to fn
+// in batches of size, oldest first.
+func EachCardioEntry(
+	userID uuid.UUID,
+	size int,
+	fn func([]models.CardioEntry) error,
+	db *gorm.DB,
+) error {
+	err := eachBatch(
+		func() *gorm.DB { return db.Preload("Exercise").Where("user_id = ?", userID) },
+		size,
+		func(entry *models.CardioEntry) (time.Time, uuid.UUID) { return entry.StartedAt, entry.ID },
+		fn,
+	)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to read cardio entries")
+	}
+	return err
+}
+
+// eachBatch pages through the query by start time and ID, which unlike an
+// offset stays cheap deep into a long history.
+func eachBatch[T any](
+	query func() *gorm.DB,
+	size int,
+	key func(*T) (time.Time, uuid.UUID),
+	fn func([]T) error,
+) error {
+	var afterTime time.Time
+	var afterID uuid.UUID
+	for first := true; ; first = false {
+		var batch []T
+		q := query()
+		if !first {
+			q = q.Where("(started_at > ? OR (started_at = ? AND id > ?))", afterTime, afterTime, afterID)
+		}
+		if err := q.Order("started_at, id").Limit(size).Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < size {
+			return nil
+		}
+		afterTime, afterID = key(&batch[len(batch)-1])
+	}
+}
+
+// ListTemplatesWithExercises returns the user's templates with their
+// exercises in order.
+func ListTemplatesWithExercises(userID uuid.UUID, db *gorm.DB) ([]models.WorkoutTemplate, error) {
+	var templates []models.WorkoutTemplate
+
+	err := db.Where("user_id = ?", userID).
+		Preload("Exercises", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("position")
+		}).
+		Preload("Exercises.Exercise").
+		Order("created_at").
+		Find(&templates).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list templates with exercises")
+	}
+	return templates, err
+}
+
+// ListAllBodyMeasurements returns every value the user entered, oldest
+// first.
+func ListAllBodyMeasurements(userID uuid.UUID, db *gorm.DB) ([]models.BodyMeasurement, error) {
+	var measurements []models.BodyMeasurement
+
+	err := db.Where("user_id = ?", userID).
+		Order("measured_on, kind").
+		Find(&measurements).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list all body measurements")
+	}
+	return measurements, err
+}
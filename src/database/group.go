@@ -0,0 +1,364 @@
+package database
+
+import (
+	"errors"
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WeekTotals is what a group leaderboard ranks a member by.
+type WeekTotals struct {
+	Sessions int
+	Volume   float64
+}
+
+// ExerciseDay totals the working sets of one exercise a user did on a day.
+type ExerciseDay struct {
+	UserID  uuid.UUID
+	Day     time.Time
+	Reps    int
+	Seconds int
+}
+
+// FindGroup returns the group of a chat, or nil when the bot has not
+// registered the chat yet.
+func FindGroup(chatID int64, db *gorm.DB) (*models.Group, error) {
+	var group models.Group
+
+	err := db.Where("chat_id = ?", chatID).First(&group).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to find group")
+		return nil, err
+	}
+	return &group, nil
+}
+
+// CreateGroup registers the chat. When it was registered meanwhile, group
+// is loaded with the stored one instead.
+func CreateGroup(group *models.Group, db *gorm.DB) error {
+	err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(group).Error
+	if err == nil {
+		err = db.Where("chat_id = ?", group.ChatID).First(group).Error
+	}
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": group.ChatID,
+			"error":   err,
+		}).Error("Failed to create group")
+	}
+	return err
+}
+
+// RenameGroup keeps the group's title up with the chat's.
+func RenameGroup(group *models.Group, title string, db *gorm.DB) error {
+	err := db.Model(group).Update("title", title).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"group_id": group.ID,
+			"error":    err,
+		}).Error("Failed to rename group")
+	}
+	return err
+}
+
+// DeleteGroup forgets a chat the bot was removed from, with its members
+// and challenges.
+func DeleteGroup(chatID int64, db *gorm.DB) error {
+	err := db.Where("chat_id = ?", chatID).Delete(&models.Group{}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"error":   err,
+		}).Error("Failed to delete group")
+	}
+	return err
+}
+
+// AddGroupMember opts the user in to the group. It reports whether the
+// user was not a member yet.
+func AddGroupMember(groupID, userID uuid.UUID, db *gorm.DB) (bool, error) {
+	member := models.GroupMember{GroupID: groupID, UserID: userID}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&member)
+	if result.Error != nil {
+		logger.WithFields(logrus.Fields{
+			"group_id": groupID,
+			"user_id":  userID,
+			"error":    result.Error,
+		}).Error("Failed to add group member")
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RemoveGroupMember opts the user out of the group and of its challenges.
+// It reports whether the user was a member.
+func RemoveGroupMember(groupID, userID uuid.UUID, db *gorm.DB) (bool, error) {
+	var removed bool
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		challenges := tx.Model(&models.Challenge{}).Select("id").Where("group_id = ?", groupID)
+		err := tx.Where("user_id = ? AND challenge_id IN (?)", userID, challenges).
+			Delete(&models.ChallengeParticipant{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("group_id = ? AND user_id = ?", groupID, userID).Delete(&models.GroupMember{})
+		removed = result.RowsAffected > 0
+		return result.Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"group_id": groupID,
+			"user_id":  userID,
+			"error":    err,
+		}).Error("Failed to remove group member")
+	}
+	return removed, err
+}
+
+func IsGroupMember(groupID, userID uuid.UUID, db *gorm.DB) (bool, error) {
+	var count int64
+
+	err := db.Model(&models.GroupMember{}).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		Count(&count).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"group_id": groupID,
+			"user_id":  userID,
+			"error":    err,
+		}).Error("Failed to check group membership")
+	}
+	return count > 0, err
+}
+
+// ListGroupMembers returns the group's members with their users, in the
+// order they joined.
+func ListGroupMembers(groupID uuid.UUID, db *gorm.DB) ([]models.GroupMember, error) {
+	var members []models.GroupMember
+
+	err := db.Preload("User").
+		Where("group_id = ?", groupID).
+		Order("created_at").
+		Find(&members).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"group_id": groupID,
+			"error":    err,
+		}).Error("Failed to list group members")
+	}
+	return members, err
+}
+
+// WeeklyTotals counts the finished sessions started since the given time
+// and their volume, by user. Users without sessions are left out.
+func WeeklyTotals(userIDs []uuid.UUID, since time.Time, db *gorm.DB) (map[uuid.UUID]WeekTotals, error) {
+	totals := make(map[uuid.UUID]WeekTotals, len(userIDs))
+	if len(userIDs) == 0 {
+		return totals, nil
+	}
+
+	var sessions []struct {
+		UserID   uuid.UUID
+		Sessions int
+	}
+	err := db.Model(&models.WorkoutSession{}).
+		Select("user_id, COUNT(*) AS sessions").
+		Where("user_id IN ? AND finished_at IS NOT NULL AND started_at >= ?", userIDs, since).
+		Group("user_id").
+		Scan(&sessions).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"users": len(userIDs),
+			"error": err,
+		}).Error("Failed to count weekly sessions")
+		return nil, err
+	}
+
+	var volumes []struct {
+		UserID uuid.UUID
+		Volume float64
+	}
+	err = db.Table("workouts.session_sets AS s").
+		Joins("JOIN workouts.session_exercises AS e ON e.id = s.session_exercise_id").
+		Joins("JOIN workouts.workout_sessions AS w ON w.id = e.session_id").
+		Where("w.user_id IN ? AND w.finished_at IS NOT NULL AND w.started_at >= ?", userIDs, since).
+		Where("NOT s.warmup AND NOT s.skipped").
+		Select("w.user_id AS user_id, COALESCE(SUM(s.reps * s.weight), 0) AS volume").
+		Group("w.user_id").
+		Scan(&volumes).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"users": len(userIDs),
+			"error": err,
+		}).Error("Failed to sum weekly volume")
+		return nil, err
+	}
+
+	for _, row := range sessions {
+		totals[row.UserID] = WeekTotals{Sessions: row.Sessions}
+	}
+	for _, row := range volumes {
+		week := totals[row.UserID]
+		week.Volume = row.Volume
+		totals[row.UserID] = week
+	}
+	return totals, nil
+}
+
+// ExerciseDays totals the users' working sets of an exercise, by slug, in
+// finished sessions started from one day through another.
+func ExerciseDays(
+	userIDs []uuid.UUID,
+	slug string,
+	from time.Time,
+	through time.Time,
+	db *gorm.DB,
+) ([]ExerciseDay, error) {
+	var days []ExerciseDay
+	if len(userIDs) == 0 {
+		return days, nil
+	}
+
+	err := db.Table("workouts.session_sets AS s").
+		Joins("JOIN workouts.session_exercises AS e ON e.id = s.session_exercise_id").
+		Joins("JOIN workouts.workout_sessions AS w ON w.id = e.session_id").
+		Joins("JOIN workouts.exercises AS x ON x.id = e.exercise_id").
+		Where("w.user_id IN ? AND w.finished_at IS NOT NULL AND x.slug = ?", userIDs, slug).
+		Where("w.started_at >= ? AND w.started_at < ?", from, through.AddDate(0, 0, 1)).
+		Where("NOT s.warmup AND NOT s.skipped").
+		Select("w.user_id AS user_id, DATE(w.started_at) AS day, " +
+			"COALESCE(SUM(s.reps), 0) AS reps, COALESCE(SUM(s.seconds), 0) AS seconds").
+		Group("w.user_id, DATE(w.started_at)").
+		Scan(&days).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"users":    len(userIDs),
+			"exercise": slug,
+			"error":    err,
+		}).Error("Failed to total exercise days")
+	}
+	return days, err
+}
+
+func CreateChallenge(challenge *models.Challenge, db *gorm.DB) error {
+	err := db.Create(challenge).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"group_id": challenge.GroupID,
+			"kind":     challenge.Kind,
+			"error":    err,
+		}).Error("Failed to create challenge")
+	}
+	return err
+}
+
+func GetChallenge(challengeID uuid.UUID, db *gorm.DB) (*models.Challenge, error) {
+	var challenge models.Challenge
+
+	err := db.Preload("Group").First(&challenge, "id = ?", challengeID).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"challenge_id": challengeID,
+			"error":        err,
+		}).Error("Failed to get challenge")
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// ListActiveChallenges returns the group's challenges that have not ended
+// by the given day, soonest to end first.
+func ListActiveChallenges(groupID uuid.UUID, day time.Time, db *gorm.DB) ([]models.Challenge, error) {
+	var challenges []models.Challenge
+
+	err := db.Where("group_id = ? AND ends_on >= ?", groupID, day).
+		Order("ends_on, created_at").
+		Find(&challenges).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"group_id": groupID,
+			"error":    err,
+		}).Error("Failed to list active challenges")
+	}
+	return challenges, err
+}
+
+// ListUnpostedChallenges returns challenges that started by the given day
+// and still have days whose progress was not posted.
+func ListUnpostedChallenges(day time.Time, db *gorm.DB) ([]models.Challenge, error) {
+	var challenges []models.Challenge
+
+	err := db.Preload("Group").
+		Where("starts_on <= ?", day).
+		Where("posted_on IS NULL OR (posted_on < ends_on AND posted_on < ?)", day).
+		Order("created_at").
+		Find(&challenges).Error
+	if err != nil {
+		logger.WithField("error", err).Error("Failed to list unposted challenges")
+	}
+	return challenges, err
+}
+
+// MarkChallengePosted records the last day whose progress was posted.
+func MarkChallengePosted(challengeID uuid.UUID, day time.Time, db *gorm.DB) error {
+	err := db.Model(&models.Challenge{}).
+		Where("id = ?", challengeID).
+		Update("posted_on", day).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"challenge_id": challengeID,
+			"error":        err,
+		}).Error("Failed to mark challenge posted")
+	}
+	return err
+}
+
+// JoinChallenge adds the user to the challenge. It reports whether the
+// user had not joined yet.
+func JoinChallenge(challengeID, userID uuid.UUID, db *gorm.DB) (bool, error) {
+	participant := models.ChallengeParticipant{ChallengeID: challengeID, UserID: userID}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&participant)
+	if result.Error != nil {
+		logger.WithFields(logrus.Fields{
+			"challenge_id": challengeID,
+			"user_id":      userID,
+			"error":        result.Error,
+		}).Error("Failed to join challenge")
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListChallengeParticipants returns the challenge's participants with
+// their users, in the order they joined.
+func ListChallengeParticipants(challengeID uuid.UUID, db *gorm.DB) ([]models.ChallengeParticipant, error) {
+	var participants []models.ChallengeParticipant
+
+	err := db.Preload("User").
+		Where("challenge_id = ?", challengeID).
+		Order("created_at").
+		Find(&participants).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"challenge_id": challengeID,
+			"error":        err,
+		}).Error("Failed to list challenge participants")
+	}
+	return participants, err
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"errors"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListImportAliases returns the exercises the user chose for names used by
+// other apps, keyed by name. Names the user skipped map to nil.
+func ListImportAliases(userID uuid.UUID, db *gorm.DB) (map[string]*uuid.UUID, error) {
+	var aliases []models.ImportAlias
+
+	err := db.Where("user_id = ?", userID).Find(&aliases).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list import aliases")
+		return nil, err
+	}
+
+	byName := make(map[string]*uuid.UUID, len(aliases))
+	for _, alias := range aliases {
+		byName[alias.Name] = alias.ExerciseID
+	}
+	return byName, nil
+}
+
+// SaveImportAlias stores the user's choice for the name, replacing an
+// earlier one.
+func SaveImportAlias(alias *models.ImportAlias, db *gorm.DB) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"exercise_id", "updated_at"}),
+	}).Create(alias).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": alias.UserID,
+			"name":    alias.Name,
+			"error":   err,
+		}).Error("Failed to save import alias")
+	}
+	return err
+}
+
+// ImportSession saves a finished session imported from another app with
+// its exercises, sets and activities, unless the user already has a
+// session with its import key. It reports whether the session was saved.
+func ImportSession(
+	session *models.WorkoutSession,
+	entries []models.CardioEntry,
+	db *gorm.DB,
+) (bool, error) {
+	saved := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var existing models.WorkoutSession
+		err := tx.Select("id").
+			Where("user_id = ? AND import_key = ?", session.UserID, session.ImportKey).
+			First(&existing).Error
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		for i := range entries {
+			entries[i].SessionID = &session.ID
+			if err := tx.Omit("Exercise").Create(&entries[i]).Error; err != nil {
+				return err
+			}
+		}
+		saved = true
+		return nil
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":    session.UserID,
+			"import_key": session.ImportKey,
+			"error":      err,
+		}).Error("Failed to import session")
+	}
+	return saved, err
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func CreatePendingAction(action *models.PendingAction, db *gorm.DB) error {
+	err := db.Create(action).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"telegram_id": action.TelegramID,
+			"action":      action.Action,
+			"error":       err,
+		}).Error("Failed to create pending action")
+	}
+	return err
+}
+
+func GetPendingAction(actionID uuid.UUID, db *gorm.DB) (*models.PendingAction, error) {
+	var action models.PendingAction
+
+	err := db.Where("id = ?", actionID).First(&action).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"action_id": actionID,
+			"error":     err,
+		}).Error("Failed to get pending action")
+		return nil, err
+	}
+	return &action, nil
+}
+
+// TakePendingAction deletes the action and reports whether this call was
+// the one that removed it, so a double tap cannot run an action twice.
+func TakePendingAction(actionID uuid.UUID, db *gorm.DB) (bool, error) {
+	result := db.Where("id = ?", actionID).Delete(&models.PendingAction{})
+	if result.Error != nil {
+		logger.WithFields(logrus.Fields{
+			"action_id": actionID,
+			"error":     result.Error,
+		}).Error("Failed to delete pending action")
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func DeleteExpiredPendingActions(db *gorm.DB) error {
+	err := db.Where("expires_at < ?", time.Now()).Delete(&models.PendingAction{}).Error
+	if err != nil {
+		logger.Error("Failed to delete expired pending actions:", err)
+	}
+	return err
+}
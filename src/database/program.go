@@ -0,0 +1,216 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FindProgramEnrollment returns the user's enrollment, or nil if the user
+// does not follow a program.
+func FindProgramEnrollment(userID uuid.UUID, db *gorm.DB) (*models.ProgramEnrollment, error) {
+	var enrollments []models.ProgramEnrollment
+
+	err := db.Where("user_id = ?", userID).Limit(1).Find(&enrollments).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to find program enrollment")
+		return nil, err
+	}
+	if len(enrollments) == 0 {
+		return nil, nil
+	}
+	return &enrollments[0], nil
+}
+
+// EnrollInProgram starts the program from its first day, replacing any
+// program the user followed before.
+func EnrollInProgram(userID uuid.UUID, program string, db *gorm.DB) (*models.ProgramEnrollment, error) {
+	enrollment := &models.ProgramEnrollment{
+		ID:      uuid.New(),
+		UserID:  userID,
+		Program: program,
+		Cycle:   1,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ProgramEnrollment{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(enrollment).Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"program": program,
+			"error":   err,
+		}).Error("Failed to enroll in program")
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+func LeaveProgram(userID uuid.UUID, db *gorm.DB) error {
+	err := db.Where("user_id = ?", userID).Delete(&models.ProgramEnrollment{}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to leave program")
+	}
+	return err
+}
+
+// AdvanceEnrollment moves the enrollment to week and day. When a cycle was
+// completed, raises are added to the training maxes, keyed by exercise ID.
+func AdvanceEnrollment(
+	enrollment *models.ProgramEnrollment,
+	week int,
+	day int,
+	cycled bool,
+	raises map[uuid.UUID]float64,
+	db *gorm.DB,
+) error {
+	cycle := enrollment.Cycle
+	if cycled {
+		cycle++
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(enrollment).Updates(map[string]any{
+			"cycle":      cycle,
+			"week":       week,
+			"day":        day,
+			"updated_at": time.Now(),
+		}).Error
+		if err != nil || !cycled {
+			return err
+		}
+		for exerciseID, raise := range raises {
+			err := tx.Model(&models.TrainingMax{}).
+				Where("user_id = ? AND exercise_id = ?", enrollment.UserID, exerciseID).
+				Updates(map[string]any{
+					"weight":     gorm.Expr("weight + ?", raise),
+					"updated_at": time.Now(),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"enrollment_id": enrollment.ID,
+			"error":         err,
+		}).Error("Failed to advance program enrollment")
+		return err
+	}
+	enrollment.Cycle, enrollment.Week, enrollment.Day = cycle, week, day
+	return nil
+}
+
+// ListTrainingMaxes returns the user's training maxes with their exercises.
+func ListTrainingMaxes(userID uuid.UUID, db *gorm.DB) ([]models.TrainingMax, error) {
+	var maxes []models.TrainingMax
+
+	err := db.Preload("Exercise").Where("user_id = ?", userID).Find(&maxes).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list training maxes")
+	}
+	return maxes, err
+}
+
+// SaveTrainingMax stores the user's training max for the exercise,
+// replacing the previous one. The known one-rep max is kept.
+func SaveTrainingMax(trainingMax *models.TrainingMax, db *gorm.DB) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "exercise_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"weight", "source", "updated_at"}),
+	}).Omit("Exercise").Create(trainingMax).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":     trainingMax.UserID,
+			"exercise_id": trainingMax.ExerciseID,
+			"error":       err,
+		}).Error("Failed to save training max")
+	}
+	return err
+}
+
+// RecordOneRepMax stores a one-rep max for the exercise with the training
+// max derived from it. A tested 1RM replaces both values; an estimate only
+// raises them. It returns the stored row and
+// whether anything changed.
+func RecordOneRepMax(
+	userID uuid.UUID,
+	exerciseID uuid.UUID,
+	oneRepMax float64,
+	weight float64,
+	source string,
+	db *gorm.DB,
+) (*models.TrainingMax, bool, error) {
+	var trainingMax models.TrainingMax
+	changed := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var existing []models.TrainingMax
+		err := tx.Where("user_id = ? AND exercise_id = ?", userID, exerciseID).
+			Limit(1).
+			Find(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		if len(existing) == 0 {
+			trainingMax = models.TrainingMax{
+				UserID:     userID,
+				ExerciseID: exerciseID,
+				Weight:     weight,
+				OneRepMax:  oneRepMax,
+				Source:     source,
+			}
+			changed = true
+			return tx.Omit("Exercise").Create(&trainingMax).Error
+		}
+
+		trainingMax = existing[0]
+		if source != models.TrainingMaxEstimate {
+			trainingMax.Weight = weight
+			trainingMax.OneRepMax = oneRepMax
+		} else if oneRepMax > trainingMax.OneRepMax {
+			trainingMax.Weight = max(trainingMax.Weight, weight)
+			trainingMax.OneRepMax = oneRepMax
+		} else {
+			return nil
+		}
+		trainingMax.Source = source
+		changed = true
+		return tx.Model(&trainingMax).Updates(map[string]any{
+			"weight":      trainingMax.Weight,
+			"one_rep_max": trainingMax.OneRepMax,
+			"source":      trainingMax.Source,
+			"updated_at":  time.Now(),
+		}).Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":     userID,
+			"exercise_id": exerciseID,
+			"source":      source,
+			"error":       err,
+		}).Error("Failed to record one-rep max")
+		return nil, false, err
+	}
+	return &trainingMax, changed, nil
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func CreateProgressPhoto(photo *models.ProgressPhoto, db *gorm.DB) error {
+	err := db.Create(photo).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":    photo.UserID,
+			"object_key": photo.ObjectKey,
+			"error":      err,
+		}).Error("Failed to create progress photo")
+	}
+	return err
+}
+
+// ListProgressPhotoDates returns the days the user took photos on, newest
+// first, and the total number of such days. An empty pose matches any pose;
+// a non-zero after keeps only later days.
+func ListProgressPhotoDates(
+	userID uuid.UUID,
+	pose string,
+	after time.Time,
+	offset int,
+	limit int,
+	db *gorm.DB,
+) ([]time.Time, int, error) {
+	query := db.Model(&models.ProgressPhoto{}).Where("user_id = ?", userID)
+	if pose != "" {
+		query = query.Where("pose = ?", pose)
+	}
+	if !after.IsZero() {
+		query = query.Where("taken_on > ?", after)
+	}
+
+	var total int64
+	err := query.Session(&gorm.Session{}).Distinct("taken_on").Count(&total).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to count progress photo dates")
+		return nil, 0, err
+	}
+
+	var dates []time.Time
+	err = query.Distinct("taken_on").
+		Order("taken_on DESC").
+		Offset(offset).
+		Limit(limit).
+		Pluck("taken_on", &dates).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list progress photo dates")
+	}
+	return dates, int(total), err
+}
+
+// ListProgressPhotosOn returns the user's photos taken on the given day in
+// upload order.
+func ListProgressPhotosOn(userID uuid.UUID, day time.Time, db *gorm.DB) ([]models.ProgressPhoto, error) {
+	var photos []models.ProgressPhoto
+
+	err := db.Where("user_id = ? AND taken_on = ?", userID, day).
+		Order("created_at").
+		Find(&photos).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list progress photos")
+	}
+	return photos, err
+}
+
+// FindProgressPhoto returns the latest photo of the pose taken on the day.
+func FindProgressPhoto(userID uuid.UUID, pose string, day time.Time, db *gorm.DB) (*models.ProgressPhoto, error) {
+	var photo models.ProgressPhoto
+
+	err := db.Where("user_id = ? AND pose = ? AND taken_on = ?", userID, pose, day).
+		Order("created_at DESC").
+		First(&photo).Error
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+func ListUserProgressPhotos(userID uuid.UUID, db *gorm.DB) ([]models.ProgressPhoto, error) {
+	var photos []models.ProgressPhoto
+
+	err := db.Where("user_id = ?", userID).Find(&photos).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list user progress photos")
+	}
+	return photos, err
+}
+
+// ListProgressPhotosBefore returns up to limit photos of any user taken
+// before the day, oldest first.
+func ListProgressPhotosBefore(day time.Time, limit int, db *gorm.DB) ([]models.ProgressPhoto, error) {
+	var photos []models.ProgressPhoto
+
+	err := db.Where("taken_on < ?", day).
+		Order("taken_on").
+		Limit(limit).
+		Find(&photos).Error
+	if err != nil {
+		logger.WithField("error", err).Error("Failed to list expired progress photos")
+	}
+	return photos, err
+}
+
+func DeleteProgressPhoto(photo *models.ProgressPhoto, db *gorm.DB) error {
+	err := db.Delete(photo).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"photo_id": photo.ID,
+			"error":    err,
+		}).Error("Failed to delete progress photo")
+	}
+	return err
+}
+
+func UpdateProgressPhotoFileID(photo *models.ProgressPhoto, fileID string, db *gorm.DB) error {
+	err := db.Model(photo).Updates(map[string]any{
+		"telegram_file_id": fileID,
+		"updated_at":       time.Now(),
+	}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"photo_id": photo.ID,
+			"error":    err,
+		}).Error("Failed to cache progress photo file ID")
+		return err
+	}
+	photo.TelegramFileID = fileID
+	return nil
+}
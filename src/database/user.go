@@ -6,6 +6,7 @@ import (
 
 	"workouts_bot/src/logger"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -30,6 +31,21 @@ func GetUserByTelegramID(telegramID int64, db *gorm.DB) (*models.User, error) {
 	return &user, nil
 }
 
+// FindUserByTelegramID is GetUserByTelegramID for lookups where a missing
+// user is expected; it returns nil without logging an error.
+func FindUserByTelegramID(telegramID int64, db *gorm.DB) (*models.User, error) {
+	var user models.User
+
+	err := db.Where("telegram_id = ?", telegramID).Limit(1).Find(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	if user.TelegramID == 0 {
+		return nil, nil
+	}
+	return &user, nil
+}
+
 func UpsertUser(user *models.User, db *gorm.DB) error {
 	existingUser, err := GetUserByTelegramID(user.TelegramID, db)
 
@@ -47,6 +63,11 @@ func UpsertUser(user *models.User, db *gorm.DB) error {
 	existingUser.Username = user.Username
 	existingUser.FirstName = user.FirstName
 	existingUser.LastName = user.LastName
+	if user.LanguageCode != "" {
+		existingUser.LanguageCode = user.LanguageCode
+	}
+	// Any update comes from the user talking to the bot, so it is not blocked.
+	existingUser.BlockedAt = nil
 	existingUser.UpdatedAt = time.Now()
 	err = db.Save(&existingUser).Error
 	if err != nil {
@@ -64,3 +85,128 @@ func UpsertUser(user *models.User, db *gorm.DB) error {
 	}).Info("User updated successfully")
 	return nil
 }
+
+func MarkUserBlocked(userID uuid.UUID, db *gorm.DB) error {
+	err := db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("blocked_at", time.Now()).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to mark user as blocked")
+	}
+	return err
+}
+
+func UpdateUserLanguage(telegramID int64, language string, db *gorm.DB) error {
+	err := db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Updates(map[string]any{"language": language, "updated_at": time.Now()}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"telegram_id": telegramID,
+			"language":    language,
+			"error":       err,
+		}).Error("Failed to update user language")
+	}
+	return err
+}
+
+func UpdateUserGoal(telegramID int64, goal string, db *gorm.DB) error {
+	err := db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Updates(map[string]any{"goal": goal, "updated_at": time.Now()}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"telegram_id": telegramID,
+			"goal":        goal,
+			"error":       err,
+		}).Error("Failed to update user goal")
+	}
+	return err
+}
+
+func UpdateUserProgression(telegramID int64, progression string, db *gorm.DB) error {
+	err := db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Updates(map[string]any{"progression": progression, "updated_at": time.Now()}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"telegram_id": telegramID,
+			"progression": progression,
+			"error":       err,
+		}).Error("Failed to update user progression")
+	}
+	return err
+}
+
+func UpdateUserPlateUnit(telegramID int64, unit string, db *gorm.DB) error {
+	err := db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Updates(map[string]any{"plate_unit": unit, "updated_at": time.Now()}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"telegram_id": telegramID,
+			"plate_unit":  unit,
+			"error":       err,
+		}).Error("Failed to update user plate unit")
+	}
+	return err
+}
+
+func UpdateUserPlates(telegramID int64, plates string, db *gorm.DB) error {
+	err := db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Updates(map[string]any{"plates": plates, "updated_at": time.Now()}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"telegram_id": telegramID,
+			"plates":      plates,
+			"error":       err,
+		}).Error("Failed to update user plates")
+	}
+	return err
+}
+
+func UpdateUserWarmups(telegramID int64, warmups bool, db *gorm.DB) error {
+	err := db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Updates(map[string]any{"warmups": warmups, "updated_at": time.Now()}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"telegram_id": telegramID,
+			"warmups":     warmups,
+			"error":       err,
+		}).Error("Failed to update user warm-ups")
+	}
+	return err
+}
+
+func UpdateUserGroupPrivacy(telegramID int64, privacy string, db *gorm.DB) error {
+	err := db.Model(&models.User{}).
+		Where("telegram_id = ?", telegramID).
+		Updates(map[string]any{"group_privacy": privacy, "updated_at": time.Now()}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"telegram_id": telegramID,
+			"privacy":     privacy,
+			"error":       err,
+		}).Error("Failed to update user group privacy")
+	}
+	return err
+}
+
+func GetUserByID(userID uuid.UUID, db *gorm.DB) (*models.User, error) {
+	var user models.User
+
+	err := db.First(&user, "id = ?", userID).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to get user by ID")
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,360 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StartWorkoutSession creates a session with the template's exercises and
+// targets. The template must be loaded with its exercises.
+func StartWorkoutSession(template *models.WorkoutTemplate, db *gorm.DB) (*models.WorkoutSession, error) {
+	templateID := template.ID
+	session := &models.WorkoutSession{
+		UserID:     template.UserID,
+		TemplateID: &templateID,
+		Name:       template.Name,
+	}
+	for _, item := range template.Exercises {
+		session.Exercises = append(session.Exercises, models.SessionExercise{
+			ExerciseID:   item.ExerciseID,
+			Position:     item.Position,
+			TargetSets:   item.TargetSets,
+			TargetReps:   item.TargetReps,
+			TargetWeight: item.TargetWeight,
+			RestSeconds:  item.RestSeconds,
+			WorkSeconds:  item.WorkSeconds,
+			Linked:       item.Linked,
+		})
+	}
+
+	if err := StartSession(session, db); err != nil {
+		logger.WithFields(logrus.Fields{
+			"template_id": template.ID,
+			"user_id":     template.UserID,
+			"error":       err,
+		}).Error("Failed to start workout session")
+		return nil, err
+	}
+	return session, nil
+}
+
+// StartSession creates the session together with its exercises.
+func StartSession(session *models.WorkoutSession, db *gorm.DB) error {
+	session.ID = uuid.New()
+	session.StartedAt = time.Now()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Exercises").Create(session).Error; err != nil {
+			return err
+		}
+		for i := range session.Exercises {
+			exercise := &session.Exercises[i]
+			exercise.SessionID = session.ID
+			if err := tx.Omit("Exercise", "Sets").Create(exercise).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetWorkoutSession loads the user's session with exercises and sets in order.
+func GetWorkoutSession(sessionID uuid.UUID, userID uuid.UUID, db *gorm.DB) (*models.WorkoutSession, error) {
+	var session models.WorkoutSession
+
+	err := preloadSession(db).
+		Where("id = ? AND user_id = ?", sessionID, userID).
+		First(&session).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_id": sessionID,
+			"user_id":    userID,
+			"error":      err,
+		}).Error("Failed to get workout session")
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindActiveSession returns the user's unfinished session, or nil if there
+// is none.
+func FindActiveSession(userID uuid.UUID, db *gorm.DB) (*models.WorkoutSession, error) {
+	var sessions []models.WorkoutSession
+
+	err := preloadSession(db).
+		Where("user_id = ? AND finished_at IS NULL", userID).
+		Limit(1).
+		Find(&sessions).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to find active workout session")
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return &sessions[0], nil
+}
+
+// GetSessionExercise loads the session that contains the exercise and
+// returns the exercise from it, with its sets.
+func GetSessionExercise(
+	sessionExerciseID uuid.UUID,
+	userID uuid.UUID,
+	db *gorm.DB,
+) (*models.SessionExercise, *models.WorkoutSession, error) {
+	var exercise models.SessionExercise
+
+	err := db.Where("id = ?", sessionExerciseID).First(&exercise).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_exercise_id": sessionExerciseID,
+			"error":               err,
+		}).Error("Failed to get session exercise")
+		return nil, nil, err
+	}
+
+	session, err := GetWorkoutSession(exercise.SessionID, userID, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range session.Exercises {
+		if session.Exercises[i].ID == sessionExerciseID {
+			return &session.Exercises[i], session, nil
+		}
+	}
+	return nil, nil, gorm.ErrRecordNotFound
+}
+
+// AddSessionExercise appends the exercise to the end of the session.
+func AddSessionExercise(exercise *models.SessionExercise, db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&models.SessionExercise{}).
+			Where("session_id = ?", exercise.SessionID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		exercise.Position = last + 1
+		if err := tx.Omit("Exercise", "Sets").Create(exercise).Error; err != nil {
+			logger.WithFields(logrus.Fields{
+				"session_id":  exercise.SessionID,
+				"exercise_id": exercise.ExerciseID,
+				"error":       err,
+			}).Error("Failed to add exercise to session")
+			return err
+		}
+		return nil
+	})
+}
+
+// LogSessionSet records the next set of the exercise. It reports whether
+// the set was logged by this call rather than by a concurrent one.
+func LogSessionSet(exercise *models.SessionExercise, set *models.SessionSet, db *gorm.DB) (bool, error) {
+	set.SessionExerciseID = exercise.ID
+	set.Number = exercise.Logged() + 1
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(set)
+	if result.Error != nil {
+		logger.WithFields(logrus.Fields{
+			"session_exercise_id": exercise.ID,
+			"error":               result.Error,
+		}).Error("Failed to log session set")
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	exercise.Sets = append(exercise.Sets, *set)
+	return true, nil
+}
+
+// LogSubSet records a drop set or a rest-pause set that extends parent.
+func LogSubSet(
+	exercise *models.SessionExercise,
+	parent *models.SessionSet,
+	set *models.SessionSet,
+	db *gorm.DB,
+) error {
+	parentID := parent.ID
+	set.SessionExerciseID = exercise.ID
+	set.Number = parent.Number
+	set.ParentID = &parentID
+
+	err := db.Create(set).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_exercise_id": exercise.ID,
+			"parent_id":           parent.ID,
+			"kind":                set.Kind,
+			"error":               err,
+		}).Error("Failed to log sub-set")
+		return err
+	}
+	exercise.Sets = append(exercise.Sets, *set)
+	return nil
+}
+
+// FinishWorkoutSession marks the session finished. It reports whether this
+// call finished it; a session finished already is left as it is.
+func FinishWorkoutSession(session *models.WorkoutSession, db *gorm.DB) (bool, error) {
+	now := time.Now()
+	result := db.Model(session).
+		Where("finished_at IS NULL").
+		Updates(map[string]any{
+			"finished_at": now,
+			"updated_at":  now,
+		})
+	if result.Error != nil {
+		logger.WithFields(logrus.Fields{
+			"session_id": session.ID,
+			"error":      result.Error,
+		}).Error("Failed to finish workout session")
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	session.FinishedAt = &now
+	return true, nil
+}
+
+func preloadSession(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Exercises", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("position")
+		}).
+		Preload("Exercises.Exercise").
+		Preload("Exercises.Sets", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("number, created_at")
+		})
+}
+
+// ListExerciseHistory returns the exercise as performed in the user's
+// finished sessions, newest first, with sets.
+func ListExerciseHistory(
+	userID uuid.UUID,
+	exerciseID uuid.UUID,
+	limit int,
+	db *gorm.DB,
+) ([]models.SessionExercise, error) {
+	var history []models.SessionExercise
+
+	err := db.
+		Joins("JOIN workouts.workout_sessions ON workout_sessions.id = session_exercises.session_id").
+		Where("workout_sessions.user_id = ? AND workout_sessions.finished_at IS NOT NULL", userID).
+		Where("session_exercises.exercise_id = ?", exerciseID).
+		Order("workout_sessions.started_at DESC").
+		Limit(limit).
+		Preload("Sets", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("number, created_at")
+		}).
+		Find(&history).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":     userID,
+			"exercise_id": exerciseID,
+			"error":       err,
+		}).Error("Failed to list exercise history")
+	}
+	return history, err
+}
+
+// UpdateSessionExerciseTargets replaces the exercise's targets for the rest
+// of the session. Warm-ups for the old targets are dropped.
+func UpdateSessionExerciseTargets(
+	exercise *models.SessionExercise,
+	sets int,
+	reps int,
+	weight float64,
+	db *gorm.DB,
+) error {
+	err := db.Model(exercise).Updates(map[string]any{
+		"target_sets":   sets,
+		"target_reps":   reps,
+		"target_weight": weight,
+		"warmup":        gorm.Expr("NULL"),
+		"updated_at":    time.Now(),
+	}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_exercise_id": exercise.ID,
+			"error":               err,
+		}).Error("Failed to update session exercise targets")
+		return err
+	}
+	exercise.TargetSets = sets
+	exercise.TargetReps = reps
+	exercise.TargetWeight = weight
+	exercise.Warmup = nil
+	return nil
+}
+
+// UpdateSessionExerciseWarmup stores the warm-up sets planned for the
+// exercise; an empty list records that it needs none.
+func UpdateSessionExerciseWarmup(
+	exercise *models.SessionExercise,
+	warmup []models.PlannedSet,
+	db *gorm.DB,
+) error {
+	err := db.Model(exercise).
+		Select("warmup", "updated_at").
+		Updates(&models.SessionExercise{Warmup: warmup, UpdatedAt: time.Now()}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"session_exercise_id": exercise.ID,
+			"error":               err,
+		}).Error("Failed to update session exercise warm-up")
+		return err
+	}
+	exercise.Warmup = warmup
+	return nil
+}
+
+// ListFinishedSessions returns the user's sessions finished after starting
+// on or after since, with exercises and sets.
+func ListFinishedSessions(userID uuid.UUID, since time.Time, db *gorm.DB) ([]models.WorkoutSession, error) {
+	var sessions []models.WorkoutSession
+
+	err := preloadSession(db).
+		Where("user_id = ? AND started_at >= ? AND finished_at IS NOT NULL", userID, since).
+		Order("started_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list finished sessions")
+	}
+	return sessions, err
+}
+
+// ResetStats deletes the user's finished sessions and cardio activities,
+// which the statistics are totalled from. A session in progress is kept.
+func ResetStats(userID uuid.UUID, db *gorm.DB) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.CardioEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND finished_at IS NOT NULL", userID).
+			Delete(&models.WorkoutSession{}).Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to reset stats")
+	}
+	return err
+}
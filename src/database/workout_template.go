@@ -0,0 +1,283 @@
+package database
+
+import (
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func CreateWorkoutTemplate(template *models.WorkoutTemplate, db *gorm.DB) error {
+	err := db.Create(template).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": template.UserID,
+			"error":   err,
+		}).Error("Failed to create workout template")
+	}
+	return err
+}
+
+func ListWorkoutTemplates(userID uuid.UUID, db *gorm.DB) ([]models.WorkoutTemplate, error) {
+	var templates []models.WorkoutTemplate
+
+	err := db.Where("user_id = ?", userID).Order("created_at").Find(&templates).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err,
+		}).Error("Failed to list workout templates")
+	}
+	return templates, err
+}
+
+// GetWorkoutTemplate loads the user's template with its exercises in order.
+func GetWorkoutTemplate(templateID uuid.UUID, userID uuid.UUID, db *gorm.DB) (*models.WorkoutTemplate, error) {
+	var template models.WorkoutTemplate
+
+	err := db.Where("id = ? AND user_id = ?", templateID, userID).
+		Preload("Exercises", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("position")
+		}).
+		Preload("Exercises.Exercise").
+		First(&template).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"template_id": templateID,
+			"user_id":     userID,
+			"error":       err,
+		}).Error("Failed to get workout template")
+		return nil, err
+	}
+	return &template, nil
+}
+
+func RenameWorkoutTemplate(template *models.WorkoutTemplate, name string, db *gorm.DB) error {
+	err := db.Model(template).Updates(map[string]any{
+		"name":       name,
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"template_id": template.ID,
+			"error":       err,
+		}).Error("Failed to rename workout template")
+	}
+	return err
+}
+
+func DeleteWorkoutTemplate(templateID uuid.UUID, userID uuid.UUID, db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", templateID, userID).
+			Delete(&models.WorkoutTemplate{})
+		if result.Error != nil {
+			logger.WithFields(logrus.Fields{
+				"template_id": templateID,
+				"error":       result.Error,
+			}).Error("Failed to delete workout template")
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("template_id = ?", templateID).Delete(&models.TemplateExercise{}).Error
+	})
+}
+
+// DuplicateWorkoutTemplate copies the template and its exercises under a
+// new name and returns the copy.
+func DuplicateWorkoutTemplate(
+	template *models.WorkoutTemplate,
+	name string,
+	db *gorm.DB,
+) (*models.WorkoutTemplate, error) {
+	duplicate := &models.WorkoutTemplate{
+		ID:     uuid.New(),
+		UserID: template.UserID,
+		Name:   name,
+		Type:   template.Type,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(duplicate).Error; err != nil {
+			return err
+		}
+		for _, item := range template.Exercises {
+			copied := models.TemplateExercise{
+				TemplateID:   duplicate.ID,
+				ExerciseID:   item.ExerciseID,
+				Position:     item.Position,
+				TargetSets:   item.TargetSets,
+				TargetReps:   item.TargetReps,
+				TargetWeight: item.TargetWeight,
+				RestSeconds:  item.RestSeconds,
+				WorkSeconds:  item.WorkSeconds,
+				Linked:       item.Linked,
+			}
+			if err := tx.Omit("Exercise").Create(&copied).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"template_id": template.ID,
+			"error":       err,
+		}).Error("Failed to duplicate workout template")
+		return nil, err
+	}
+	return duplicate, nil
+}
+
+// AddTemplateExercise appends the exercise to the end of the template.
+func AddTemplateExercise(item *models.TemplateExercise, db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&models.TemplateExercise{}).
+			Where("template_id = ?", item.TemplateID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		item.Position = last + 1
+		if err := tx.Omit("Exercise").Create(item).Error; err != nil {
+			logger.WithFields(logrus.Fields{
+				"template_id": item.TemplateID,
+				"exercise_id": item.ExerciseID,
+				"error":       err,
+			}).Error("Failed to add exercise to template")
+			return err
+		}
+		return tx.Model(&models.WorkoutTemplate{}).
+			Where("id = ?", item.TemplateID).
+			Update("updated_at", time.Now()).Error
+	})
+}
+
+// GetTemplateExercise loads a template item together with its template so
+// callers can check ownership.
+func GetTemplateExercise(
+	itemID uuid.UUID,
+	userID uuid.UUID,
+	db *gorm.DB,
+) (*models.TemplateExercise, *models.WorkoutTemplate, error) {
+	var item models.TemplateExercise
+
+	err := db.Where("id = ?", itemID).Preload("Exercise").First(&item).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"item_id": itemID,
+			"error":   err,
+		}).Error("Failed to get template exercise")
+		return nil, nil, err
+	}
+
+	template, err := GetWorkoutTemplate(item.TemplateID, userID, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &item, template, nil
+}
+
+func UpdateTemplateExercise(item *models.TemplateExercise, db *gorm.DB) error {
+	item.UpdatedAt = time.Now()
+	err := db.Omit("Exercise").Save(item).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"item_id": item.ID,
+			"error":   err,
+		}).Error("Failed to update template exercise")
+	}
+	return err
+}
+
+// MoveTemplateExercise swaps the item with its neighbour; delta is -1 to
+// move it up and +1 to move it down. Moving past either end is a no-op.
+func MoveTemplateExercise(template *models.WorkoutTemplate, itemID uuid.UUID, delta int, db *gorm.DB) error {
+	index := -1
+	for i, item := range template.Exercises {
+		if item.ID == itemID {
+			index = i
+			break
+		}
+	}
+	target := index + delta
+	if index < 0 || target < 0 || target >= len(template.Exercises) {
+		return nil
+	}
+
+	current := template.Exercises[index]
+	neighbour := template.Exercises[target]
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.TemplateExercise{}).
+			Where("id = ?", current.ID).
+			Update("position", neighbour.Position).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.TemplateExercise{}).
+			Where("id = ?", neighbour.ID).
+			Update("position", current.Position).Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"template_id": template.ID,
+			"item_id":     itemID,
+			"error":       err,
+		}).Error("Failed to move template exercise")
+	}
+	return err
+}
+
+// RemoveTemplateExercise deletes the item and closes the gap in positions.
+func RemoveTemplateExercise(item *models.TemplateExercise, db *gorm.DB) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.TemplateExercise{}, "id = ?", item.ID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.TemplateExercise{}).
+			Where("template_id = ? AND position > ?", item.TemplateID, item.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"item_id": item.ID,
+			"error":   err,
+		}).Error("Failed to remove template exercise")
+	}
+	return err
+}
+
+// UpdateTemplateTargets sets the targets of the exercise wherever it appears
+// in the template, so the next session starts from them.
+func UpdateTemplateTargets(
+	templateID uuid.UUID,
+	exerciseID uuid.UUID,
+	sets int,
+	reps int,
+	weight float64,
+	db *gorm.DB,
+) error {
+	err := db.Model(&models.TemplateExercise{}).
+		Where("template_id = ? AND exercise_id = ?", templateID, exerciseID).
+		Updates(map[string]any{
+			"target_sets":   sets,
+			"target_reps":   reps,
+			"target_weight": weight,
+			"updated_at":    time.Now(),
+		}).Error
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"template_id": templateID,
+			"exercise_id": exerciseID,
+			"error":       err,
+		}).Error("Failed to update template targets")
+	}
+	return err
+}
@@ -0,0 +1,635 @@
+package i18n
+
+var en = map[string]string{
+	// Buttons
+	"button.workout_start":          "▶️ Start",
+	"button.workout_edit":           "✏️ Edit",
+	"button.workout_delete":         "🗑️ Delete",
+	"button.workout_refresh":        "🔄 Refresh",
+	"button.workout_stats":          "📊 Statistics",
+	"button.stats_reset":            "🗑️ Reset statistics",
+	"button.exercise_details":       "📖 Details",
+	"button.exercise_video":         "🎥 Video",
+	"button.exercise_add":           "➕ Add to workout",
+	"button.set_complete":           "✅ Complete set",
+	"button.set_skip":               "⏭️ Skip",
+	"button.set_pause":              "⏸️ Pause",
+	"button.goal_muscle_gain":       "💪 Muscle gain",
+	"button.goal_strength":          "🏋️ Strength",
+	"button.goal_endurance":         "🏃 Endurance",
+	"button.goal_weight_loss":       "🔥 Weight loss",
+	"button.equipment_home":         "🏠 Home gym",
+	"button.equipment_gym":          "🏋️ Gym",
+	"button.equipment_none":         "🚫 No equipment",
+	"button.equipment_custom":       "⚙️ Customize",
+	"button.workout_type_split":     "🏋️ Classic split",
+	"button.workout_type_push_pull": "🔄 Push/Pull/Legs",
+	"button.workout_type_full_body": "💪 Full body",
+	"button.workout_type_custom":    "🎯 Custom",
+	"button.exercise_compound":      "🏋️ Compound",
+	"button.exercise_isolation":     "🎯 Isolation",
+	"button.exercise_strength":      "💪 Strength training",
+	"button.exercise_cardio":        "🏃 Cardio",
+	"button.exercise_bodyweight":    "🤸 Bodyweight",
+	"button.exercise_hiit":          "⚡ HIIT",
+	"button.exercise_endurance":     "🔄 Endurance training",
+	"button.settings_goals":         "🎯 Goals",
+	"button.settings_equipment":     "🏋️ Equipment",
+	"button.settings_experience":    "📈 Experience level",
+	"button.settings_limitations":   "⚠️ Limitations",
+	"button.exp_beginner":           "🟢 Beginner",
+	"button.exp_intermediate":       "🟡 Intermediate",
+	"button.exp_advanced":           "🟠 Advanced",
+	"button.exp_expert":             "🔴 Expert",
+	"button.muscle_chest":           "💪 Chest",
+	"button.muscle_back":            "🏋️ Back",
+	"button.muscle_shoulders":       "🦾 Shoulders",
+	"button.muscle_biceps":          "💪 Biceps",
+	"button.muscle_triceps":         "💪 Triceps",
+	"button.muscle_legs":            "🦵 Legs",
+	"button.muscle_glutes":          "🍑 Glutes",
+	"button.muscle_abs":             "💪 Abs",
+	"button.duration_30":            "⏰ 30 minutes",
+	"button.duration_45":            "⏰ 45 minutes",
+	"button.duration_60":            "⏰ 60 minutes",
+	"button.duration_90":            "⏰ 90 minutes",
+	"button.nav_main_menu":          "🏠 Main menu",
+	"button.nav_back":               "🔙 Back",
+	"button.nav_yes":                "✅ Yes",
+	"button.nav_no":                 "❌ No",
+	"button.settings":               "⚙️ Settings",
+	"button.settings_language":      "🌐 Language",
+	"button.language_ru":            "🇷🇺 Русский",
+	"button.language_en":            "🇬🇧 English",
+	"button.language_auto":          "🔄 Same as Telegram",
+
+	// Common
+	"error.unknown_command": "Unknown command",
+	"error.invalid_format":  "Invalid command format",
+	"error.user_not_found":  "User not found",
+	"error.user_fetch":      "Failed to load your profile",
+	"callback.outdated":     "⌛ This button is outdated. Please open the menu again.",
+	"menu.main":             "Main menu",
+
+	// Start
+	"start.hello": "Hi! I am a workout bot 🏋️\n\n" +
+		"I will help you:\n" +
+		"• Pick exercises\n" +
+		"• Build a training program\n" +
+		"• Track your progress\n" +
+		"• Log sets and weights\n\n" +
+		"Choose an action:",
+
+	// Settings
+	"settings.title":               "⚙️ Settings",
+	"settings.summary_title":       "⚙️ Your settings:\n\n",
+	"settings.summary_experience":  "📈 Experience level: %s\n",
+	"settings.summary_language":    "🌐 Language: %s\n\n",
+	"settings.summary_hint":        "Use the buttons below to change your settings:",
+	"settings.experience_question": "📈 What is your training experience?",
+	"settings.language_question":   "🌐 Choose the interface language",
+	"error.unknown_setting":        "Unknown setting",
+	"error.invalid_experience":     "Invalid experience level",
+	"error.experience_update":      "Failed to update experience level",
+	"experience.updated":           "✅ Experience level updated!",
+	"error.language_update":        "Failed to update language",
+	"language.updated":             "✅ Language updated",
+	"settings.summary_goal":        "🎯 Goal: %s\n",
+	"settings.goal_question":       "🎯 What is your main goal?",
+	"settings.goal_none":           "not set",
+	"error.goal_update":            "Failed to update goal",
+	"goal.updated":                 "✅ Goal updated! New exercises will be added with matching sets and reps.",
+
+	// Confirmation
+	"confirm.outdated":     "⌛ This confirmation is outdated",
+	"confirm.already_done": "ℹ️ This action has already been handled",
+	"confirm.expired":      "⌛ The confirmation has expired, please try again",
+	"confirm.foreign":      "This confirmation belongs to another user",
+	"confirm.failed":       "❌ Failed to perform the action",
+	"confirm.cancelled":    "🚫 Action cancelled",
+
+	// Broadcast
+	"error.admin_only":           "This command is for administrators only",
+	"broadcast.ask_text":         "📣 Send the broadcast text as a single message.",
+	"error.broadcast_text_only":  "Broadcasts support text only",
+	"error.broadcast_save":       "Failed to save the broadcast",
+	"broadcast.confirm_question": "☝️ This is how users will see the message. Send it to everyone?",
+	"broadcast.starting":         "📣 Starting the broadcast...",
+	"broadcast.progress_title":   "📣 Broadcast in progress",
+	"broadcast.done_title":       "✅ Broadcast completed",
+	"broadcast.failed_title":     "❌ Broadcast stopped: delivery state could not be saved",
+	"broadcast.progress":         "%s: %d/%d\n\n✅ Delivered: %d\n🚫 Blocked the bot: %d\n❌ Errors: %d",
+
+	// Workouts
+	"button.my_workouts":          "🏋️ My workouts",
+	"button.workout_rename":       "✏️ Rename",
+	"button.workout_add_exercise": "➕ Exercise",
+	"button.workout_duplicate":    "📄 Duplicate",
+	"button.item_up":              "⬆️ Up",
+	"button.item_down":            "⬇️ Down",
+	"button.item_targets":         "🎯 Targets",
+	"button.item_remove":          "❌ Remove",
+	"workouts.list_title":         "🏋️ Your workouts:",
+	"workouts.list_empty":         "🏋️ You have no workouts yet. Create your own!",
+	"workouts.ask_name":           "✏️ Enter a name for the new workout",
+	"workouts.ask_rename":         "✏️ Enter a new name for “%s”",
+	"workouts.ask_exercise":       "🔎 Type an exercise name, for example “press” or “squat”",
+	"workouts.exercise_not_found": "Nothing found for “%s”. Try something else.",
+	"workouts.exercise_choose":    "Choose an exercise:",
+	"workouts.ask_targets": "🎯 %s\n\n" +
+		"Send targets as “sets x reps weight rest”, for example: 4x8 60 90\n" +
+		"Weight (kg) and rest (seconds) are optional.\n" +
+		"For intervals send “rounds x work/rest” in seconds, for example: 8x40/20",
+	"workouts.template_title":  "🏋️ %s\n\n",
+	"workouts.template_empty":  "No exercises yet. Tap “✏️ Edit” to add some.",
+	"workouts.edit_hint":       "\nTap an exercise to change its targets or order.",
+	"workouts.item_line":       "%d. %s — %s\n",
+	"workouts.item_title":      "🏋️ %s\n\n%d. %s\n🎯 %s",
+	"workouts.targets":         "%d×%d",
+	"workouts.targets_weight":  " × %s kg",
+	"workouts.targets_rest":    ", rest %d s",
+	"workouts.delete_question": "🗑️ Delete the workout “%s”? This cannot be undone.",
+	"workouts.deleted":         "🗑️ Workout “%s” deleted",
+	"workouts.copy_name":       "%s (copy)",
+	"error.workout_not_found":  "Workout not found",
+	"error.workout_save":       "Failed to save the workout",
+	"error.workout_name":       "The name must be 1 to 64 characters long",
+	"error.targets_format":     "Could not parse the targets. Example: 4x8 60 90",
+
+	// Exercises
+	"button.exercises":           "📚 Exercises",
+	"button.page_prev":           "◀️",
+	"button.page_next":           "▶️",
+	"exercises.categories_title": "📚 Exercise catalog\n\nChoose a category:",
+	"exercises.category_title":   "%s\n\nChoose an exercise:",
+	"exercises.card":             "%s\n\n🏷️ %s\n🎯 %s\n🛠️ %s",
+	"exercises.add_question":     "➕ Where should “%s” go?",
+	"exercises.add_empty":        "➕ You have no workouts yet. Create one under “🏋️ My workouts”.",
+	"exercises.added_template":   "✅ “%s” added to “%s”: %s",
+	"exercises.added_session":    "✅ “%s” added to the current workout: %s",
+	"error.exercise_not_found":   "Exercise not found",
+	"equipment.barbell":          "Barbell",
+	"equipment.dumbbell":         "Dumbbells",
+	"equipment.kettlebell":       "Kettlebell",
+	"equipment.cable":            "Cable machine",
+	"equipment.machine":          "Machine",
+	"equipment.cardio_machine":   "Cardio machine",
+	"equipment.bodyweight":       "Bodyweight",
+	"equipment.none":             "No equipment",
+
+	// Exercise media
+	"button.exercise_upload": "📎 Upload media",
+	"exercises.no_images":    "📖 No technique photos for “%s” yet.",
+	"exercises.no_video":     "🎥 No technique video for “%s” yet.",
+	"media.ask_upload":       "📎 Send photos, GIFs or videos for “%s”. Press any menu button when you are done.",
+	"media.uploaded":         "✅ Saved for “%s”. Send more or press a menu button.",
+	"error.media_type":       "Send a photo, GIF or video",
+	"error.media_upload":     "Failed to upload the file",
+	"error.media_send":       "Failed to send the media",
+	"error.media_disabled":   "Media storage is not configured",
+
+	// Sessions
+	"button.workout_finish":      "🏁 Finish",
+	"sessions.continue":          "▶️ Continue: %s",
+	"sessions.title":             "▶️ %s\n\n",
+	"sessions.empty":             "No exercises yet. Add some from the “📚 Exercises” catalog.",
+	"sessions.line_done":         "✅ %d. %s — %d/%d\n",
+	"sessions.line_current":      "👉 %d. %s — %d/%d\n",
+	"sessions.line_pending":      "▫️ %d. %s — %d/%d\n",
+	"sessions.next_set":          "\n👉 %s\nSet %d of %d: %s",
+	"sessions.set_target_reps":   "%d reps",
+	"sessions.set_target_weight": "%d × %s kg",
+	"sessions.rest":              "\n⏱️ Rest between sets: %d s",
+	"sessions.all_done":          "\n🎉 All sets done! Tap “🏁 Finish”.",
+	"sessions.already_active":    "You already have “%s” in progress. Finish it to start a new one.",
+	"sessions.finished": "🏁 Workout “%s” finished!\n\n" +
+		"⏱️ Duration: %d min\n" +
+		"✅ Sets: %d\n" +
+		"🏋️ Volume: %s kg",
+	"error.session_not_found": "Workout not found or already finished",
+	"error.session_save":      "Failed to save the workout",
+
+	// Progress photos
+	"button.progress":         "📸 Progress",
+	"button.progress_add":     "📷 Add photo",
+	"button.progress_history": "🗂️ History",
+	"button.progress_compare": "🔀 Compare",
+	"button.pose_front":       "Front",
+	"button.pose_side":        "Side",
+	"button.pose_back":        "Back",
+	"format.date":             "Jan 2, 2006",
+	"progress.title":          "📸 Progress photos\n\nPhotos are stored privately and only you can see them.",
+	"progress.choose_pose":    "Choose a pose:",
+	"progress.ask_photo":      "📷 Send a photo: %s.",
+	"progress.saved":          "✅ Photo saved: %s. Add another pose?",
+	"progress.history_title":  "🗂️ Days with photos:",
+	"progress.empty":          "You have no progress photos yet.",
+	"progress.photo_caption":  "%s · %s",
+	"progress.compare_from":   "🔀 %s: choose the first date:",
+	"progress.compare_to":     "🔀 %s, %s: choose the second date:",
+	"progress.compare_empty":  "Comparing needs photos (%s) from at least two days.",
+	"progress.collage":        "%s: %s → %s",
+	"error.progress_photo":    "Send a photo",
+	"error.progress_save":     "Failed to save the photo",
+	"error.progress_send":     "Failed to send the photos",
+
+	// Measurements
+	"button.progress_measurements":   "📏 Measurements",
+	"measurements.kind_weight":       "Weight",
+	"measurements.kind_body_fat":     "Body fat",
+	"measurements.kind_waist":        "Waist",
+	"measurements.kind_chest":        "Chest",
+	"measurements.kind_arms":         "Arms",
+	"measurements.kind_thighs":       "Thighs",
+	"measurements.unit_weight":       "%s kg",
+	"measurements.unit_body_fat":     "%s%%",
+	"measurements.unit_waist":        "%s cm",
+	"measurements.unit_chest":        "%s cm",
+	"measurements.unit_arms":         "%s cm",
+	"measurements.unit_thighs":       "%s cm",
+	"measurements.title":             "📏 Measurements\n",
+	"measurements.empty":             "\nNo measurements yet.",
+	"measurements.line":              "\n%s: %s (%s)",
+	"measurements.hint":              "\n\nQuick entry: /weight 82.5, /bodyfat 18, /waist 84, /chest, /arms, /thighs. A command without a number shows the history.",
+	"measurements.trend_up":          "\n📈 7-day average %s, %s per week",
+	"measurements.trend_down":        "\n📉 7-day average %s, %s per week",
+	"measurements.trend_flat":        "\n➡️ 7-day average %s, %s per week",
+	"measurements.verdict_on_track":  "\n✅ Heading towards your goal",
+	"measurements.verdict_off_track": "\n⚠️ Moving away from your goal",
+	"measurements.verdict_stalled":   "\n⏸️ Progress has stalled",
+	"measurements.saved":             "✅ %s: %s saved",
+	"measurements.history_title":     "📏 %s: history\n",
+	"measurements.history_empty":     "\nNo entries yet.",
+	"measurements.history_line":      "\n%s: %s (average %s)",
+	"error.measurement_value":        "Enter a number from %s to %s",
+	"error.measurement_save":         "Failed to save the measurement",
+	"error.measurement_load":         "Failed to load measurements",
+
+	// Progression
+	"button.set_other":            "✏️ Other",
+	"button.set_accept":           "✅ Accept recommendation",
+	"button.settings_progression": "📈 Progression",
+	"button.settings_calendar":    "📅 Calendar",
+	"button.settings_groups":      "👥 Groups",
+	"button.progression_linear":   "📈 Linear",
+	"button.progression_double":   "🔁 Double progression",
+	"button.progression_rpe":      "🎚️ By RPE",
+	"sessions.rpe":                "RPE %s",
+	"sessions.ask_set":            "✏️ Send the set as “reps weight @RPE”, for example “8 60 @8”. Weight and RPE are optional.",
+	"error.set_format":            "❌ Could not read the set. Example: “8 60 @8”.",
+	"error.progression_update":    "Failed to update progression scheme",
+	"settings.progression_question": "📈 How should the bot suggest your next targets?\n\n" +
+		"📈 Linear — add weight every time all sets are completed.\n" +
+		"🔁 Double progression — add reps up to the top of the range, then add weight.\n" +
+		"🎚️ By RPE — adjust weight based on how hard the last sets felt.",
+	"settings.summary_progression":  "📈 Progression: %s\n",
+	"progression.updated":           "✅ Progression scheme updated!",
+	"progression.recommendation":    "\n\n💡 %s: %s → %s",
+	"progression.scheme_linear":     "Linear",
+	"progression.scheme_double":     "Double progression",
+	"progression.scheme_rpe":        "By RPE",
+	"progression.action_deload":     "deload",
+	"progression.action_hold":       "repeat",
+	"progression.action_add_reps":   "add reps",
+	"progression.action_add_weight": "add weight",
+	"progression.last":              "\nLast time: %s",
+	"progression.last_weight":       "%s × %s kg",
+
+	// Programs
+	"button.programs":        "📅 Programs",
+	"button.program_current": "▶️ My program: %s",
+	"button.program_enroll":  "✅ Enroll",
+	"button.program_start":   "🏋️ Start next day",
+	"button.program_maxes":   "🎯 Training maxes",
+	"button.program_leave":   "🚪 Leave program",
+	"error.program_load":     "Failed to load the program",
+	"error.program_save":     "Failed to save the program",
+	"error.training_max":     "❌ Send the training max in kg, a number from 1 to %d, or a tested set such as “120x5”.",
+	"sessions.set_amrap":     " — as many reps as possible",
+	"programs.list": "📅 Programs\n\n" +
+		"Proven periodized programs. The bot computes every day's weights from your training maxes " +
+		"and moves you through weeks and cycles as you finish workouts.",
+	"programs.title":           "📅 %s\n\n",
+	"programs.schedule":        "\n\n🗓 Weeks per cycle: %d, workouts per week: %d",
+	"programs.maxes_needed":    "\n🎯 Training maxes: %s",
+	"programs.replace_warning": "\n\n⚠️ You follow “%s” now. Enrolling replaces it and starts from day one.",
+	"programs.position":        "Cycle %d · week %d of %d",
+	"programs.deload":          " · deload",
+	"programs.next_day":        "\n\nNext: %s\n",
+	"programs.lift_line":       "• %s: %s\n",
+	"programs.reps":            "%d",
+	"programs.reps_amrap":      "%d+",
+	"programs.sets":            "%d × %s",
+	"programs.sets_weight":     "%d × %s × %s kg",
+	"programs.session_name":    "%s — %s",
+	"programs.ask_max": "🎯 Send your training max for “%s” in kg.\n\n" +
+		"It is about 90%% of the weight you can lift once. " +
+		"You can also send a set you did to failure, such as “120x5”, and the bot will work it out.",
+	"programs.max":                    "%s: %s kg",
+	"programs.max_missing":            "%s: not set",
+	"programs.max_saved":              "✅ Training max saved. %s",
+	"programs.maxes":                  "🎯 Training maxes\n\nTap an exercise to change its training max.",
+	"programs.advanced":               "\n\n📅 Next program day: %s",
+	"programs.cycle_done":             "\n\n🎉 Cycle complete! Training maxes went up, cycle %d begins.",
+	"programs.leave_question":         "Leave “%s”? Your place in the program will be lost; training maxes are kept.",
+	"programs.left":                   "🚪 You left “%s”.",
+	"programs.not_enrolled":           "You do not follow a program.",
+	"programs.name_531":               "5/3/1",
+	"programs.name_gzclp":             "GZCLP",
+	"programs.name_ppl":               "Push / Pull / Legs",
+	"programs.name_starting_strength": "Starting Strength",
+	"programs.about_531": "Jim Wendler's 5/3/1 with Boring But Big: one main lift a day in a four week wave " +
+		"of fives, threes and 5/3/1 with AMRAP top sets, then a deload. 5×10 at 50% follows the main lift.",
+	"programs.about_gzclp": "Cody Lefever's GZCL linear progression: heavy T1 triples with an AMRAP last set, " +
+		"T2 sets of ten and light T3 accessories, rotating over four days.",
+	"programs.about_ppl": "Push, pull and legs days with a three week wave on the main lifts, heavier every week " +
+		"with an AMRAP set in the third, then a deload.",
+	"programs.about_starting_strength": "Mark Rippetoe's novice program: workouts A and B in turn, " +
+		"three sets of five at the working weight. Your training max is the working weight itself.",
+	"programs.day_press":    "Press",
+	"programs.day_deadlift": "Deadlift",
+	"programs.day_bench":    "Bench",
+	"programs.day_squat":    "Squat",
+	"programs.day_a1":       "A1",
+	"programs.day_b1":       "B1",
+	"programs.day_a2":       "A2",
+	"programs.day_b2":       "B2",
+	"programs.day_push":     "Push",
+	"programs.day_pull":     "Pull",
+	"programs.day_legs":     "Legs",
+	"programs.day_a":        "Workout A",
+	"programs.day_b":        "Workout B",
+
+	// One-rep max
+	"button.exercise_max":    "🎯 1RM",
+	"error.onerm_format":     "❌ Send the set as “/1rm 100x5”, optionally with RPE: “/1rm 100x5 @8”. Up to %d reps.",
+	"onerm.title":            "🏋️ %s kg × %d\n\nEstimated 1RM:\n",
+	"onerm.title_rpe":        "🏋️ %s kg × %d @ RPE %s\n\nEstimated 1RM:\n",
+	"onerm.formula_line":     "• %s: %s kg\n",
+	"onerm.formula_epley":    "Epley",
+	"onerm.formula_brzycki":  "Brzycki",
+	"onerm.formula_lombardi": "Lombardi",
+	"onerm.formula_rpe":      "RPE table",
+	"onerm.average":          "\nAverage: %s kg · training max (90%%): %s kg\n",
+	"onerm.table_title":      "\n📊 Percentages of 1RM:\n",
+	"onerm.table_line":       "%d%% — %s kg · ≈%d reps\n",
+	"onerm.maxes_title":      "🎯 Training maxes\n\n",
+	"onerm.maxes_empty":      "🎯 No training maxes yet. Set them from an exercise card or a program.\n",
+	"onerm.maxes_line":       "• %s: TM %s kg · 1RM %s (%s)\n",
+	"onerm.weight":           "%s kg",
+	"onerm.unknown":          "unknown",
+	"onerm.source_manual":    "entered",
+	"onerm.source_test":      "tested",
+	"onerm.source_estimate":  "from workouts",
+	"onerm.usage":            "\nSend “/1rm 100x5” to estimate your 1RM from a set and get a table of percentages. Add “@8” for the RPE.",
+	"onerm.records":          "\n\n🏆 New estimated 1RM:\n%s",
+	"onerm.record_line":      "• %s: %s kg",
+
+	// Warm-ups and equipment
+	"sessions.next_warmup":         "\n👉 %s\n🔥 Warm-up %d of %d: %s",
+	"sessions.target_weight_lb":    "%d × %s lb",
+	"settings.summary_equipment":   "🏋️ Plates: %s, warm-ups %s\n",
+	"equipment.title":              "🏋️ Equipment\n\nUnit: %s\nPlates: %s\nBar: %s %s\nDumbbell step: %s %s\nWarm-ups: %s\n\nWarm-up sets before heavy compound lifts are rounded to these plates and dumbbells and do not count towards volume.",
+	"equipment.unit_kg":            "kg",
+	"equipment.unit_lb":            "lb",
+	"equipment.plates_standard":    "%s (standard)",
+	"equipment.warmups_on":         "on",
+	"equipment.warmups_off":        "off",
+	"equipment.ask_plates":         "✏️ Send the plate sizes you have in %s separated by spaces, for example “20 10 5 2.5 1.25”. Send 0 to use the standard set.",
+	"button.equipment_plates":      "✏️ Edit plates",
+	"button.equipment_warmups_on":  "🔥 Turn warm-ups on",
+	"button.equipment_warmups_off": "🚫 Turn warm-ups off",
+	"error.plates_format":          "Could not read the plates. Send up to 10 sizes separated by spaces, for example “20 10 5 2.5”.",
+	"error.equipment_update":       "Failed to update equipment settings",
+
+	// Supersets, drop sets and intervals
+	"button.set_drop":              "⬇️ Drop set",
+	"button.set_rest_pause":        "⏸ Rest-pause",
+	"button.item_link":             "🔗 Superset with next",
+	"button.item_unlink":           "✂️ Unlink from next",
+	"sessions.line_linked":         "   🔗\n",
+	"sessions.superset":            "\n🔗 Superset, round %d of %d",
+	"sessions.circuit":             "\n🔁 Circuit, round %d of %d",
+	"sessions.rest_next":           "\n➡️ Then straight to: %s",
+	"sessions.next_interval":       "\n👉 %s\n⏱️ Round %d of %d: %s",
+	"sessions.set_target_interval": "%d s work, then %d s rest",
+	"sessions.extended_set":        "\n📝 %s: %s\n",
+	"sessions.sub_drop":            " ↘️ %s",
+	"sessions.sub_rest_pause":      " ⏸ %s",
+	"sessions.ask_drop":            "⬇️ Drop set for %s. Send the reps and weight, for example “8 50”. Without a weight it is %s kg.",
+	"sessions.ask_rest_pause":      "⏸ Rest-pause for %s: rest 15–20 seconds, then send the reps. The weight stays %s kg unless you type another one.",
+	"workouts.intervals":           "%d × %d s work / %d s rest",
+	"workouts.item_linked":         "   🔗\n",
+	"workouts.item_linked_next":    "\n🔗 Superset with the next exercise",
+	"error.link_last":              "There is no exercise after this one to link with.",
+
+	// Cardio and statistics
+	"button.set_activity":              "📝 Log activity",
+	"button.import_skip":               "⏭ Don't import",
+	"button.export_csv":                "📄 CSV (zip)",
+	"button.export_json":               "🧾 JSON",
+	"button.export_xlsx":               "📊 Excel",
+	"button.export_photos":             "📸 Progress photos (zip)",
+	"button.account_delete":            "🗑 Delete everything",
+	"button.calendar_enable":           "📅 Get a calendar link",
+	"button.calendar_rotate":           "🔄 New link",
+	"button.calendar_revoke":           "🚫 Turn off",
+	"button.group_privacy_public":      "Show my name and numbers",
+	"button.group_privacy_hide_volume": "Show my name, hide volume",
+	"button.group_privacy_anonymous":   "Stay anonymous",
+	"button.challenge_join":            "🙋 Join: %s",
+	"button.challenge_start":           "🚀 Start: %s",
+	"button.coach_invite":              "🔗 New invite link",
+	"button.coach_assign_program":      "📋 Assign a program",
+	"button.coach_remove_client":       "🚫 Remove client",
+	"button.coach_comment":             "💬 Comment",
+	"button.coach_clients":             "👥 All clients",
+	"button.coach_leave":               "🚫 Stop sharing with my coach",
+	"button.coach_open_program":        "📋 Open the program",
+	"button.coach_open_session":        "🔎 Open the workout",
+	"button.coach_open_client":         "👤 Open the client",
+	"sessions.next_activity":           "\n👉 %s\nLog the duration and distance when you are done.",
+	"cardio.title":                     "🏃 Cardio\n\n",
+	"cardio.empty":                     "No activities yet.\n",
+	"cardio.line":                      "%s — %s\n",
+	"cardio.choose":                    "\nChoose an activity to log:",
+	"cardio.ask_entry":                 "🏃 %s\n\nSend “duration distance heart rate @effort”, for example “25:30 5 148 @7”.\nDuration is minutes or mm:ss, distance is in km (0 if not measured); heart rate and effort from 1 to 10 are optional.",
+	"cardio.ask_session":               "🏃 Send “duration distance heart rate @effort”, for example “25:30 5 148 @7”. Distance is in km; heart rate and effort are optional.",
+	"cardio.saved":                     "✅ Saved: %s",
+	"cardio.meters":                    "%d m",
+	"cardio.km":                        "%s km",
+	"cardio.per_km":                    "km",
+	"cardio.pace":                      "%s /%s",
+	"cardio.speed":                     "%s km/h",
+	"cardio.heart_rate":                "❤️ %d",
+	"cardio.effort":                    "effort %d/10",
+	"stats.title":                      "📊 Statistics by week\n",
+	"stats.week":                       "\n📅 Week of %s\n",
+	"stats.week_empty":                 "No training\n",
+	"stats.sessions":                   "🏋️ Workouts: %d, volume %s kg\n",
+	"stats.cardio":                     "🏃 Cardio: %d, %s, %s\n",
+	"stats.reset_question":             "🗑️ Reset your statistics? All finished workouts and cardio activities will be deleted. This cannot be undone.",
+	"stats.reset_done":                 "🗑️ Statistics reset",
+	"error.cardio_format":              "Could not read the activity. Send it like “25:30 5 148 @7”.",
+	"error.cardio_load":                "Failed to load activities",
+	"error.cardio_save":                "Failed to save the activity",
+	"error.stats_load":                 "Failed to load statistics",
+	"error.stats_reset":                "Failed to reset statistics",
+
+	// Activity import
+	"import.help":               "📥 Send a GPX, TCX or FIT file from your watch or app and I will save the workout as a cardio activity.\n\nTo bring over your history from Strong, Hevy or FitNotes, send their CSV export. Importing the same file again adds nothing twice.",
+	"import.saved":              "✅ Activity imported\n\n%s",
+	"import.duplicate":          "This activity is already saved: %s",
+	"import.summary_title":      "🏃 %s\n",
+	"import.summary_date":       "📅 %s\n",
+	"import.summary_time":       "⏱ %s\n",
+	"import.summary_elevation":  "⛰ Elevation gain: %d m\n",
+	"import.summary_heart_rate": "❤️ Heart rate: average %d, max %d\n",
+	"error.import_too_large":    "The file is too large to import",
+	"error.import_format":       "Could not read the file. GPX, TCX and FIT files with a recorded track are supported.",
+	"error.import_failed":       "Failed to import the activity",
+
+	// Workout history import
+	"import.ask_alias":            "Which catalog exercise is “%s”? Names left to match: %d.\nPick one or type a name to search.",
+	"import.choose_alias":         "Choose the exercise for “%s”:",
+	"import.history_done":         "✅ Import from %s finished: %d workouts added, %d already imported.",
+	"import.workout_name":         "Workout from %s",
+	"error.import_history_format": "Could not read the file. CSV exports from Strong, Hevy and FitNotes are supported.",
+	"error.import_expired":        "This import has expired. Send the file again.",
+
+	// Data export
+	"export.choose":       "📤 Export all your data: profile, templates, workouts, sets, records, activities and measurements. Progress photos come as a separate archive. Choose a format:",
+	"export.caption":      "📤 Your data",
+	"export.photos":       "📸 Your progress photos: %d",
+	"error.export_failed": "Failed to export your data",
+
+	// Account deletion
+	"account.delete_ask":          "⚠️ Delete your account? Your profile, templates, workouts, activities, measurements, records and progress photos will be erased for good and cannot be restored.\n\nYou can download all of your data and progress photos first.",
+	"account.deleted":             "✅ Your account and all of its data have been deleted. Send /start if you ever want to begin again.",
+	"error.account_delete_failed": "Failed to start deleting the account",
+
+	// Calendar feed
+	"calendar.name":           "Workouts",
+	"calendar.on":             "📅 Subscribe to this link in Google Calendar, Apple Calendar or Outlook to see your upcoming program workouts and finished sessions:\n\n%s\n\nKeep the link private: anyone who has it can see your workouts. A new link stops the old one from working.",
+	"calendar.active":         "📅 Your calendar feed is on. The link is only shown when it is made; if you no longer have it, get a new link, and the old one stops working.",
+	"calendar.off":            "📅 Your workouts can appear in your calendar app: upcoming program days with their exercises and the sessions you have finished.\n\nGet a private link and subscribe to it from the calendar app.",
+	"error.calendar_disabled": "The calendar feed is not available on this bot",
+	"error.calendar_update":   "Failed to update the calendar link",
+
+	// Achievements
+	"achievements.new":              "\n\n🏅 New achievements:\n%s",
+	"profile.title":                 "👤 %s\n\n",
+	"profile.totals":                "🏋️ Workouts: %d, lifted %.1f t\n",
+	"profile.week_streak":           "🔥 Weeks in a row with %[2]d+ workouts: %[1]d\n",
+	"profile.planned_streak":        "📅 Program days on schedule in a row: %d\n",
+	"profile.badges":                "\n🏅 Achievements: %d of %d\n",
+	"profile.badges_empty":          "None yet — finish a workout to earn the first one.\n",
+	"profile.badge_line":            "%s — %s\n",
+	"profile.next":                  "\n🎯 Next up:\n",
+	"profile.next_line":             "%s: %s / %s\n",
+	"achievement.first_session":     "First workout",
+	"achievement.sessions_10":       "10 workouts",
+	"achievement.sessions_50":       "50 workouts",
+	"achievement.sessions_100":      "100 workouts",
+	"achievement.sessions_500":      "500 workouts",
+	"achievement.volume_10t":        "10 tonnes lifted",
+	"achievement.volume_100t":       "100 tonnes lifted",
+	"achievement.volume_1000t":      "1,000 tonnes lifted",
+	"achievement.bench_100":         "100 kg bench press",
+	"achievement.squat_140":         "140 kg squat",
+	"achievement.deadlift_180":      "180 kg deadlift",
+	"achievement.week_streak_4":     "4 strong weeks in a row",
+	"achievement.week_streak_12":    "12 strong weeks in a row",
+	"achievement.week_streak_52":    "A year of strong weeks",
+	"achievement.planned_streak_10": "10 program days on schedule",
+	"achievement.planned_streak_30": "30 program days on schedule",
+
+	// Group chats
+	"group.welcome":                  "👋 Hi! I keep a weekly leaderboard and run challenges for this group.\n\n/join — take part (start @%s in a private chat first)\n/leaderboard — this week's standings\n/challenge — running challenges and new ones\n/leave — stop taking part\n\nOnly members who joined are shown. Choose how you appear in Settings → Groups in the private chat.",
+	"group.start_first":              "To take part, open @%s in a private chat, press /start and then send /join here.",
+	"group.joined":                   "✅ You are in! Your workouts now count for this group's leaderboard and challenges. Choose how you appear in Settings → Groups in @%s.",
+	"group.already_joined":           "You are already taking part.",
+	"group.left":                     "👋 You no longer take part in this group's leaderboard and challenges.",
+	"group.not_member":               "You are not taking part in this group.",
+	"group.members_only":             "Only members who joined with /join can take part in challenges.",
+	"group.anonymous":                "Athlete #%d",
+	"group.privacy":                  "👥 How you appear in group leaderboards and challenges:",
+	"group.leaderboard_title":        "🏆 Leaderboard, week of %s\n\n",
+	"group.leaderboard_empty":        "Nobody has joined yet — send /join to take part.",
+	"group.leaderboard_line":         "%d. %s — workouts: %d",
+	"group.leaderboard_volume":       ", volume %s kg",
+	"group.leaderboard_streak":       ", 🔥 %d wk",
+	"group.leaderboard_footer":       "\n🔥 weeks in a row with at least %d workouts",
+	"group.challenges_title":         "🎯 Challenges\n\n",
+	"group.challenges_empty":         "No challenges are running.\n",
+	"group.challenge_line":           "%s — %s a day, %s – %s\n",
+	"group.challenges_hint":          "\nProgress counts the exercise from your finished workouts. It is posted here every day.",
+	"group.challenge_started":        "🚀 New challenge: %s — %s a day until %s. Tap Join to take part!",
+	"group.challenge_running":        "This challenge is already running — join it instead.",
+	"group.challenge_over":           "This challenge is over.",
+	"group.challenge_joined":         "✅ You joined %s in %s: %s a day until %s. Log the exercise in your workouts to count it.",
+	"group.challenge_already_joined": "You are already in %s.",
+	"challenge.push_up_30":           "30 days of push-ups",
+	"challenge.pull_up_30":           "30 days of pull-ups",
+	"challenge.burpee_30":            "30 days of burpees",
+	"challenge.plank_30":             "30 days of planks",
+	"challenge.crunch_14":            "14 days of crunches",
+	"challenge.reps":                 "%d reps",
+	"challenge.seconds":              "%d s",
+	"challenge.day":                  "%s — day %d of %d\n",
+	"challenge.results":              "🏁 %s is over! Results:\n",
+	"challenge.goal":                 "Goal: %s a day\n\n",
+	"challenge.line":                 "%s %s: %s, goal met %d/%d days, %s in total\n",
+	"error.group_update":             "Failed to update group membership",
+	"error.group_load":               "Failed to load the group",
+
+	// Coach mode
+	"coach.title":                "👥 Your clients: %d\n\n",
+	"coach.empty":                "No clients yet. Send them your invite link.\n",
+	"coach.invite":               "\n🔗 Invite link: %s\nA new link replaces the old one.",
+	"coach.invite_none":          "\nMake an invite link and send it to your clients.",
+	"coach.client":               "👤 %s\n\n",
+	"coach.client_program":       "📋 %s, cycle %d, week %d, day %d\n",
+	"coach.client_no_program":    "📋 No program\n",
+	"coach.client_week":          "📅 This week: %d of %d workouts\n",
+	"coach.client_adherence":     "🎯 Last %d weeks: %d of %d planned workouts (%d%%)\n",
+	"coach.client_no_sessions":   "\nNo finished workouts yet.",
+	"coach.client_sessions":      "\nRecent workouts:",
+	"coach.session":              "👤 %s\n🏋️ %s, %s\n\n",
+	"coach.set_skipped":          "skipped",
+	"coach.session_exercise":     "%s: %s\n",
+	"coach.session_volume":       "\nVolume: %s kg\n",
+	"coach.comment_line":         "\n💬 %s: %s",
+	"coach.notify_finished":      "🏁 %s finished “%s”: %d sets, %s kg.",
+	"coach.notify_skipped_sets":  "\n⏭ Skipped sets: %d",
+	"coach.notify_missed":        "⚠️ %s skipped the %s workout planned for %s.",
+	"coach.notify_comment":       "💬 Your coach %s commented on “%s” (%s):\n\n%s",
+	"coach.notify_program":       "📋 Your coach %s assigned you the program “%s”. Open it to set your training maxes and start.",
+	"coach.notify_removed":       "Your coach %s no longer sees your training.",
+	"coach.notify_left":          "%s stopped sharing their training with you.",
+	"coach.notify_joined":        "🎉 %s is now your client.",
+	"coach.no_coach":             "You have no coach. A coach can send you an invite link.",
+	"coach.your_coach":           "👤 Your coach: %s\n\nThey see your workouts and program, comment on your workouts and can assign you a program.",
+	"coach.already_client":       "%s is already your coach.",
+	"coach.invite_ask":           "👤 %s invites you to train with them.\n\nYour coach will see your workouts and program, comment on your workouts and can assign you a program. You can stop sharing at any time with /coach.\n\nShare your training?",
+	"coach.invite_replaces":      "\n\nYour current coach %s will no longer see your training.",
+	"coach.linked":               "✅ %s is now your coach.",
+	"coach.left":                 "✅ %s no longer sees your training.",
+	"coach.ask_comment":          "💬 Send your comment on the workout.",
+	"coach.comment_saved":        "✅ Comment sent.",
+	"coach.choose_program":       "📋 Choose a program for %s. It replaces the client's current program.",
+	"error.coach_load":           "Failed to load coaching data",
+	"error.coach_link":           "Failed to update coaching",
+	"error.coach_invite":         "This invite link is no longer valid. Ask your coach for a new one.",
+	"error.coach_self":           "You can't be your own client.",
+	"error.coach_forbidden":      "This is not available to you.",
+	"error.coach_comment":        "Failed to save the comment",
+	"error.coach_comment_length": "The comment must be from 1 to %d characters.",
+}
+
+var enPlurals = map[string]Plural{
+	"broadcast.started": {
+		One:   "✅ Broadcast started for %d recipient",
+		Other: "✅ Broadcast started for %d recipients",
+	},
+}
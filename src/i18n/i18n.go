@@ -0,0 +1,173 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"workouts_bot/src/models"
+)
+
+const (
+	RU = "ru"
+	EN = "en"
+
+	Default = RU
+)
+
+// Plural holds the forms of a countable message. Russian uses One, Few and
+// Many; English uses One and Other.
+type Plural struct {
+	One   string
+	Few   string
+	Many  string
+	Other string
+}
+
+var catalogs = map[string]map[string]string{
+	RU: ru,
+	EN: en,
+}
+
+var plurals = map[string]map[string]Plural{
+	RU: ruPlurals,
+	EN: enPlurals,
+}
+
+// Supported lists the locales users can pick in settings.
+var Supported = []string{RU, EN}
+
+// russianFallback are Telegram language codes whose speakers are more
+// likely to read Russian than English.
+var russianFallback = map[string]bool{
+	"ru": true, "uk": true, "be": true, "kk": true, "uz": true, "ky": true,
+}
+
+var (
+	buttonsOnce sync.Once
+	buttons     map[string]string
+)
+
+// Resolve maps a Telegram language code such as "en-US" to a locale.
+func Resolve(languageCode string) string {
+	code := strings.ToLower(strings.SplitN(languageCode, "-", 2)[0])
+	if _, ok := catalogs[code]; ok {
+		return code
+	}
+	if code == "" || russianFallback[code] {
+		return Default
+	}
+	return EN
+}
+
+// UserLocale returns the locale the user picked in settings, or the one
+// derived from their Telegram client language.
+func UserLocale(user *models.User) string {
+	if user == nil {
+		return Default
+	}
+	if user.Language != "" {
+		return Resolve(user.Language)
+	}
+	return Resolve(user.LanguageCode)
+}
+
+// T returns the message for key, formatted with args. Messages missing in
+// the locale fall back to the default locale and then to the key itself.
+func T(locale string, key string, args ...any) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// N returns the plural form of key matching n. The form is formatted with
+// n followed by args.
+func N(locale string, key string, n int, args ...any) string {
+	forms, ok := plurals[locale][key]
+	if !ok {
+		locale = Default
+		forms, ok = plurals[Default][key]
+	}
+	if !ok {
+		return key
+	}
+
+	message := pick(forms, form(locale, n))
+	return fmt.Sprintf(message, append([]any{n}, args...)...)
+}
+
+// Match finds the catalog key of a button label in any locale, so reply
+// keyboards keep working after the user switches language.
+func Match(text string) (string, bool) {
+	buttonsOnce.Do(func() {
+		buttons = make(map[string]string)
+		for _, catalog := range catalogs {
+			for key, message := range catalog {
+				if strings.HasPrefix(key, "button.") {
+					buttons[message] = key
+				}
+			}
+		}
+	})
+
+	key, ok := buttons[text]
+	return key, ok
+}
+
+type pluralForm int
+
+const (
+	formOne pluralForm = iota
+	formFew
+	formMany
+	formOther
+)
+
+func form(locale string, n int) pluralForm {
+	if n < 0 {
+		n = -n
+	}
+
+	switch locale {
+	case RU:
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return formOne
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return formFew
+		default:
+			return formMany
+		}
+	default:
+		if n == 1 {
+			return formOne
+		}
+		return formOther
+	}
+}
+
+func pick(forms Plural, f pluralForm) string {
+	var message string
+	switch f {
+	case formOne:
+		message = forms.One
+	case formFew:
+		message = forms.Few
+	case formMany:
+		message = forms.Many
+	}
+	if message == "" {
+		message = forms.Other
+	}
+	if message == "" {
+		message = forms.Many
+	}
+	return message
+}
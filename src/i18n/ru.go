@@ -0,0 +1,636 @@
+package i18n
+
+var ru = map[string]string{
+	// Buttons
+	"button.workout_start":          "▶️ Начать",
+	"button.workout_edit":           "✏️ Редактировать",
+	"button.workout_delete":         "🗑️ Удалить",
+	"button.workout_refresh":        "🔄 Обновить",
+	"button.workout_stats":          "📊 Статистика",
+	"button.stats_reset":            "🗑️ Сбросить статистику",
+	"button.exercise_details":       "📖 Подробнее",
+	"button.exercise_video":         "🎥 Видео",
+	"button.exercise_add":           "➕ Добавить в тренировку",
+	"button.set_complete":           "✅ Завершить подход",
+	"button.set_skip":               "⏭️ Пропустить",
+	"button.set_pause":              "⏸️ Пауза",
+	"button.goal_muscle_gain":       "💪 Набор массы",
+	"button.goal_strength":          "🏋️ Сила",
+	"button.goal_endurance":         "🏃 Выносливость",
+	"button.goal_weight_loss":       "🔥 Похудение",
+	"button.equipment_home":         "🏠 Домашний зал",
+	"button.equipment_gym":          "🏋️ Тренажерный зал",
+	"button.equipment_none":         "🚫 Без оборудования",
+	"button.equipment_custom":       "⚙️ Настроить",
+	"button.workout_type_split":     "🏋️ Классический сплит",
+	"button.workout_type_push_pull": "🔄 Push/Pull/Legs",
+	"button.workout_type_full_body": "💪 Фулбади",
+	"button.workout_type_custom":    "🎯 Кастомная",
+	"button.exercise_compound":      "🏋️ Базовые",
+	"button.exercise_isolation":     "🎯 Изолированные",
+	"button.exercise_strength":      "💪 Силовые",
+	"button.exercise_cardio":        "🏃 Кардио",
+	"button.exercise_bodyweight":    "🤸 С собственным весом",
+	"button.exercise_hiit":          "⚡ HIIT",
+	"button.exercise_endurance":     "🔄 Выносливость",
+	"button.settings_goals":         "🎯 Цели",
+	"button.settings_equipment":     "🏋️ Оборудование",
+	"button.settings_experience":    "📈 Уровень опыта",
+	"button.settings_limitations":   "⚠️ Ограничения",
+	"button.exp_beginner":           "🟢 Начинающий",
+	"button.exp_intermediate":       "🟡 Средний",
+	"button.exp_advanced":           "🟠 Продвинутый",
+	"button.exp_expert":             "🔴 Эксперт",
+	"button.muscle_chest":           "💪 Грудь",
+	"button.muscle_back":            "🏋️ Спина",
+	"button.muscle_shoulders":       "🦾 Плечи",
+	"button.muscle_biceps":          "💪 Бицепс",
+	"button.muscle_triceps":         "💪 Трицепс",
+	"button.muscle_legs":            "🦵 Ноги",
+	"button.muscle_glutes":          "🍑 Ягодицы",
+	"button.muscle_abs":             "💪 Пресс",
+	"button.duration_30":            "⏰ 30 минут",
+	"button.duration_45":            "⏰ 45 минут",
+	"button.duration_60":            "⏰ 60 минут",
+	"button.duration_90":            "⏰ 90 минут",
+	"button.nav_main_menu":          "🏠 Главное меню",
+	"button.nav_back":               "🔙 Назад",
+	"button.nav_yes":                "✅ Да",
+	"button.nav_no":                 "❌ Нет",
+	"button.settings":               "⚙️ Настройки",
+	"button.settings_language":      "🌐 Язык",
+	"button.language_ru":            "🇷🇺 Русский",
+	"button.language_en":            "🇬🇧 English",
+	"button.language_auto":          "🔄 Как в Telegram",
+
+	// Common
+	"error.unknown_command": "Неизвестная команда",
+	"error.invalid_format":  "Неверный формат команды",
+	"error.user_not_found":  "Пользователь не найден",
+	"error.user_fetch":      "Ошибка при получении пользователя",
+	"callback.outdated":     "⌛ Эта кнопка устарела. Откройте меню заново.",
+	"menu.main":             "Главное меню",
+
+	// Start
+	"start.hello": "Привет! Я бот для тренировок 🏋️\n\n" +
+		"Я помогу тебе:\n" +
+		"• Подобрать упражнения\n" +
+		"• Составить программу тренировок\n" +
+		"• Отслеживать прогресс\n" +
+		"• Записывать подходы и веса\n\n" +
+		"Выбери действие:",
+
+	// Settings
+	"settings.title":               "⚙️ Настройки",
+	"settings.summary_title":       "⚙️ Ваши настройки:\n\n",
+	"settings.summary_experience":  "📈 Уровень опыта: %s\n",
+	"settings.summary_language":    "🌐 Язык: %s\n\n",
+	"settings.summary_hint":        "Используйте кнопки ниже для изменения настроек:",
+	"settings.experience_question": "📈 Какой у вас уровень опыта в тренировках?",
+	"settings.language_question":   "🌐 Выберите язык интерфейса",
+	"error.unknown_setting":        "Неизвестная настройка",
+	"error.invalid_experience":     "Неверный уровень опыта",
+	"error.experience_update":      "Ошибка обновления уровня опыта",
+	"experience.updated":           "✅ Уровень опыта обновлен!",
+	"error.language_update":        "Ошибка обновления языка",
+	"language.updated":             "✅ Язык обновлён",
+	"settings.summary_goal":        "🎯 Цель: %s\n",
+	"settings.goal_question":       "🎯 Какая у вас основная цель?",
+	"settings.goal_none":           "не выбрана",
+	"error.goal_update":            "Ошибка обновления цели",
+	"goal.updated":                 "✅ Цель обновлена! Новые упражнения будут добавляться с подходящими подходами и повторениями.",
+
+	// Confirmation
+	"confirm.outdated":     "⌛ Это подтверждение устарело",
+	"confirm.already_done": "ℹ️ Действие уже обработано",
+	"confirm.expired":      "⌛ Время на подтверждение истекло, повторите действие",
+	"confirm.foreign":      "Это подтверждение предназначено другому пользователю",
+	"confirm.failed":       "❌ Не удалось выполнить действие",
+	"confirm.cancelled":    "🚫 Действие отменено",
+
+	// Broadcast
+	"error.admin_only":           "Команда доступна только администраторам",
+	"broadcast.ask_text":         "📣 Отправьте текст рассылки одним сообщением.",
+	"error.broadcast_text_only":  "Рассылка поддерживает только текст",
+	"error.broadcast_save":       "Не удалось сохранить рассылку",
+	"broadcast.confirm_question": "☝️ Так сообщение увидят пользователи. Отправить всем?",
+	"broadcast.starting":         "📣 Рассылка запускается...",
+	"broadcast.progress_title":   "📣 Рассылка идёт",
+	"broadcast.done_title":       "✅ Рассылка завершена",
+	"broadcast.failed_title":     "❌ Рассылка остановлена: не удалось сохранить статус доставки",
+	"broadcast.progress":         "%s: %d/%d\n\n✅ Доставлено: %d\n🚫 Заблокировали бота: %d\n❌ Ошибки: %d",
+
+	// Workouts
+	"button.my_workouts":          "🏋️ Мои тренировки",
+	"button.workout_rename":       "✏️ Переименовать",
+	"button.workout_add_exercise": "➕ Упражнение",
+	"button.workout_duplicate":    "📄 Дублировать",
+	"button.item_up":              "⬆️ Выше",
+	"button.item_down":            "⬇️ Ниже",
+	"button.item_targets":         "🎯 Подходы и вес",
+	"button.item_remove":          "❌ Убрать",
+	"workouts.list_title":         "🏋️ Ваши тренировки:",
+	"workouts.list_empty":         "🏋️ У вас пока нет тренировок. Создайте свою!",
+	"workouts.ask_name":           "✏️ Введите название новой тренировки",
+	"workouts.ask_rename":         "✏️ Введите новое название для «%s»",
+	"workouts.ask_exercise":       "🔎 Напишите название упражнения, например «жим» или «присед»",
+	"workouts.exercise_not_found": "Ничего не найдено по запросу «%s». Попробуйте по-другому.",
+	"workouts.exercise_choose":    "Выберите упражнение:",
+	"workouts.ask_targets": "🎯 %s\n\n" +
+		"Отправьте цели в формате «подходы x повторения вес отдых», например: 4x8 60 90\n" +
+		"Вес (кг) и отдых (секунды) можно не указывать.\n" +
+		"Для интервалов отправьте «раунды x работа/отдых» в секундах, например: 8x40/20",
+	"workouts.template_title":  "🏋️ %s\n\n",
+	"workouts.template_empty":  "Упражнений пока нет. Нажмите «✏️ Редактировать», чтобы добавить.",
+	"workouts.edit_hint":       "\nНажмите на упражнение, чтобы изменить цели или порядок.",
+	"workouts.item_line":       "%d. %s — %s\n",
+	"workouts.item_title":      "🏋️ %s\n\n%d. %s\n🎯 %s",
+	"workouts.targets":         "%d×%d",
+	"workouts.targets_weight":  " × %s кг",
+	"workouts.targets_rest":    ", отдых %d с",
+	"workouts.delete_question": "🗑️ Удалить тренировку «%s»? Это действие нельзя отменить.",
+	"workouts.deleted":         "🗑️ Тренировка «%s» удалена",
+	"workouts.copy_name":       "%s (копия)",
+	"error.workout_not_found":  "Тренировка не найдена",
+	"error.workout_save":       "Не удалось сохранить тренировку",
+	"error.workout_name":       "Название должно быть от 1 до 64 символов",
+	"error.targets_format":     "Не удалось разобрать цели. Пример: 4x8 60 90",
+
+	// Exercises
+	"button.exercises":           "📚 Упражнения",
+	"button.page_prev":           "◀️",
+	"button.page_next":           "▶️",
+	"exercises.categories_title": "📚 Каталог упражнений\n\nВыберите категорию:",
+	"exercises.category_title":   "%s\n\nВыберите упражнение:",
+	"exercises.card":             "%s\n\n🏷️ %s\n🎯 %s\n🛠️ %s",
+	"exercises.add_question":     "➕ Куда добавить «%s»?",
+	"exercises.add_empty":        "➕ У вас пока нет тренировок. Создайте тренировку в разделе «🏋️ Мои тренировки».",
+	"exercises.added_template":   "✅ «%s» добавлено в тренировку «%s»: %s",
+	"exercises.added_session":    "✅ «%s» добавлено в текущую тренировку: %s",
+	"error.exercise_not_found":   "Упражнение не найдено",
+	"equipment.barbell":          "Штанга",
+	"equipment.dumbbell":         "Гантели",
+	"equipment.kettlebell":       "Гиря",
+	"equipment.cable":            "Блочный тренажёр",
+	"equipment.machine":          "Тренажёр",
+	"equipment.cardio_machine":   "Кардиотренажёр",
+	"equipment.bodyweight":       "Собственный вес",
+	"equipment.none":             "Без инвентаря",
+
+	// Exercise media
+	"button.exercise_upload": "📎 Загрузить медиа",
+	"exercises.no_images":    "📖 Для «%s» пока нет фото техники.",
+	"exercises.no_video":     "🎥 Для «%s» пока нет видео техники.",
+	"media.ask_upload":       "📎 Отправьте фото, GIF или видео для «%s». Когда закончите, нажмите любую кнопку меню.",
+	"media.uploaded":         "✅ Сохранено для «%s». Отправьте ещё или нажмите кнопку меню.",
+	"error.media_type":       "Отправьте фото, GIF или видео",
+	"error.media_upload":     "Не удалось загрузить файл",
+	"error.media_send":       "Не удалось отправить медиа",
+	"error.media_disabled":   "Хранилище медиа не настроено",
+
+	// Sessions
+	"button.workout_finish":      "🏁 Завершить",
+	"sessions.continue":          "▶️ Продолжить: %s",
+	"sessions.title":             "▶️ %s\n\n",
+	"sessions.empty":             "Упражнений пока нет. Добавьте их из каталога «📚 Упражнения».",
+	"sessions.line_done":         "✅ %d. %s — %d/%d\n",
+	"sessions.line_current":      "👉 %d. %s — %d/%d\n",
+	"sessions.line_pending":      "▫️ %d. %s — %d/%d\n",
+	"sessions.next_set":          "\n👉 %s\nПодход %d из %d: %s",
+	"sessions.set_target_reps":   "%d повт.",
+	"sessions.set_target_weight": "%d × %s кг",
+	"sessions.rest":              "\n⏱️ Отдых между подходами: %d с",
+	"sessions.all_done":          "\n🎉 Все подходы выполнены! Нажмите «🏁 Завершить».",
+	"sessions.already_active":    "У вас уже идёт тренировка «%s». Завершите её, чтобы начать новую.",
+	"sessions.finished": "🏁 Тренировка «%s» завершена!\n\n" +
+		"⏱️ Длительность: %d мин\n" +
+		"✅ Подходов: %d\n" +
+		"🏋️ Объём: %s кг",
+	"error.session_not_found": "Тренировка не найдена или уже завершена",
+	"error.session_save":      "Не удалось сохранить тренировку",
+
+	// Progress photos
+	"button.progress":         "📸 Прогресс",
+	"button.progress_add":     "📷 Добавить фото",
+	"button.progress_history": "🗂️ История",
+	"button.progress_compare": "🔀 Сравнить",
+	"button.pose_front":       "Спереди",
+	"button.pose_side":        "Сбоку",
+	"button.pose_back":        "Сзади",
+	"format.date":             "02.01.2006",
+	"progress.title":          "📸 Фото прогресса\n\nФото хранятся приватно, их видите только вы.",
+	"progress.choose_pose":    "Выберите ракурс:",
+	"progress.ask_photo":      "📷 Отправьте фото: %s.",
+	"progress.saved":          "✅ Фото сохранено: %s. Добавить другой ракурс?",
+	"progress.history_title":  "🗂️ Дни с фото:",
+	"progress.empty":          "У вас пока нет фото прогресса.",
+	"progress.photo_caption":  "%s · %s",
+	"progress.compare_from":   "🔀 %s: выберите первую дату:",
+	"progress.compare_to":     "🔀 %s, %s: выберите вторую дату:",
+	"progress.compare_empty":  "Для сравнения нужны фото (%s) минимум за два дня.",
+	"progress.collage":        "%s: %s → %s",
+	"error.progress_photo":    "Отправьте фото",
+	"error.progress_save":     "Не удалось сохранить фото",
+	"error.progress_send":     "Не удалось отправить фото",
+
+	// Measurements
+	"button.progress_measurements":   "📏 Замеры",
+	"measurements.kind_weight":       "Вес",
+	"measurements.kind_body_fat":     "Жир",
+	"measurements.kind_waist":        "Талия",
+	"measurements.kind_chest":        "Грудь",
+	"measurements.kind_arms":         "Руки",
+	"measurements.kind_thighs":       "Бёдра",
+	"measurements.unit_weight":       "%s кг",
+	"measurements.unit_body_fat":     "%s%%",
+	"measurements.unit_waist":        "%s см",
+	"measurements.unit_chest":        "%s см",
+	"measurements.unit_arms":         "%s см",
+	"measurements.unit_thighs":       "%s см",
+	"measurements.title":             "📏 Замеры\n",
+	"measurements.empty":             "\nЗамеров пока нет.",
+	"measurements.line":              "\n%s: %s (%s)",
+	"measurements.hint":              "\n\nБыстрый ввод: /weight 82.5, /bodyfat 18, /waist 84, /chest, /arms, /thighs. Команда без числа покажет историю.",
+	"measurements.trend_up":          "\n📈 Среднее за 7 дней %s, %s в неделю",
+	"measurements.trend_down":        "\n📉 Среднее за 7 дней %s, %s в неделю",
+	"measurements.trend_flat":        "\n➡️ Среднее за 7 дней %s, %s в неделю",
+	"measurements.verdict_on_track":  "\n✅ Движетесь к цели",
+	"measurements.verdict_off_track": "\n⚠️ Тренд против вашей цели",
+	"measurements.verdict_stalled":   "\n⏸️ Прогресс остановился",
+	"measurements.saved":             "✅ %s: %s сохранено",
+	"measurements.history_title":     "📏 %s: история\n",
+	"measurements.history_empty":     "\nЗаписей пока нет.",
+	"measurements.history_line":      "\n%s: %s (среднее %s)",
+	"error.measurement_value":        "Введите число от %s до %s",
+	"error.measurement_save":         "Не удалось сохранить замер",
+	"error.measurement_load":         "Не удалось загрузить замеры",
+
+	// Progression
+	"button.set_other":            "✏️ Другое",
+	"button.set_accept":           "✅ Принять рекомендацию",
+	"button.settings_progression": "📈 Прогрессия",
+	"button.settings_calendar":    "📅 Календарь",
+	"button.settings_groups":      "👥 Группы",
+	"button.progression_linear":   "📈 Линейная",
+	"button.progression_double":   "🔁 Двойная прогрессия",
+	"button.progression_rpe":      "🎚️ По RPE",
+	"sessions.rpe":                "RPE %s",
+	"sessions.ask_set":            "✏️ Отправьте подход в виде «повторы вес @RPE», например «8 60 @8». Вес и RPE можно не указывать.",
+	"error.set_format":            "❌ Не удалось разобрать подход. Пример: «8 60 @8».",
+	"error.progression_update":    "Не удалось обновить схему прогрессии",
+	"settings.progression_question": "📈 Как бот должен предлагать следующие цели?\n\n" +
+		"📈 Линейная — добавлять вес, когда все подходы выполнены.\n" +
+		"🔁 Двойная прогрессия — добавлять повторы до верхней границы диапазона, затем вес.\n" +
+		"🎚️ По RPE — менять вес в зависимости от тяжести последних подходов.",
+	"settings.summary_progression":  "📈 Прогрессия: %s\n",
+	"progression.updated":           "✅ Схема прогрессии обновлена!",
+	"progression.recommendation":    "\n\n💡 %s: %s → %s",
+	"progression.scheme_linear":     "Линейная",
+	"progression.scheme_double":     "Двойная прогрессия",
+	"progression.scheme_rpe":        "По RPE",
+	"progression.action_deload":     "разгрузка",
+	"progression.action_hold":       "повторить",
+	"progression.action_add_reps":   "добавить повторы",
+	"progression.action_add_weight": "добавить вес",
+	"progression.last":              "\nВ прошлый раз: %s",
+	"progression.last_weight":       "%s × %s кг",
+
+	// Programs
+	"button.programs":        "📅 Программы",
+	"button.program_current": "▶️ Моя программа: %s",
+	"button.program_enroll":  "✅ Начать программу",
+	"button.program_start":   "🏋️ Начать следующий день",
+	"button.program_maxes":   "🎯 Тренировочные максимумы",
+	"button.program_leave":   "🚪 Выйти из программы",
+	"error.program_load":     "Не удалось загрузить программу",
+	"error.program_save":     "Не удалось сохранить программу",
+	"error.training_max":     "❌ Отправьте тренировочный максимум в кг, число от 1 до %d, или выполненный подход, например «120x5».",
+	"sessions.set_amrap":     " — максимум повторов",
+	"programs.list": "📅 Программы\n\n" +
+		"Проверенные периодизированные программы. Бот рассчитывает веса каждого дня от ваших тренировочных " +
+		"максимумов и переводит вас по неделям и циклам по мере завершения тренировок.",
+	"programs.title":           "📅 %s\n\n",
+	"programs.schedule":        "\n\n🗓 Недель в цикле: %d, тренировок в неделю: %d",
+	"programs.maxes_needed":    "\n🎯 Тренировочные максимумы: %s",
+	"programs.replace_warning": "\n\n⚠️ Сейчас вы занимаетесь по «%s». Новая программа заменит её и начнётся с первого дня.",
+	"programs.position":        "Цикл %d · неделя %d из %d",
+	"programs.deload":          " · разгрузка",
+	"programs.next_day":        "\n\nДалее: %s\n",
+	"programs.lift_line":       "• %s: %s\n",
+	"programs.reps":            "%d",
+	"programs.reps_amrap":      "%d+",
+	"programs.sets":            "%d × %s",
+	"programs.sets_weight":     "%d × %s × %s кг",
+	"programs.session_name":    "%s — %s",
+	"programs.ask_max": "🎯 Отправьте тренировочный максимум для «%s» в кг.\n\n" +
+		"Это примерно 90%% от веса, который вы можете поднять один раз. " +
+		"Можно отправить и подход до отказа, например «120x5», — бот рассчитает максимум сам.",
+	"programs.max":                    "%s: %s кг",
+	"programs.max_missing":            "%s: не задан",
+	"programs.max_saved":              "✅ Тренировочный максимум сохранён. %s",
+	"programs.maxes":                  "🎯 Тренировочные максимумы\n\nНажмите на упражнение, чтобы изменить максимум.",
+	"programs.advanced":               "\n\n📅 Следующий день программы: %s",
+	"programs.cycle_done":             "\n\n🎉 Цикл завершён! Тренировочные максимумы увеличены, начинается цикл %d.",
+	"programs.leave_question":         "Выйти из «%s»? Текущее место в программе будет потеряно, тренировочные максимумы сохранятся.",
+	"programs.left":                   "🚪 Вы вышли из «%s».",
+	"programs.not_enrolled":           "Вы не занимаетесь по программе.",
+	"programs.name_531":               "5/3/1",
+	"programs.name_gzclp":             "GZCLP",
+	"programs.name_ppl":               "Тяни / Толкай / Ноги",
+	"programs.name_starting_strength": "Starting Strength",
+	"programs.about_531": "5/3/1 Джима Вендлера с Boring But Big: одно основное упражнение в день, четырёхнедельная " +
+		"волна на пятёрках, тройках и 5/3/1 с подходом на максимум повторов, затем разгрузка. После основного — 5×10 на 50%.",
+	"programs.about_gzclp": "Линейная прогрессия GZCL Коди Лефевера: тяжёлые тройки T1 с последним подходом на максимум, " +
+		"подходы по десять T2 и лёгкие подсобные T3 по четырём дням.",
+	"programs.about_ppl": "Дни жима, тяги и ног с трёхнедельной волной в основных упражнениях: тяжелее каждую неделю, " +
+		"на третьей — подход на максимум повторов, затем разгрузка.",
+	"programs.about_starting_strength": "Программа для новичков Марка Риппето: тренировки A и B по очереди, " +
+		"три подхода по пять с рабочим весом. Тренировочный максимум — это и есть рабочий вес.",
+	"programs.day_press":    "Жим стоя",
+	"programs.day_deadlift": "Становая тяга",
+	"programs.day_bench":    "Жим лёжа",
+	"programs.day_squat":    "Присед",
+	"programs.day_a1":       "A1",
+	"programs.day_b1":       "B1",
+	"programs.day_a2":       "A2",
+	"programs.day_b2":       "B2",
+	"programs.day_push":     "Толкай",
+	"programs.day_pull":     "Тяни",
+	"programs.day_legs":     "Ноги",
+	"programs.day_a":        "Тренировка A",
+	"programs.day_b":        "Тренировка B",
+
+	// One-rep max
+	"button.exercise_max":    "🎯 1ПМ",
+	"error.onerm_format":     "❌ Отправьте подход как «/1rm 100x5», можно с RPE: «/1rm 100x5 @8». Не больше %d повторов.",
+	"onerm.title":            "🏋️ %s кг × %d\n\nРасчётный 1ПМ:\n",
+	"onerm.title_rpe":        "🏋️ %s кг × %d @ RPE %s\n\nРасчётный 1ПМ:\n",
+	"onerm.formula_line":     "• %s: %s кг\n",
+	"onerm.formula_epley":    "Эпли",
+	"onerm.formula_brzycki":  "Бжицки",
+	"onerm.formula_lombardi": "Ломбарди",
+	"onerm.formula_rpe":      "Таблица RPE",
+	"onerm.average":          "\nСреднее: %s кг · тренировочный максимум (90%%): %s кг\n",
+	"onerm.table_title":      "\n📊 Проценты от 1ПМ:\n",
+	"onerm.table_line":       "%d%% — %s кг · ≈%d повт.\n",
+	"onerm.maxes_title":      "🎯 Тренировочные максимумы\n\n",
+	"onerm.maxes_empty":      "🎯 Тренировочных максимумов пока нет. Задайте их в карточке упражнения или в программе.\n",
+	"onerm.maxes_line":       "• %s: ТМ %s кг · 1ПМ %s (%s)\n",
+	"onerm.weight":           "%s кг",
+	"onerm.unknown":          "неизвестен",
+	"onerm.source_manual":    "введён",
+	"onerm.source_test":      "проверен",
+	"onerm.source_estimate":  "по тренировкам",
+	"onerm.usage":            "\nОтправьте «/1rm 100x5», чтобы рассчитать 1ПМ по подходу и получить таблицу процентов. Добавьте «@8», чтобы указать RPE.",
+	"onerm.records":          "\n\n🏆 Новый расчётный 1ПМ:\n%s",
+	"onerm.record_line":      "• %s: %s кг",
+
+	// Warm-ups and equipment
+	"sessions.next_warmup":         "\n👉 %s\n🔥 Разминка %d из %d: %s",
+	"sessions.target_weight_lb":    "%d × %s фнт",
+	"settings.summary_equipment":   "🏋️ Блины: %s, разминка %s\n",
+	"equipment.title":              "🏋️ Оборудование\n\nЕдиницы: %s\nБлины: %s\nГриф: %s %s\nШаг гантелей: %s %s\nРазминка: %s\n\nРазминочные подходы перед тяжёлыми базовыми упражнениями округляются под эти блины и гантели и не учитываются в тоннаже.",
+	"equipment.unit_kg":            "кг",
+	"equipment.unit_lb":            "фунты",
+	"equipment.plates_standard":    "%s (стандартный набор)",
+	"equipment.warmups_on":         "включена",
+	"equipment.warmups_off":        "выключена",
+	"equipment.ask_plates":         "✏️ Отправьте веса ваших блинов (%s) через пробел, например «20 10 5 2,5 1,25». Отправьте 0, чтобы вернуть стандартный набор.",
+	"button.equipment_plates":      "✏️ Изменить блины",
+	"button.equipment_warmups_on":  "🔥 Включить разминку",
+	"button.equipment_warmups_off": "🚫 Выключить разминку",
+	"error.plates_format":          "Не удалось разобрать блины. Отправьте до 10 весов через пробел, например «20 10 5 2,5».",
+	"error.equipment_update":       "Не удалось обновить настройки оборудования",
+
+	// Supersets, drop sets and intervals
+	"button.set_drop":              "⬇️ Дроп-сет",
+	"button.set_rest_pause":        "⏸ Отдых-пауза",
+	"button.item_link":             "🔗 Суперсет со следующим",
+	"button.item_unlink":           "✂️ Отделить от следующего",
+	"sessions.line_linked":         "   🔗\n",
+	"sessions.superset":            "\n🔗 Суперсет, круг %d из %d",
+	"sessions.circuit":             "\n🔁 Круговая, круг %d из %d",
+	"sessions.rest_next":           "\n➡️ Без отдыха переходите к: %s",
+	"sessions.next_interval":       "\n👉 %s\n⏱️ Раунд %d из %d: %s",
+	"sessions.set_target_interval": "%d с работы, затем %d с отдыха",
+	"sessions.extended_set":        "\n📝 %s: %s\n",
+	"sessions.sub_drop":            " ↘️ %s",
+	"sessions.sub_rest_pause":      " ⏸ %s",
+	"sessions.ask_drop":            "⬇️ Дроп-сет в упражнении «%s». Отправьте повторы и вес, например «8 50». Без веса будет %s кг.",
+	"sessions.ask_rest_pause":      "⏸ Отдых-пауза в упражнении «%s»: отдохните 15–20 секунд и отправьте число повторов. Вес останется %s кг, если не указать другой.",
+	"workouts.intervals":           "%d × %d с работы / %d с отдыха",
+	"workouts.item_linked":         "   🔗\n",
+	"workouts.item_linked_next":    "\n🔗 Суперсет со следующим упражнением",
+	"error.link_last":              "После этого упражнения нет другого, чтобы объединить их.",
+
+	// Cardio and statistics
+	"button.set_activity":              "📝 Записать активность",
+	"button.import_skip":               "⏭ Не импортировать",
+	"button.export_csv":                "📄 CSV (zip)",
+	"button.export_json":               "🧾 JSON",
+	"button.export_xlsx":               "📊 Excel",
+	"button.export_photos":             "📸 Фото прогресса (zip)",
+	"button.account_delete":            "🗑 Удалить всё",
+	"button.calendar_enable":           "📅 Получить ссылку",
+	"button.calendar_rotate":           "🔄 Новая ссылка",
+	"button.calendar_revoke":           "🚫 Отключить",
+	"button.group_privacy_public":      "Показывать имя и цифры",
+	"button.group_privacy_hide_volume": "Показывать имя, скрыть объём",
+	"button.group_privacy_anonymous":   "Оставаться анонимным",
+	"button.challenge_join":            "🙋 Участвовать: %s",
+	"button.challenge_start":           "🚀 Начать: %s",
+	"button.coach_invite":              "🔗 Новая ссылка-приглашение",
+	"button.coach_assign_program":      "📋 Назначить программу",
+	"button.coach_remove_client":       "🚫 Удалить клиента",
+	"button.coach_comment":             "💬 Комментарий",
+	"button.coach_clients":             "👥 Все клиенты",
+	"button.coach_leave":               "🚫 Больше не делиться с тренером",
+	"button.coach_open_program":        "📋 Открыть программу",
+	"button.coach_open_session":        "🔎 Открыть тренировку",
+	"button.coach_open_client":         "👤 Открыть клиента",
+	"sessions.next_activity":           "\n👉 %s\nКогда закончите, запишите время и дистанцию.",
+	"cardio.title":                     "🏃 Кардио\n\n",
+	"cardio.empty":                     "Активностей пока нет.\n",
+	"cardio.line":                      "%s — %s\n",
+	"cardio.choose":                    "\nВыберите активность, чтобы записать её:",
+	"cardio.ask_entry":                 "🏃 %s\n\nОтправьте «время дистанция пульс @усилие», например «25:30 5 148 @7».\nВремя — минуты или мм:сс, дистанция в км (0, если не измеряли); пульс и усилие от 1 до 10 можно не указывать.",
+	"cardio.ask_session":               "🏃 Отправьте «время дистанция пульс @усилие», например «25:30 5 148 @7». Дистанция в км; пульс и усилие можно не указывать.",
+	"cardio.saved":                     "✅ Записано: %s",
+	"cardio.meters":                    "%d м",
+	"cardio.km":                        "%s км",
+	"cardio.per_km":                    "км",
+	"cardio.pace":                      "%s /%s",
+	"cardio.speed":                     "%s км/ч",
+	"cardio.heart_rate":                "❤️ %d",
+	"cardio.effort":                    "усилие %d/10",
+	"stats.title":                      "📊 Статистика по неделям\n",
+	"stats.week":                       "\n📅 Неделя с %s\n",
+	"stats.week_empty":                 "Тренировок не было\n",
+	"stats.sessions":                   "🏋️ Тренировки: %d, тоннаж %s кг\n",
+	"stats.cardio":                     "🏃 Кардио: %d, %s, %s\n",
+	"stats.reset_question":             "🗑️ Сбросить статистику? Все завершённые тренировки и кардио-активности будут удалены. Это действие нельзя отменить.",
+	"stats.reset_done":                 "🗑️ Статистика сброшена",
+	"error.cardio_format":              "Не удалось разобрать активность. Отправьте её в виде «25:30 5 148 @7».",
+	"error.cardio_load":                "Не удалось загрузить активности",
+	"error.cardio_save":                "Не удалось сохранить активность",
+	"error.stats_load":                 "Не удалось загрузить статистику",
+	"error.stats_reset":                "Не удалось сбросить статистику",
+
+	// Activity import
+	"import.help":               "📥 Отправьте файл GPX, TCX или FIT из часов или приложения, и я сохраню тренировку как кардио-активность.\n\nЧтобы перенести историю из Strong, Hevy или FitNotes, отправьте их экспорт в CSV. Повторный импорт того же файла ничего не дублирует.",
+	"import.saved":              "✅ Активность импортирована\n\n%s",
+	"import.duplicate":          "Эта активность уже сохранена: %s",
+	"import.summary_title":      "🏃 %s\n",
+	"import.summary_date":       "📅 %s\n",
+	"import.summary_time":       "⏱ %s\n",
+	"import.summary_elevation":  "⛰ Набор высоты: %d м\n",
+	"import.summary_heart_rate": "❤️ Пульс: средний %d, максимальный %d\n",
+	"error.import_too_large":    "Файл слишком большой для импорта",
+	"error.import_format":       "Не удалось прочитать файл. Поддерживаются GPX, TCX и FIT с записанным треком.",
+	"error.import_failed":       "Не удалось импортировать активность",
+
+	// Workout history import
+	"import.ask_alias":            "Какое упражнение из каталога соответствует «%s»? Осталось сопоставить названий: %d.\nВыберите вариант или напишите название для поиска.",
+	"import.choose_alias":         "Выберите упражнение для «%s»:",
+	"import.history_done":         "✅ Импорт из %s завершён: добавлено тренировок — %d, уже были импортированы — %d.",
+	"import.workout_name":         "Тренировка из %s",
+	"error.import_history_format": "Не удалось прочитать файл. Поддерживается экспорт CSV из Strong, Hevy и FitNotes.",
+	"error.import_expired":        "Импорт устарел. Отправьте файл ещё раз.",
+
+	// Data export
+	"export.choose":       "📤 Выгрузка всех ваших данных: профиль, шаблоны, тренировки, подходы, рекорды, активности и замеры. Фото прогресса выгружаются отдельным архивом. Выберите формат:",
+	"export.caption":      "📤 Ваши данные",
+	"export.photos":       "📸 Ваши фото прогресса: %d",
+	"error.export_failed": "Не удалось выгрузить данные",
+
+	// Account deletion
+	"account.delete_ask":          "⚠️ Удалить аккаунт? Профиль, шаблоны, тренировки, активности, замеры, рекорды и фото прогресса будут стёрты навсегда без возможности восстановления.\n\nСначала можно скачать все свои данные и фото прогресса.",
+	"account.deleted":             "✅ Аккаунт и все его данные удалены. Если захотите начать заново, отправьте /start.",
+	"error.account_delete_failed": "Не удалось начать удаление аккаунта",
+
+	// Calendar feed
+	"calendar.name":           "Тренировки",
+	"calendar.on":             "📅 Подпишитесь на эту ссылку в Google Календаре, Apple Календаре или Outlook, чтобы видеть предстоящие тренировки программы и завершённые занятия:\n\n%s\n\nНе делитесь ссылкой: по ней видны ваши тренировки. После выпуска новой ссылки старая перестаёт работать.",
+	"calendar.active":         "📅 Календарь подключён. Ссылка показывается только при выпуске; если она потерялась, получите новую, и старая перестанет работать.",
+	"calendar.off":            "📅 Тренировки могут появляться в вашем календаре: предстоящие дни программы с упражнениями и завершённые занятия.\n\nПолучите личную ссылку и подпишитесь на неё в приложении календаря.",
+	"error.calendar_disabled": "Календарь недоступен в этом боте",
+	"error.calendar_update":   "Не удалось обновить ссылку на календарь",
+
+	// Achievements
+	"achievements.new":              "\n\n🏅 Новые достижения:\n%s",
+	"profile.title":                 "👤 %s\n\n",
+	"profile.totals":                "🏋️ Тренировок: %d, поднято %.1f т\n",
+	"profile.week_streak":           "🔥 Недель подряд с %[2]d+ тренировками: %[1]d\n",
+	"profile.planned_streak":        "📅 Дней программы подряд по плану: %d\n",
+	"profile.badges":                "\n🏅 Достижения: %d из %d\n",
+	"profile.badges_empty":          "Пока нет — завершите тренировку, чтобы получить первое.\n",
+	"profile.badge_line":            "%s — %s\n",
+	"profile.next":                  "\n🎯 Ближайшие цели:\n",
+	"profile.next_line":             "%s: %s / %s\n",
+	"achievement.first_session":     "Первая тренировка",
+	"achievement.sessions_10":       "10 тренировок",
+	"achievement.sessions_50":       "50 тренировок",
+	"achievement.sessions_100":      "100 тренировок",
+	"achievement.sessions_500":      "500 тренировок",
+	"achievement.volume_10t":        "10 тонн поднято",
+	"achievement.volume_100t":       "100 тонн поднято",
+	"achievement.volume_1000t":      "1000 тонн поднято",
+	"achievement.bench_100":         "Жим лёжа 100 кг",
+	"achievement.squat_140":         "Присед 140 кг",
+	"achievement.deadlift_180":      "Становая тяга 180 кг",
+	"achievement.week_streak_4":     "4 сильные недели подряд",
+	"achievement.week_streak_12":    "12 сильных недель подряд",
+	"achievement.week_streak_52":    "Год сильных недель",
+	"achievement.planned_streak_10": "10 дней программы по плану",
+	"achievement.planned_streak_30": "30 дней программы по плану",
+
+	// Group chats
+	"group.welcome":                  "👋 Привет! Я веду недельный рейтинг и челленджи этой группы.\n\n/join — участвовать (сначала запустите @%s в личном чате)\n/leaderboard — рейтинг недели\n/challenge — текущие и новые челленджи\n/leave — перестать участвовать\n\nПоказываются только участники. Как вас показывать, выберите в личном чате: Настройки → Группы.",
+	"group.start_first":              "Чтобы участвовать, откройте @%s в личном чате, нажмите /start, а затем отправьте /join здесь.",
+	"group.joined":                   "✅ Вы участвуете! Ваши тренировки теперь учитываются в рейтинге и челленджах группы. Как вас показывать, выберите в @%s: Настройки → Группы.",
+	"group.already_joined":           "Вы уже участвуете.",
+	"group.left":                     "👋 Вы больше не участвуете в рейтинге и челленджах группы.",
+	"group.not_member":               "Вы не участвуете в этой группе.",
+	"group.members_only":             "В челленджах могут участвовать только те, кто вступил через /join.",
+	"group.anonymous":                "Атлет №%d",
+	"group.privacy":                  "👥 Как вас показывать в рейтингах и челленджах групп:",
+	"group.leaderboard_title":        "🏆 Рейтинг недели с %s\n\n",
+	"group.leaderboard_empty":        "Пока никто не участвует — отправьте /join.",
+	"group.leaderboard_line":         "%d. %s — тренировок: %d",
+	"group.leaderboard_volume":       ", объём %s кг",
+	"group.leaderboard_streak":       ", 🔥 %d нед.",
+	"group.leaderboard_footer":       "\n🔥 недель подряд, в которых не меньше %d тренировок",
+	"group.challenges_title":         "🎯 Челленджи\n\n",
+	"group.challenges_empty":         "Сейчас челленджей нет.\n",
+	"group.challenge_line":           "%s — %s в день, %s – %s\n",
+	"group.challenges_hint":          "\nПрогресс считается по упражнению в завершённых тренировках и публикуется здесь каждый день.",
+	"group.challenge_started":        "🚀 Новый челлендж: %s — %s в день до %s. Нажмите «Участвовать», чтобы присоединиться!",
+	"group.challenge_running":        "Этот челлендж уже идёт — присоединяйтесь к нему.",
+	"group.challenge_over":           "Этот челлендж уже закончился.",
+	"group.challenge_joined":         "✅ Вы участвуете в челлендже %s в группе %s: %s в день до %s. Записывайте упражнение в тренировках, чтобы оно засчитывалось.",
+	"group.challenge_already_joined": "Вы уже участвуете в челлендже %s.",
+	"challenge.push_up_30":           "30 дней отжиманий",
+	"challenge.pull_up_30":           "30 дней подтягиваний",
+	"challenge.burpee_30":            "30 дней бёрпи",
+	"challenge.plank_30":             "30 дней планки",
+	"challenge.crunch_14":            "14 дней скручиваний",
+	"challenge.reps":                 "%d повт.",
+	"challenge.seconds":              "%d с",
+	"challenge.day":                  "%s — день %d из %d\n",
+	"challenge.results":              "🏁 %s завершён! Итоги:\n",
+	"challenge.goal":                 "Цель: %s в день\n\n",
+	"challenge.line":                 "%s %s: %s, цель выполнена %d/%d дн., всего %s\n",
+	"error.group_update":             "Не удалось обновить участие в группе",
+	"error.group_load":               "Не удалось загрузить группу",
+
+	// Coach mode
+	"coach.title":                "👥 Ваши клиенты: %d\n\n",
+	"coach.empty":                "Клиентов пока нет. Отправьте им ссылку-приглашение.\n",
+	"coach.invite":               "\n🔗 Ссылка-приглашение: %s\nНовая ссылка заменяет старую.",
+	"coach.invite_none":          "\nСоздайте ссылку-приглашение и отправьте её клиентам.",
+	"coach.client":               "👤 %s\n\n",
+	"coach.client_program":       "📋 %s, цикл %d, неделя %d, день %d\n",
+	"coach.client_no_program":    "📋 Без программы\n",
+	"coach.client_week":          "📅 На этой неделе: %d из %d тренировок\n",
+	"coach.client_adherence":     "🎯 За %d нед.: %d из %d запланированных тренировок (%d%%)\n",
+	"coach.client_no_sessions":   "\nЗавершённых тренировок пока нет.",
+	"coach.client_sessions":      "\nПоследние тренировки:",
+	"coach.session":              "👤 %s\n🏋️ %s, %s\n\n",
+	"coach.set_skipped":          "пропущен",
+	"coach.session_exercise":     "%s: %s\n",
+	"coach.session_volume":       "\nОбъём: %s кг\n",
+	"coach.comment_line":         "\n💬 %s: %s",
+	"coach.notify_finished":      "🏁 %s завершил(а) «%s»: подходов %d, %s кг.",
+	"coach.notify_skipped_sets":  "\n⏭ Пропущено подходов: %d",
+	"coach.notify_missed":        "⚠️ %s пропустил(а) тренировку по программе «%s», запланированную на %s.",
+	"coach.notify_comment":       "💬 Ваш тренер %s прокомментировал(а) «%s» (%s):\n\n%s",
+	"coach.notify_program":       "📋 Ваш тренер %s назначил(а) вам программу «%s». Откройте её, чтобы указать рабочие максимумы и начать.",
+	"coach.notify_removed":       "Ваш тренер %s больше не видит ваши тренировки.",
+	"coach.notify_left":          "%s больше не делится с вами тренировками.",
+	"coach.notify_joined":        "🎉 %s теперь ваш клиент.",
+	"coach.no_coach":             "У вас нет тренера. Тренер может прислать вам ссылку-приглашение.",
+	"coach.your_coach":           "👤 Ваш тренер: %s\n\nТренер видит ваши тренировки и программу, комментирует тренировки и может назначить программу.",
+	"coach.already_client":       "%s уже ваш тренер.",
+	"coach.invite_ask":           "👤 %s приглашает вас тренироваться вместе.\n\nТренер будет видеть ваши тренировки и программу, комментировать тренировки и сможет назначить программу. Прекратить доступ можно в любой момент через /coach.\n\nДелиться тренировками?",
+	"coach.invite_replaces":      "\n\nВаш текущий тренер %s больше не будет видеть ваши тренировки.",
+	"coach.linked":               "✅ %s теперь ваш тренер.",
+	"coach.left":                 "✅ %s больше не видит ваши тренировки.",
+	"coach.ask_comment":          "💬 Отправьте комментарий к тренировке.",
+	"coach.comment_saved":        "✅ Комментарий отправлен.",
+	"coach.choose_program":       "📋 Выберите программу для клиента %s. Она заменит его текущую программу.",
+	"error.coach_load":           "Не удалось загрузить данные тренера",
+	"error.coach_link":           "Не удалось обновить связь с тренером",
+	"error.coach_invite":         "Ссылка-приглашение больше не действует. Попросите у тренера новую.",
+	"error.coach_self":           "Нельзя быть своим собственным клиентом.",
+	"error.coach_forbidden":      "Это вам недоступно.",
+	"error.coach_comment":        "Не удалось сохранить комментарий",
+	"error.coach_comment_length": "Комментарий должен быть от 1 до %d символов.",
+}
+
+var ruPlurals = map[string]Plural{
+	"broadcast.started": {
+		One:  "✅ Рассылка запущена для %d получателя",
+		Few:  "✅ Рассылка запущена для %d получателей",
+		Many: "✅ Рассылка запущена для %d получателей",
+	},
+}
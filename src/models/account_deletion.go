@@ -0,0 +1,38 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountDeletion is the audit record of an account deleted at the user's
+// request. Subject is a keyed hash of the Telegram ID; Deleted counts the
+// rows removed per table and Photos the progress photos removed from storage.
+type AccountDeletion struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Subject   string           `gorm:"not null" json:"subject"`
+	Deleted   map[string]int64 `gorm:"serializer:json;not null" json:"deleted"`
+	Photos    int              `gorm:"not null;default:0" json:"photos"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+func (AccountDeletion) TableName() string {
+	return "workouts.account_deletions"
+}
+
+// DeletionSubject is the HMAC AccountDeletion keeps of a Telegram ID, so
+// the ID cannot be recovered by hashing every possible one. Without a
+// secret no subject is kept.
+func DeletionSubject(secret string, telegramID int64) string {
+	if secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(telegramID, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Achievement is an achievement the user earned; Slug names its rule.
+type Achievement struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_achievements_user_slug" json:"user_id"`
+	Slug     string    `gorm:"not null;uniqueIndex:idx_achievements_user_slug" json:"slug"`
+	EarnedAt time.Time `gorm:"not null" json:"earned_at"`
+}
+
+func (Achievement) TableName() string {
+	return "workouts.achievements"
+}
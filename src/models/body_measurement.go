@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MeasurementWeight  = "weight"
+	MeasurementBodyFat = "body_fat"
+	MeasurementWaist   = "waist"
+	MeasurementChest   = "chest"
+	MeasurementArms    = "arms"
+	MeasurementThighs  = "thighs"
+)
+
+// BodyMeasurement is one value per kind per day: kilograms for weight,
+// percent for body fat and centimetres for circumferences. Entering a value
+// again on the same day replaces it.
+type BodyMeasurement struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_body_measurement_day" json:"user_id"`
+	Kind       string    `gorm:"not null;uniqueIndex:idx_body_measurement_day" json:"kind"`
+	Value      float64   `gorm:"not null" json:"value"`
+	MeasuredOn time.Time `gorm:"type:date;not null;uniqueIndex:idx_body_measurement_day" json:"measured_on"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (BodyMeasurement) TableName() string {
+	return "workouts.body_measurements"
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	BroadcastStatusDraft     = "draft"
+	BroadcastStatusSending   = "sending"
+	BroadcastStatusCompleted = "completed"
+	BroadcastStatusCancelled = "cancelled"
+	BroadcastStatusFailed    = "failed"
+)
+
+const (
+	DeliveryStatusPending = "pending"
+	DeliveryStatusSent    = "sent"
+	DeliveryStatusFailed  = "failed"
+	DeliveryStatusBlocked = "blocked"
+)
+
+type Broadcast struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	AdminTelegramID   int64      `gorm:"not null" json:"admin_telegram_id"`
+	Text              string     `gorm:"not null" json:"text"`
+	Status            string     `gorm:"not null;default:draft" json:"status"`
+	ProgressChatID    int64      `json:"progress_chat_id"`
+	ProgressMessageID int        `json:"progress_message_id"`
+	Total             int        `json:"total"`
+	Sent              int        `json:"sent"`
+	Failed            int        `json:"failed"`
+	Blocked           int        `json:"blocked"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	CompletedAt       *time.Time `json:"completed_at"`
+}
+
+func (Broadcast) TableName() string {
+	return "workouts.broadcasts"
+}
+
+type BroadcastDelivery struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	BroadcastID uuid.UUID  `gorm:"type:uuid;not null;index" json:"broadcast_id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	TelegramID  int64      `gorm:"not null" json:"telegram_id"`
+	Status      string     `gorm:"not null;default:pending" json:"status"`
+	Error       string     `json:"error"`
+	SentAt      *time.Time `json:"sent_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (BroadcastDelivery) TableName() string {
+	return "workouts.broadcast_deliveries"
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarToken holds the SHA-256 of the secret in the URL of the user's
+// calendar feed; the secret itself is only shown when it is made.
+type CalendarToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	TokenHash string    `gorm:"not null;uniqueIndex" json:"token_hash"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (CalendarToken) TableName() string {
+	return "workouts.calendar_tokens"
+}
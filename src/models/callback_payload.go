@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// CallbackPayload holds callback data too large for an inline button.
+type CallbackPayload struct {
+	Key       string    `gorm:"primaryKey" json:"key"`
+	Payload   string    `gorm:"not null" json:"payload"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (CallbackPayload) TableName() string {
+	return "workouts.callback_payloads"
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardioEntry is a run, ride, row or similar activity. Distance is zero
+// when it was not measured; HeartRate is the average and Effort the
+// perceived effort from 1 to 10, zero when not given. Entries logged in a
+// session keep it. Source is the file format of imported activities, empty
+// for ones typed in.
+type CardioEntry struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ExerciseID      uuid.UUID  `gorm:"type:uuid;not null" json:"exercise_id"`
+	Exercise        Exercise   `gorm:"foreignKey:ExerciseID" json:"exercise"`
+	SessionID       *uuid.UUID `gorm:"type:uuid" json:"session_id"`
+	StartedAt       time.Time  `gorm:"not null" json:"started_at"`
+	DurationSeconds int        `gorm:"not null" json:"duration_seconds"`
+	DistanceMeters  float64    `gorm:"not null;default:0" json:"distance_meters"`
+	HeartRate       int        `gorm:"not null;default:0" json:"heart_rate"`
+	Effort          int        `gorm:"not null;default:0" json:"effort"`
+	Source          string     `gorm:"not null;default:''" json:"source,omitempty"`
+	ElevationGain   float64    `gorm:"not null;default:0" json:"elevation_gain,omitempty"`
+	MaxHeartRate    int        `gorm:"not null;default:0" json:"max_heart_rate,omitempty"`
+	HeartRates      []int      `gorm:"serializer:json" json:"heart_rates,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func (CardioEntry) TableName() string {
+	return "workouts.cardio_entries"
+}
+
+// Duration is how long the activity took.
+func (e *CardioEntry) Duration() time.Duration {
+	return time.Duration(e.DurationSeconds) * time.Second
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CoachInvite is the token of a coach's invite link.
+type CoachInvite struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	CoachID   uuid.UUID `gorm:"type:uuid;uniqueIndex;not null" json:"coach_id"`
+	Token     string    `gorm:"uniqueIndex;not null" json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (CoachInvite) TableName() string {
+	return "workouts.coach_invites"
+}
+
+// CoachClient lets a coach see and manage the client's training. A client
+// has at most one coach. CheckedOn is the last day checked for missed
+// program workouts.
+type CoachClient struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	CoachID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"coach_id"`
+	Coach     User       `gorm:"foreignKey:CoachID" json:"coach"`
+	ClientID  uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null" json:"client_id"`
+	Client    User       `gorm:"foreignKey:ClientID" json:"client"`
+	CheckedOn *time.Time `gorm:"type:date" json:"checked_on"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (CoachClient) TableName() string {
+	return "workouts.coach_clients"
+}
+
+type SessionComment struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SessionID uuid.UUID `gorm:"type:uuid;not null;index" json:"session_id"`
+	CoachID   uuid.UUID `gorm:"type:uuid;not null" json:"coach_id"`
+	Text      string    `gorm:"not null" json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (SessionComment) TableName() string {
+	return "workouts.session_comments"
+}
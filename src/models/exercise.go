@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CategoryCompound   = "compound"
+	CategoryIsolation  = "isolation"
+	CategoryStrength   = "strength"
+	CategoryCardio     = "cardio"
+	CategoryBodyweight = "bodyweight"
+	CategoryHIIT       = "hiit"
+	CategoryEndurance  = "endurance"
+)
+
+type Exercise struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Slug        string    `gorm:"uniqueIndex;not null" json:"slug"`
+	NameRU      string    `gorm:"column:name_ru;not null" json:"name_ru"`
+	NameEN      string    `gorm:"column:name_en;not null" json:"name_en"`
+	Category    string    `gorm:"not null" json:"category"`
+	MuscleGroup string    `gorm:"not null" json:"muscle_group"`
+	Equipment   string    `gorm:"not null" json:"equipment"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (Exercise) TableName() string {
+	return "workouts.exercises"
+}
+
+// Cardio reports whether the exercise is logged as an activity with a
+// duration and distance rather than as sets.
+func (e *Exercise) Cardio() bool {
+	return e.Category == CategoryCardio || e.Category == CategoryEndurance
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MediaKindImage     = "image"
+	MediaKindAnimation = "animation"
+	MediaKindVideo     = "video"
+)
+
+// ExerciseMedia is a technique image, GIF or video stored in S3. The
+// Telegram file ID is cached after the first send so the file is not
+// uploaded to Telegram again.
+type ExerciseMedia struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ExerciseID     uuid.UUID `gorm:"type:uuid;not null;index" json:"exercise_id"`
+	Kind           string    `gorm:"not null" json:"kind"`
+	ObjectKey      string    `gorm:"uniqueIndex;not null" json:"object_key"`
+	ContentType    string    `gorm:"not null" json:"content_type"`
+	TelegramFileID string    `json:"telegram_file_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (ExerciseMedia) TableName() string {
+	return "workouts.exercise_media"
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How a user appears in groups: with name and all numbers, without the
+// volume lifted, or without the name.
+const (
+	GroupPrivacyPublic     = "public"
+	GroupPrivacyHideVolume = "hide_volume"
+	GroupPrivacyAnonymous  = "anonymous"
+)
+
+// GroupPrivacies lists the privacy options in the order they are offered.
+var GroupPrivacies = []string{GroupPrivacyPublic, GroupPrivacyHideVolume, GroupPrivacyAnonymous}
+
+// Group is a group chat the bot is in. Language is the locale of its
+// posts, taken from whoever used the bot there first.
+type Group struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ChatID    int64     `gorm:"uniqueIndex;not null" json:"chat_id"`
+	Title     string    `gorm:"not null;default:''" json:"title"`
+	Language  string    `gorm:"not null;default:''" json:"language"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Group) TableName() string {
+	return "workouts.groups"
+}
+
+// GroupMember is a user who opted in to a group's leaderboards and
+// challenges.
+type GroupMember struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	GroupID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_group_members_group_user" json:"group_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_group_members_group_user" json:"user_id"`
+	User      User      `gorm:"foreignKey:UserID" json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (GroupMember) TableName() string {
+	return "workouts.group_members"
+}
+
+// Challenge is a group challenge running from StartsOn to EndsOn, both
+// days included. Kind names the challenge; PostedOn is the last day whose
+// progress was posted to the group.
+type Challenge struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	GroupID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"group_id"`
+	Group     Group      `gorm:"foreignKey:GroupID" json:"group"`
+	Kind      string     `gorm:"not null" json:"kind"`
+	StartsOn  time.Time  `gorm:"type:date;not null" json:"starts_on"`
+	EndsOn    time.Time  `gorm:"type:date;not null" json:"ends_on"`
+	PostedOn  *time.Time `gorm:"type:date" json:"posted_on"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (Challenge) TableName() string {
+	return "workouts.challenges"
+}
+
+type ChallengeParticipant struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ChallengeID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_challenge_participants_challenge_user" json:"challenge_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_challenge_participants_challenge_user" json:"user_id"`
+	User        User      `gorm:"foreignKey:UserID" json:"user"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (ChallengeParticipant) TableName() string {
+	return "workouts.challenge_participants"
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportAlias is the catalog exercise the user chose for a name used by
+// another app. A nil ExerciseID leaves the name out of imports.
+type ImportAlias struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_import_alias_name" json:"user_id"`
+	Name       string     `gorm:"not null;uniqueIndex:idx_import_alias_name" json:"name"`
+	ExerciseID *uuid.UUID `gorm:"type:uuid" json:"exercise_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (ImportAlias) TableName() string {
+	return "workouts.import_aliases"
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PendingAction is a destructive action waiting for the user to confirm it.
+type PendingAction struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TelegramID int64     `gorm:"not null;index" json:"telegram_id"`
+	Action     string    `gorm:"not null" json:"action"`
+	Payload    string    `json:"payload"`
+	ExpiresAt  time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (PendingAction) TableName() string {
+	return "workouts.pending_actions"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Where a training max came from: typed in, computed from a tested set or
+// raised from sets logged in sessions.
+const (
+	TrainingMaxManual   = "manual"
+	TrainingMaxTest     = "test"
+	TrainingMaxEstimate = "estimate"
+)
+
+// TrainingMax is the weight program percentages are taken from, usually
+// about 90% of the one-rep max. OneRepMax is the best tested or estimated
+// 1RM, 0 when unknown.
+type TrainingMax struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_training_maxes_user_exercise" json:"user_id"`
+	ExerciseID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_training_maxes_user_exercise" json:"exercise_id"`
+	Exercise   Exercise  `gorm:"foreignKey:ExerciseID" json:"exercise"`
+	Weight     float64   `gorm:"not null" json:"weight"`
+	OneRepMax  float64   `gorm:"column:one_rep_max;not null;default:0" json:"one_rep_max"`
+	Source     string    `gorm:"not null;default:manual" json:"source"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (TrainingMax) TableName() string {
+	return "workouts.training_maxes"
+}
+
+// ProgramEnrollment is the user's place in a periodized program. Week and
+// Day are zero-based positions in the program; Cycle counts from 1.
+type ProgramEnrollment struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Program   string    `gorm:"not null" json:"program"`
+	Cycle     int       `gorm:"not null;default:1" json:"cycle"`
+	Week      int       `gorm:"not null;default:0" json:"week"`
+	Day       int       `gorm:"not null;default:0" json:"day"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (ProgramEnrollment) TableName() string {
+	return "workouts.program_enrollments"
+}
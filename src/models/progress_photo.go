@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PoseFront = "front"
+	PoseSide  = "side"
+	PoseBack  = "back"
+)
+
+// ProgressPhoto is a user's body photo kept in a private per-user prefix of
+// the bucket. Only the owner ever gets it, through the cached file ID or a
+// short-lived presigned URL.
+type ProgressPhoto struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Pose           string    `gorm:"not null" json:"pose"`
+	ObjectKey      string    `gorm:"uniqueIndex;not null" json:"object_key"`
+	TelegramFileID string    `json:"telegram_file_id"`
+	TakenOn        time.Time `gorm:"type:date;not null" json:"taken_on"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (ProgressPhoto) TableName() string {
+	return "workouts.progress_photos"
+}
@@ -6,15 +6,47 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	GoalMuscleGain = "muscle_gain"
+	GoalStrength   = "strength"
+	GoalEndurance  = "endurance"
+	GoalWeightLoss = "weight_loss"
+)
+
+// Progression schemes pick the next targets of an exercise.
+const (
+	ProgressionLinear = "linear"
+	ProgressionDouble = "double"
+	ProgressionRPE    = "rpe"
+)
+
+// Units of the user's plates and dumbbells.
+const (
+	UnitKg = "kg"
+	UnitLb = "lb"
+)
+
+// User is a bot user. Plates lists the user's plate sizes in PlateUnit,
+// empty for a standard set; Warmups turns warm-up sets on. GroupPrivacy is
+// how the user appears in groups.
 type User struct {
-	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	TelegramID int64     `gorm:"uniqueIndex;not null" json:"telegram_id"`
-	Username   string    `json:"username"`
-	FirstName  string    `json:"first_name"`
-	LastName   string    `json:"last_name"`
-	Experience int       `gorm:"default:1" json:"experience"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TelegramID   int64      `gorm:"uniqueIndex;not null" json:"telegram_id"`
+	Username     string     `json:"username"`
+	FirstName    string     `json:"first_name"`
+	LastName     string     `json:"last_name"`
+	Experience   int        `gorm:"default:1" json:"experience"`
+	Goal         string     `json:"goal"`
+	Progression  string     `gorm:"default:double" json:"progression"`
+	PlateUnit    string     `gorm:"not null;default:kg" json:"plate_unit"`
+	Plates       string     `gorm:"not null;default:''" json:"plates"`
+	Warmups      bool       `gorm:"not null;default:true" json:"warmups"`
+	GroupPrivacy string     `gorm:"not null;default:public" json:"group_privacy"`
+	LanguageCode string     `json:"language_code"`
+	Language     string     `json:"language"`
+	BlockedAt    *time.Time `json:"blocked_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
 
 func (User) TableName() string {
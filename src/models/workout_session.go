@@ -0,0 +1,228 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkoutSession is a workout being performed or already done. Exercises are
+// copied from the template when the session starts, so later template edits
+// do not change history. Sessions of a program day keep the enrollment so
+// finishing them moves the program on. Sessions imported from another app
+// keep the key identifying them in its export.
+type WorkoutSession struct {
+	ID           uuid.UUID         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID         `gorm:"type:uuid;not null;index" json:"user_id"`
+	TemplateID   *uuid.UUID        `gorm:"type:uuid" json:"template_id"`
+	EnrollmentID *uuid.UUID        `gorm:"type:uuid" json:"enrollment_id"`
+	ImportKey    *string           `json:"import_key,omitempty"`
+	Name         string            `gorm:"not null" json:"name"`
+	Exercises    []SessionExercise `gorm:"foreignKey:SessionID" json:"exercises"`
+	StartedAt    time.Time         `gorm:"not null" json:"started_at"`
+	FinishedAt   *time.Time        `json:"finished_at"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+func (WorkoutSession) TableName() string {
+	return "workouts.workout_sessions"
+}
+
+// Set kinds. Drop and rest-pause sets extend the set they are linked to.
+const (
+	SetKindDrop      = "drop"
+	SetKindRestPause = "rest_pause"
+)
+
+// Current returns the first exercise with sets left, or nil when all are
+// done. Within a superset or circuit the exercises take turns: the one
+// with the fewest rounds done goes next.
+func (s *WorkoutSession) Current() *SessionExercise {
+	for i := range s.Exercises {
+		if s.Exercises[i].Done() {
+			continue
+		}
+		first, last := s.Group(i)
+		current := &s.Exercises[i]
+		for j := first; j <= last; j++ {
+			exercise := &s.Exercises[j]
+			if !exercise.Done() && exercise.Round() < current.Round() {
+				current = exercise
+			}
+		}
+		return current
+	}
+	return nil
+}
+
+// Group returns the first and last index of the superset or circuit the
+// exercise at index belongs to; both are index for a single exercise.
+func (s *WorkoutSession) Group(index int) (int, int) {
+	first, last := index, index
+	for first > 0 && s.Exercises[first-1].Linked {
+		first--
+	}
+	for last < len(s.Exercises)-1 && s.Exercises[last].Linked {
+		last++
+	}
+	return first, last
+}
+
+// LastExercise returns the exercise with the most recently logged set,
+// the one a drop set or a rest-pause set extends, or nil.
+func (s *WorkoutSession) LastExercise() *SessionExercise {
+	var last *SessionExercise
+	var latest time.Time
+	for i := range s.Exercises {
+		exercise := &s.Exercises[i]
+		for _, set := range exercise.Sets {
+			if set.Skipped || set.Warmup || set.Reps == 0 {
+				continue
+			}
+			if last == nil || set.CreatedAt.After(latest) {
+				last, latest = exercise, set.CreatedAt
+			}
+		}
+	}
+	return last
+}
+
+// Volume is the total weight lifted in completed working sets.
+func (s *WorkoutSession) Volume() float64 {
+	var volume float64
+	for _, exercise := range s.Exercises {
+		for _, set := range exercise.WorkSets() {
+			if !set.Skipped {
+				volume += float64(set.Reps) * set.Weight
+			}
+		}
+	}
+	return volume
+}
+
+// SessionExercise is an exercise in a session. Plan, when set, prescribes
+// each working set separately and takes precedence over the targets.
+// Warmup sets come before the working sets and do not count towards
+// TargetSets. Linked and WorkSeconds are copied from the template.
+type SessionExercise struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SessionID    uuid.UUID    `gorm:"type:uuid;not null;index" json:"session_id"`
+	ExerciseID   uuid.UUID    `gorm:"type:uuid;not null" json:"exercise_id"`
+	Exercise     Exercise     `gorm:"foreignKey:ExerciseID" json:"exercise"`
+	Position     int          `gorm:"not null" json:"position"`
+	TargetSets   int          `gorm:"not null;default:3" json:"target_sets"`
+	TargetReps   int          `gorm:"not null;default:10" json:"target_reps"`
+	TargetWeight float64      `gorm:"not null;default:0" json:"target_weight"`
+	RestSeconds  int          `gorm:"not null;default:90" json:"rest_seconds"`
+	WorkSeconds  int          `gorm:"not null;default:0" json:"work_seconds"`
+	Linked       bool         `gorm:"not null;default:false" json:"linked"`
+	Plan         []PlannedSet `gorm:"serializer:json" json:"plan,omitempty"`
+	Warmup       []PlannedSet `gorm:"serializer:json" json:"warmup,omitempty"`
+	Sets         []SessionSet `gorm:"foreignKey:SessionExerciseID" json:"sets"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+func (SessionExercise) TableName() string {
+	return "workouts.session_exercises"
+}
+
+// Done reports whether every warm-up and target set was logged or skipped.
+func (e *SessionExercise) Done() bool {
+	return e.Logged() >= len(e.Warmup)+e.TargetSets
+}
+
+// Logged counts the sets logged so far, leaving out drop and rest-pause
+// sets, which extend another set.
+func (e *SessionExercise) Logged() int {
+	count := 0
+	for _, set := range e.Sets {
+		if set.ParentID == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Round is how many working sets were logged, negative while warm-ups are
+// left.
+func (e *SessionExercise) Round() int {
+	return e.Logged() - len(e.Warmup)
+}
+
+// LastWorkSet returns the latest logged working set that is not a drop or
+// rest-pause set, or nil. Drop and rest-pause sets extend this set.
+func (e *SessionExercise) LastWorkSet() *SessionSet {
+	for i := len(e.Sets) - 1; i >= 0; i-- {
+		set := &e.Sets[i]
+		if set.ParentID == nil && !set.Skipped && !set.Warmup {
+			return set
+		}
+	}
+	return nil
+}
+
+// Intervals reports whether the sets are timed intervals.
+func (e *SessionExercise) Intervals() bool {
+	return e.WorkSeconds > 0
+}
+
+// Target returns what set number, counting from 1 and including warm-ups,
+// should be.
+func (e *SessionExercise) Target(number int) PlannedSet {
+	if number >= 1 && number <= len(e.Warmup) {
+		return e.Warmup[number-1]
+	}
+	number -= len(e.Warmup)
+	if number >= 1 && number <= len(e.Plan) {
+		return e.Plan[number-1]
+	}
+	return PlannedSet{Reps: e.TargetReps, Weight: e.TargetWeight}
+}
+
+// WorkSets returns the logged sets without warm-ups.
+func (e *SessionExercise) WorkSets() []SessionSet {
+	sets := make([]SessionSet, 0, len(e.Sets))
+	for _, set := range e.Sets {
+		if !set.Warmup {
+			sets = append(sets, set)
+		}
+	}
+	return sets
+}
+
+// PlannedSet is a set prescribed by a program or a warm-up. AMRAP sets
+// ask for as many reps as possible, at least Reps. Weight is in kilograms;
+// Unit is the unit the set was loaded in and is shown in, kilograms when
+// empty.
+type PlannedSet struct {
+	Reps   int     `json:"reps"`
+	Weight float64 `json:"weight"`
+	Unit   string  `json:"unit,omitempty"`
+	AMRAP  bool    `json:"amrap,omitempty"`
+	Warmup bool    `json:"warmup,omitempty"`
+}
+
+// SessionSet is a logged set. RPE is the rated effort from 6 to 10, or 0
+// when the set was not rated. Warm-up sets are left out of volume and
+// progression. Drop and rest-pause sets share the number of their parent
+// set. Seconds is the duration of an interval.
+type SessionSet struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SessionExerciseID uuid.UUID  `gorm:"type:uuid;not null;index" json:"session_exercise_id"`
+	Number            int        `gorm:"not null" json:"number"`
+	Reps              int        `gorm:"not null;default:0" json:"reps"`
+	Weight            float64    `gorm:"not null;default:0" json:"weight"`
+	Skipped           bool       `gorm:"not null;default:false" json:"skipped"`
+	RPE               float64    `gorm:"column:rpe;not null;default:0" json:"rpe"`
+	Warmup            bool       `gorm:"not null;default:false" json:"warmup"`
+	Kind              string     `gorm:"not null;default:''" json:"kind,omitempty"`
+	ParentID          *uuid.UUID `gorm:"type:uuid" json:"parent_id,omitempty"`
+	Seconds           int        `gorm:"not null;default:0" json:"seconds"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+func (SessionSet) TableName() string {
+	return "workouts.session_sets"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WorkoutTypeSplit    = "split"
+	WorkoutTypePushPull = "push_pull"
+	WorkoutTypeFullBody = "full_body"
+	WorkoutTypeCustom   = "custom"
+)
+
+type WorkoutTemplate struct {
+	ID        uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID          `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name      string             `gorm:"not null" json:"name"`
+	Type      string             `gorm:"not null;default:custom" json:"type"`
+	Exercises []TemplateExercise `gorm:"foreignKey:TemplateID" json:"exercises"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+func (WorkoutTemplate) TableName() string {
+	return "workouts.workout_templates"
+}
+
+// TemplateExercise is an exercise in a template. Linked joins it with the
+// next exercise into a superset or circuit; WorkSeconds, when set, makes
+// each set a timed interval followed by RestSeconds of rest.
+type TemplateExercise struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TemplateID   uuid.UUID `gorm:"type:uuid;not null;index" json:"template_id"`
+	ExerciseID   uuid.UUID `gorm:"type:uuid;not null" json:"exercise_id"`
+	Exercise     Exercise  `gorm:"foreignKey:ExerciseID" json:"exercise"`
+	Position     int       `gorm:"not null" json:"position"`
+	TargetSets   int       `gorm:"not null;default:3" json:"target_sets"`
+	TargetReps   int       `gorm:"not null;default:10" json:"target_reps"`
+	TargetWeight float64   `gorm:"not null;default:0" json:"target_weight"`
+	RestSeconds  int       `gorm:"not null;default:90" json:"rest_seconds"`
+	WorkSeconds  int       `gorm:"not null;default:0" json:"work_seconds"`
+	Linked       bool      `gorm:"not null;default:false" json:"linked"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (TemplateExercise) TableName() string {
+	return "workouts.template_exercises"
+}
@@ -0,0 +1,102 @@
+package router
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"workouts_bot/src/database"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// authScheme prefixes the Telegram Mini App init data in the
+	// Authorization header.
+	authScheme = "tma "
+
+	// maxAuthAge is how long signed init data is accepted after Telegram
+	// issued it.
+	maxAuthAge = 24 * time.Hour
+
+	userKey = "user"
+)
+
+var errUnauthorized = errors.New("invalid init data")
+
+// telegramAuth lets through requests carrying init data Telegram signed
+// for the bot and stores the user they come from in the context.
+func telegramAuth(botToken string, db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, authScheme) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing init data"})
+			return
+		}
+
+		telegramID, err := validateInitData(strings.TrimPrefix(header, authScheme), botToken, time.Now())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		user, err := database.GetUserByTelegramID(telegramID, db)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+
+		c.Set(userKey, user)
+		c.Next()
+	}
+}
+
+// validateInitData checks the init data hash as Telegram describes for
+// Mini Apps and returns the Telegram ID of the user it was issued to.
+func validateInitData(initData string, botToken string, now time.Time) (int64, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return 0, errUnauthorized
+	}
+	hash := values.Get("hash")
+	if hash == "" {
+		return 0, errUnauthorized
+	}
+
+	lines := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			lines = append(lines, key+"="+values.Get(key))
+		}
+	}
+	sort.Strings(lines)
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(botToken))
+	mac := hmac.New(sha256.New, secret.Sum(nil))
+	mac.Write([]byte(strings.Join(lines, "\n")))
+	expected, err := hex.DecodeString(hash)
+	if err != nil || !hmac.Equal(mac.Sum(nil), expected) {
+		return 0, errUnauthorized
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil || now.Sub(time.Unix(authDate, 0)) > maxAuthAge {
+		return 0, errors.New("init data expired")
+	}
+
+	var user struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(values.Get("user")), &user); err != nil || user.ID == 0 {
+		return 0, errUnauthorized
+	}
+	return user.ID, nil
+}
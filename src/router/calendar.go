@@ -0,0 +1,52 @@
+package router
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/services/calendar"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// calendarFeed serves the iCalendar feed whose token is in the file name.
+// The token is the only credential, so calendar apps can subscribe.
+func calendarFeed(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutSuffix(c.Param("file"), calendar.FileExtension)
+		if !ok || token == "" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		user, err := database.GetUserByCalendarTokenHash(calendar.HashToken(token), db)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		// Feeds are small; building one first lets a failure be an error
+		// status rather than a truncated calendar.
+		var feed bytes.Buffer
+		if err := calendar.Write(&feed, user, time.Now(), db); err != nil {
+			logger.WithFields(logrus.Fields{
+				"user_id": user.TelegramID,
+				"error":   err,
+			}).Error("Failed to write calendar feed")
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		c.Header("Cache-Control", "private, max-age=900")
+		c.Data(http.StatusOK, calendar.ContentType, feed.Bytes())
+	}
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/export"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// exportData streams the user's data in the format of the format query
+// parameter, JSON by default.
+func exportData(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet(userKey).(*models.User)
+		format := c.DefaultQuery("format", export.FormatJSON)
+		if !slices.Contains(export.Formats, format) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": export.ErrUnknownFormat.Error()})
+			return
+		}
+
+		c.Header("Content-Type", export.ContentType(format))
+		c.Header("Content-Disposition", fmt.Sprintf(
+			"attachment; filename=%q", export.FileName(format, time.Now()),
+		))
+		c.Status(http.StatusOK)
+
+		// Headers are sent by now, so a failure can only cut the body short.
+		if err := export.Write(c.Writer, format, user, db); err != nil {
+			logger.WithFields(logrus.Fields{
+				"user_id": user.TelegramID,
+				"format":  format,
+				"error":   err,
+			}).Error("Failed to export user data")
+			_ = c.Error(err)
+		}
+	}
+}
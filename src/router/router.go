@@ -1,22 +1,63 @@
 package router
 
 import (
+	"fmt"
 	"log"
+	"strings"
+	"time"
 	"workouts_bot/src/config"
+	"workouts_bot/src/services/calendar"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 const (
 	defaultPath = "/api/v1"
+
+	// redactedToken stands for the calendar token in logged paths.
+	redactedToken = "REDACTED"
 )
 
-func NewRouter(cfg *config.Config) *gin.Engine {
+func NewRouter(cfg *config.Config, db *gorm.DB) *gin.Engine {
 	engine := gin.New()
 
 	log.Printf("Service started port %d", cfg.Port)
 
-	engine.Use(gin.Logger())
+	engine.Use(gin.LoggerWithFormatter(logFormat))
+
+	engine.GET(calendar.Path+":file", calendarFeed(db))
+
+	api := engine.Group(defaultPath, telegramAuth(cfg.BotToken, db))
+	api.GET("/export", exportData(db))
 
 	return engine
 }
+
+// logFormat is gin's default log line, except that the token of a
+// calendar feed is left out: it is the feed's only credential.
+func logFormat(param gin.LogFormatterParams) string {
+	var statusColor, methodColor, resetColor string
+	if param.IsOutputColor() {
+		statusColor = param.StatusCodeColor()
+		methodColor = param.MethodColor()
+		resetColor = param.ResetColor()
+	}
+
+	path := param.Path
+	if strings.HasPrefix(path, calendar.Path) {
+		path = calendar.Path + redactedToken + calendar.FileExtension
+	}
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		statusColor, param.StatusCode, resetColor,
+		param.Latency,
+		param.ClientIP,
+		methodColor, param.Method, resetColor,
+		path,
+		param.ErrorMessage,
+	)
+}
@@ -0,0 +1,42 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"workouts_bot/src/services/calendar"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLogFormatRedactsCalendarToken(t *testing.T) {
+	token, err := calendar.NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{calendar.URL("", token), calendar.Path + redactedToken + calendar.FileExtension},
+		{calendar.URL("", token) + "?refresh=1", calendar.Path + redactedToken + calendar.FileExtension},
+		{defaultPath + "/export", defaultPath + "/export"},
+	}
+
+	for _, tt := range tests {
+		line := logFormat(gin.LogFormatterParams{
+			TimeStamp:  time.Now(),
+			StatusCode: http.StatusOK,
+			Method:     http.MethodGet,
+			Path:       tt.path,
+		})
+		if strings.Contains(line, token) {
+			t.Errorf("log line %q has the token", line)
+		}
+		if !strings.Contains(line, `"`+tt.want+`"`) {
+			t.Errorf("log line %q, want path %q", line, tt.want)
+		}
+	}
+}
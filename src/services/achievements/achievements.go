@@ -0,0 +1,119 @@
+package achievements
+
+import (
+	"slices"
+	"time"
+	"workouts_bot/src/services/training"
+)
+
+// Facts is what rules are evaluated on. Days are the start times of the
+// finished sessions; Schedule is the weekdays of the user's program, empty
+// without one.
+type Facts struct {
+	Sessions int
+	Volume   float64
+	Heaviest map[string]float64
+	Days     []time.Time
+	Schedule []time.Weekday
+}
+
+// Value is the rule's metric for the facts as of now.
+func (r *Rule) Value(facts *Facts, now time.Time) float64 {
+	switch r.Metric {
+	case MetricSessions:
+		return float64(facts.Sessions)
+	case MetricVolume:
+		return facts.Volume
+	case MetricLift:
+		return facts.Heaviest[r.Exercise]
+	case MetricWeekStreak:
+		return float64(WeekStreak(facts.Days, WeeklySessions, now))
+	case MetricPlannedStreak:
+		return float64(PlannedStreak(facts.Days, facts.Schedule, now))
+	}
+	return 0
+}
+
+// Reached returns the rules the facts meet that are not among earned, by
+// slug, in rule order.
+func Reached(rules []Rule, facts *Facts, earned []string, now time.Time) []Rule {
+	var reached []Rule
+	for i := range rules {
+		rule := &rules[i]
+		if slices.Contains(earned, rule.Slug) {
+			continue
+		}
+		if rule.Value(facts, now) >= rule.Goal {
+			reached = append(reached, *rule)
+		}
+	}
+	return reached
+}
+
+// Find returns the rule with the slug.
+func Find(slug string) (*Rule, bool) {
+	for i := range Rules {
+		if Rules[i].Slug == slug {
+			return &Rules[i], true
+		}
+	}
+	return nil, false
+}
+
+// WeekStreak counts the weeks in a row, up to the current one, with at
+// least perWeek sessions. The current week counts once it has enough but
+// does not break the streak before it ends.
+func WeekStreak(days []time.Time, perWeek int, now time.Time) int {
+	counts := make(map[time.Time]int)
+	for _, day := range days {
+		counts[training.WeekStart(day.In(now.Location()))]++
+	}
+
+	week := training.WeekStart(now)
+	streak := 0
+	if counts[week] >= perWeek {
+		streak++
+	}
+	for week = week.AddDate(0, 0, -7); counts[week] >= perWeek; week = week.AddDate(0, 0, -7) {
+		streak++
+	}
+	return streak
+}
+
+// PlannedStreak counts the planned weekdays in a row, up to today, that
+// had a session. Today counts once trained but does not break the streak
+// before it ends.
+func PlannedStreak(days []time.Time, schedule []time.Weekday, now time.Time) int {
+	if len(schedule) == 0 || len(days) == 0 {
+		return 0
+	}
+	trained := make(map[time.Time]bool, len(days))
+	first := now
+	for _, day := range days {
+		day = dateOf(day.In(now.Location()))
+		trained[day] = true
+		if day.Before(first) {
+			first = day
+		}
+	}
+
+	today := dateOf(now)
+	streak := 0
+	for day := today; !day.Before(first); day = day.AddDate(0, 0, -1) {
+		if !slices.Contains(schedule, day.Weekday()) {
+			continue
+		}
+		switch {
+		case trained[day]:
+			streak++
+		case day.Equal(today):
+		default:
+			return streak
+		}
+	}
+	return streak
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,225 @@
+package achievements
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// msk is three hours ahead of UTC, where sessions late on Sunday evening
+// are already on Monday.
+var msk = time.FixedZone("MSK", 3*60*60)
+
+// now is Wednesday, 21 October 2026; its week starts on Monday the 19th.
+var now = time.Date(2026, 10, 21, 10, 0, 0, 0, time.UTC)
+
+// schedule is a program trained on Mondays, Wednesdays and Fridays.
+var schedule = []time.Weekday{time.Monday, time.Wednesday, time.Friday}
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func times(values ...string) []time.Time {
+	days := make([]time.Time, len(values))
+	for i, value := range values {
+		days[i] = at(value)
+	}
+	return days
+}
+
+// fullWeeks is a history of WeeklySessions evening sessions in each of the
+// weeks before now's.
+func fullWeeks(weeks int) []time.Time {
+	var days []time.Time
+	monday := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+	for week := 1; week <= weeks; week++ {
+		for day := range WeeklySessions {
+			days = append(days, monday.AddDate(0, 0, day-7*week))
+		}
+	}
+	return days
+}
+
+// plannedDays is a history of evening sessions on the last n days of the
+// schedule before today.
+func plannedDays(n int) []time.Time {
+	var days []time.Time
+	day := time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC)
+	for len(days) < n {
+		for _, weekday := range schedule {
+			if day.Weekday() == weekday {
+				days = append(days, day)
+			}
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return days
+}
+
+func history(days []time.Time) *Facts {
+	return &Facts{Sessions: len(days), Days: days}
+}
+
+func TestReachedRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		facts  *Facts
+		earned []string
+		want   bool
+	}{
+		{"no sessions", "first_session", history(nil), nil, false},
+		{"first session", "first_session", history(times("2026-10-20 18:00")), nil, true},
+		{"already earned", "first_session", history(times("2026-10-20 18:00")), []string{"first_session"}, false},
+		{"nine sessions", "sessions_10", history(plannedDays(9)), nil, false},
+		{"ten sessions", "sessions_10", history(plannedDays(10)), nil, true},
+		{"short of 10 t", "volume_10t", &Facts{Volume: 9_999.5}, nil, false},
+		{"10 t lifted", "volume_10t", &Facts{Volume: 10_000}, nil, true},
+		{"bench short of 100", "bench_100", &Facts{Heaviest: map[string]float64{"bench_press": 97.5}}, nil, false},
+		{"bench 100", "bench_100", &Facts{Heaviest: map[string]float64{"bench_press": 100}}, nil, true},
+		{"squat 140", "squat_140", &Facts{Heaviest: map[string]float64{"squat": 140}}, nil, true},
+		{"deadlift of another exercise", "deadlift_180", &Facts{Heaviest: map[string]float64{"squat": 200}}, nil, false},
+		{"three full weeks", "week_streak_4", history(fullWeeks(3)), nil, false},
+		{"four full weeks", "week_streak_4", history(fullWeeks(4)), nil, true},
+		{
+			name:  "three full weeks and a full current one",
+			rule:  "week_streak_4",
+			facts: history(append(fullWeeks(3), times("2026-10-19 07:00", "2026-10-20 07:00", "2026-10-21 07:00")...)),
+			want:  true,
+		},
+		{"nine planned days", "planned_streak_10", &Facts{Days: plannedDays(9), Schedule: schedule}, nil, false},
+		{"ten planned days", "planned_streak_10", &Facts{Days: plannedDays(10), Schedule: schedule}, nil, true},
+		{"planned days without a program", "planned_streak_10", history(plannedDays(10)), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := Find(tt.rule)
+			if !ok {
+				t.Fatalf("no rule %q", tt.rule)
+			}
+			reached := Reached([]Rule{*rule}, tt.facts, tt.earned, now)
+			if got := len(reached) == 1; got != tt.want {
+				t.Errorf("reached = %v, want %v (value %v, goal %v)", got, tt.want, rule.Value(tt.facts, now), rule.Goal)
+			}
+		})
+	}
+}
+
+func TestReachedOrder(t *testing.T) {
+	facts := &Facts{
+		Sessions: 10,
+		Volume:   12_000,
+		Heaviest: map[string]float64{"squat": 150},
+		Days:     plannedDays(10),
+	}
+
+	var slugs []string
+	for _, rule := range Reached(Rules, facts, []string{"first_session"}, now) {
+		slugs = append(slugs, rule.Slug)
+	}
+	want := []string{"sessions_10", "volume_10t", "squat_140"}
+	if !reflect.DeepEqual(slugs, want) {
+		t.Errorf("reached %v, want %v", slugs, want)
+	}
+}
+
+func TestWeekStreak(t *testing.T) {
+	tests := []struct {
+		name string
+		days []time.Time
+		now  time.Time
+		want int
+	}{
+		{"no sessions", nil, now, 0},
+		{"current week not full yet", times("2026-10-19 18:00", "2026-10-20 18:00"), now, 0},
+		{"current week full", times("2026-10-19 07:00", "2026-10-20 07:00", "2026-10-21 07:00"), now, 1},
+		{"short current week keeps the streak", append(fullWeeks(2), at("2026-10-19 18:00")), now, 2},
+		{"missed week breaks the streak", append(fullWeeks(1), fullWeeks(3)[6:]...), now, 1},
+		{
+			name: "sunday night is the week before",
+			days: times("2026-10-12 18:00", "2026-10-13 18:00", "2026-10-18 23:59"),
+			now:  now,
+			want: 1,
+		},
+		{
+			name: "monday midnight starts the next week",
+			days: times("2026-10-12 18:00", "2026-10-13 18:00", "2026-10-19 00:00"),
+			now:  now,
+			want: 0,
+		},
+		{
+			name: "late sunday in utc is monday in moscow",
+			days: times("2026-10-12 15:00", "2026-10-13 15:00", "2026-10-18 22:30"),
+			now:  now.In(msk),
+			want: 0,
+		},
+		{
+			name: "week rolls over on monday",
+			days: fullWeeks(1),
+			now:  at("2026-10-26 00:00"),
+			want: 0,
+		},
+		{
+			name: "last day of the week",
+			days: fullWeeks(1),
+			now:  at("2026-10-25 23:59"),
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStreak(tt.days, WeeklySessions, tt.now); got != tt.want {
+				t.Errorf("WeekStreak = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlannedStreak(t *testing.T) {
+	tests := []struct {
+		name     string
+		days     []time.Time
+		schedule []time.Weekday
+		now      time.Time
+		want     int
+	}{
+		{"no program", plannedDays(3), nil, now, 0},
+		{"no sessions", nil, schedule, now, 0},
+		{"today not trained yet", plannedDays(3), schedule, now, 3},
+		{"today trained", append(plannedDays(3), at("2026-10-21 08:00")), schedule, now, 4},
+		{"missed planned day", times("2026-10-19 18:00", "2026-10-14 18:00"), schedule, now, 1},
+		{"unplanned day between", times("2026-10-19 18:00", "2026-10-17 11:00", "2026-10-16 18:00"), schedule, now, 2},
+		{"streak starts at the first session", times("2026-10-19 18:00"), schedule, now, 1},
+		{"sunday night is not monday", times("2026-10-18 23:59", "2026-10-16 18:00"), schedule, now, 0},
+		{"monday midnight is monday", times("2026-10-19 00:00", "2026-10-16 18:00"), schedule, now, 2},
+		{
+			name:     "late sunday in utc is monday in moscow",
+			days:     times("2026-10-18 22:30", "2026-10-16 15:00"),
+			schedule: schedule,
+			now:      now.In(msk),
+			want:     2,
+		},
+		{
+			name:     "planned day missed once it is over",
+			days:     plannedDays(3),
+			schedule: schedule,
+			now:      at("2026-10-22 00:00"),
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlannedStreak(tt.days, tt.schedule, tt.now); got != tt.want {
+				t.Errorf("PlannedStreak = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package achievements
+
+import (
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/programs"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Collect gathers what rules are evaluated on from the user's history.
+func Collect(userID uuid.UUID, db *gorm.DB) (*Facts, error) {
+	starts, err := database.ListSessionStarts(userID, db)
+	if err != nil {
+		return nil, err
+	}
+	volume, err := database.LifetimeVolume(userID, db)
+	if err != nil {
+		return nil, err
+	}
+	heaviest, err := database.HeaviestLifts(userID, Lifts(), db)
+	if err != nil {
+		return nil, err
+	}
+
+	facts := &Facts{
+		Sessions: len(starts),
+		Volume:   volume,
+		Heaviest: heaviest,
+		Days:     starts,
+	}
+
+	enrollment, err := database.FindProgramEnrollment(userID, db)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment != nil {
+		if program, ok := programs.Find(enrollment.Program); ok {
+			facts.Schedule = program.TrainingDays()
+		}
+	}
+	return facts, nil
+}
+
+// Award records the achievements the user's history now meets and
+// returns the new ones. It runs when a session is finished.
+func Award(userID uuid.UUID, db *gorm.DB) ([]Rule, error) {
+	facts, err := Collect(userID, db)
+	if err != nil {
+		return nil, err
+	}
+	earned, err := database.ListAchievements(userID, db)
+	if err != nil {
+		return nil, err
+	}
+	slugs := make([]string, len(earned))
+	for i := range earned {
+		slugs[i] = earned[i].Slug
+	}
+
+	now := time.Now()
+	reached := Reached(Rules, facts, slugs, now)
+	records := make([]models.Achievement, len(reached))
+	for i := range reached {
+		records[i] = models.Achievement{UserID: userID, Slug: reached[i].Slug, EarnedAt: now}
+	}
+	if err := database.SaveAchievements(records, db); err != nil {
+		return nil, err
+	}
+
+	if len(reached) > 0 {
+		logger.WithFields(logrus.Fields{
+			"user_id":      userID,
+			"achievements": len(reached),
+		}).Info("Achievements earned")
+	}
+	return reached, nil
+}
@@ -0,0 +1,66 @@
+package achievements
+
+// Metrics rules measure the training by.
+const (
+	// MetricSessions counts finished sessions.
+	MetricSessions = "sessions"
+	// MetricVolume is the lifetime tonnage of working sets in kilograms.
+	MetricVolume = "volume"
+	// MetricLift is the heaviest weight lifted for a rep on the exercise.
+	MetricLift = "lift"
+	// MetricWeekStreak counts weeks in a row with at least WeeklySessions
+	// sessions.
+	MetricWeekStreak = "week_streak"
+	// MetricPlannedStreak counts program days in a row trained on the day
+	// they were planned.
+	MetricPlannedStreak = "planned_streak"
+)
+
+// WeeklySessions is how many sessions make a week count towards a streak.
+const WeeklySessions = 3
+
+// Rule is an achievement: earned once the metric reaches Goal. Exercise is
+// the catalog slug MetricLift rules look at.
+type Rule struct {
+	Slug     string
+	Badge    string
+	Metric   string
+	Exercise string
+	Goal     float64
+}
+
+// Rules are every achievement, in the order they are shown. Slugs are
+// stored with earned achievements, so they must not change.
+var Rules = []Rule{
+	{Slug: "first_session", Badge: "🎉", Metric: MetricSessions, Goal: 1},
+	{Slug: "sessions_10", Badge: "🔟", Metric: MetricSessions, Goal: 10},
+	{Slug: "sessions_50", Badge: "🏅", Metric: MetricSessions, Goal: 50},
+	{Slug: "sessions_100", Badge: "💯", Metric: MetricSessions, Goal: 100},
+	{Slug: "sessions_500", Badge: "🏆", Metric: MetricSessions, Goal: 500},
+
+	{Slug: "volume_10t", Badge: "🧱", Metric: MetricVolume, Goal: 10_000},
+	{Slug: "volume_100t", Badge: "🚚", Metric: MetricVolume, Goal: 100_000},
+	{Slug: "volume_1000t", Badge: "🚂", Metric: MetricVolume, Goal: 1_000_000},
+
+	{Slug: "bench_100", Badge: "🏋️", Metric: MetricLift, Exercise: "bench_press", Goal: 100},
+	{Slug: "squat_140", Badge: "🦵", Metric: MetricLift, Exercise: "squat", Goal: 140},
+	{Slug: "deadlift_180", Badge: "⛓", Metric: MetricLift, Exercise: "deadlift", Goal: 180},
+
+	{Slug: "week_streak_4", Badge: "🔥", Metric: MetricWeekStreak, Goal: 4},
+	{Slug: "week_streak_12", Badge: "☄️", Metric: MetricWeekStreak, Goal: 12},
+	{Slug: "week_streak_52", Badge: "🌋", Metric: MetricWeekStreak, Goal: 52},
+
+	{Slug: "planned_streak_10", Badge: "📅", Metric: MetricPlannedStreak, Goal: 10},
+	{Slug: "planned_streak_30", Badge: "🗓", Metric: MetricPlannedStreak, Goal: 30},
+}
+
+// Lifts are the exercise slugs MetricLift rules need the heaviest weight of.
+func Lifts() []string {
+	var slugs []string
+	for _, rule := range Rules {
+		if rule.Metric == MetricLift {
+			slugs = append(slugs, rule.Exercise)
+		}
+	}
+	return slugs
+}
@@ -0,0 +1,237 @@
+package activities
+
+import (
+	"errors"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxFileSize bounds the files accepted for import.
+	MaxFileSize = 10 << 20
+
+	// maxSamples bounds the heart rate samples kept per activity; longer
+	// series are averaged down.
+	maxSamples = 500
+
+	earthRadius = 6371000.0
+
+	// elevationThreshold ignores altitude jitter when adding up the climb.
+	elevationThreshold = 3.0
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported activity file")
+	ErrInvalid     = errors.New("invalid activity file")
+	ErrEmpty       = errors.New("activity has no track points")
+)
+
+// Activity is a recorded workout read from a file. Sport is the catalog
+// slug of the exercise, empty when the file does not say; Distance and
+// ElevationGain are in meters.
+type Activity struct {
+	Sport         string
+	StartedAt     time.Time
+	Duration      time.Duration
+	Distance      float64
+	ElevationGain float64
+	HeartRates    []int
+}
+
+// AverageHeartRate is the mean of the samples, zero without any.
+func (a *Activity) AverageHeartRate() int {
+	if len(a.HeartRates) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, rate := range a.HeartRates {
+		sum += rate
+	}
+	return int(math.Round(float64(sum) / float64(len(a.HeartRates))))
+}
+
+// MaxHeartRate is the highest sample, zero without any.
+func (a *Activity) MaxHeartRate() int {
+	highest := 0
+	for _, rate := range a.HeartRates {
+		highest = max(highest, rate)
+	}
+	return highest
+}
+
+// point is a track sample. Fields the file leaves out are NaN, or zero for
+// the heart rate.
+type point struct {
+	Time      time.Time
+	Lat, Lon  float64
+	Altitude  float64
+	Distance  float64
+	HeartRate int
+}
+
+var parsers = map[string]func(io.Reader) (*Activity, error){
+	".gpx": parseGPX,
+	".tcx": parseTCX,
+	".fit": parseFIT,
+}
+
+// Supported reports whether the file name has an extension Parse reads.
+func Supported(name string) bool {
+	_, ok := parsers[strings.ToLower(filepath.Ext(name))]
+	return ok
+}
+
+// Format is the file format by the name's extension: "gpx", "tcx" or "fit".
+func Format(name string) string {
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+}
+
+// Parse reads a GPX, TCX or FIT file, chosen by the file name.
+func Parse(name string, r io.Reader) (*Activity, error) {
+	parse, ok := parsers[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	return parse(io.LimitReader(r, MaxFileSize))
+}
+
+// summarize builds the activity from its track. A distance the device
+// recorded wins over one measured along the coordinates.
+func summarize(sport string, points []point) (*Activity, error) {
+	var timed []point
+	for _, p := range points {
+		if !p.Time.IsZero() {
+			timed = append(timed, p)
+		}
+	}
+	if len(timed) < 2 {
+		return nil, ErrEmpty
+	}
+
+	first, last := timed[0], timed[len(timed)-1]
+	activity := &Activity{
+		Sport:     sport,
+		StartedAt: first.Time.UTC().Truncate(time.Second),
+		Duration:  last.Time.Sub(first.Time).Round(time.Second),
+	}
+	if activity.Duration <= 0 {
+		return nil, ErrInvalid
+	}
+
+	recorded, measured := 0.0, 0.0
+	climbFrom := math.NaN()
+	var previous *point
+	var rates []int
+	for i := range timed {
+		p := &timed[i]
+		if !math.IsNaN(p.Distance) {
+			recorded = max(recorded, p.Distance)
+		}
+		if previous != nil && located(previous) && located(p) {
+			measured += haversine(previous, p)
+		}
+		if located(p) {
+			previous = p
+		}
+
+		if !math.IsNaN(p.Altitude) {
+			switch {
+			case math.IsNaN(climbFrom) || p.Altitude < climbFrom:
+				climbFrom = p.Altitude
+			case p.Altitude-climbFrom >= elevationThreshold:
+				activity.ElevationGain += p.Altitude - climbFrom
+				climbFrom = p.Altitude
+			}
+		}
+		if p.HeartRate > 0 {
+			rates = append(rates, p.HeartRate)
+		}
+	}
+
+	activity.Distance = math.Round(measured)
+	if recorded > 0 {
+		activity.Distance = math.Round(recorded)
+	}
+	activity.ElevationGain = math.Round(activity.ElevationGain)
+	activity.HeartRates = downsample(rates, maxSamples)
+	return activity, nil
+}
+
+func located(p *point) bool {
+	return !math.IsNaN(p.Lat) && !math.IsNaN(p.Lon)
+}
+
+// haversine is the great-circle distance between two points in meters.
+func haversine(a, b *point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// downsample averages the samples into at most limit buckets.
+func downsample(samples []int, limit int) []int {
+	if len(samples) <= limit {
+		return samples
+	}
+	result := make([]int, limit)
+	for i := range result {
+		from := i * len(samples) / limit
+		to := (i + 1) * len(samples) / limit
+		sum := 0
+		for _, sample := range samples[from:to] {
+			sum += sample
+		}
+		result[i] = int(math.Round(float64(sum) / float64(to-from)))
+	}
+	return result
+}
+
+// sportSlugs maps the sport names GPX and TCX files use to catalog slugs.
+var sportSlugs = map[string]string{
+	"running":    "running",
+	"run":        "running",
+	"trail_run":  "running",
+	"biking":     "cycling",
+	"cycling":    "cycling",
+	"ride":       "cycling",
+	"walking":    "walking",
+	"walk":       "walking",
+	"hiking":     "hiking",
+	"hike":       "hiking",
+	"swimming":   "swimming",
+	"swim":       "swimming",
+	"rowing":     "rowing",
+	"row":        "rowing",
+	"elliptical": "elliptical",
+}
+
+func sportSlug(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	return sportSlugs[name]
+}
+
+// GuessSport picks the catalog slug for an activity whose file does not
+// name the sport, from its average speed.
+func GuessSport(activity *Activity) string {
+	hours := activity.Duration.Hours()
+	if hours <= 0 || activity.Distance <= 0 {
+		return "running"
+	}
+	speed := activity.Distance / 1000 / hours
+	switch {
+	case speed >= 16:
+		return "cycling"
+	case speed >= 7:
+		return "running"
+	default:
+		return "walking"
+	}
+}
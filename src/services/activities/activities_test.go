@@ -0,0 +1,217 @@
+package activities
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseFile(t *testing.T, name string) *Activity {
+	t.Helper()
+	file, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	activity, err := Parse(name, file)
+	if err != nil {
+		t.Fatalf("Parse %s: %v", name, err)
+	}
+	return activity
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		file       string
+		want       *Activity
+		averageHR  int
+		maxHR      int
+		guessSport string
+	}{
+		{
+			// No sport in the file; the distance is measured along the
+			// coordinates and the 2 m rise is jitter.
+			file: "walk.gpx",
+			want: &Activity{
+				StartedAt:     time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC),
+				Duration:      3 * time.Minute,
+				Distance:      334,
+				ElevationGain: 6,
+				HeartRates:    []int{90, 95, 100, 104},
+			},
+			averageHR:  97,
+			maxHR:      104,
+			guessSport: "walking",
+		},
+		{
+			// Laps are joined and the recorded distance wins.
+			file: "ride.tcx",
+			want: &Activity{
+				Sport:         "cycling",
+				StartedAt:     time.Date(2026, 3, 2, 17, 30, 0, 0, time.UTC),
+				Duration:      4 * time.Minute,
+				Distance:      2000,
+				ElevationGain: 8,
+				HeartRates:    []int{110, 140, 151},
+			},
+			averageHR:  134,
+			maxHR:      151,
+			guessSport: "cycling",
+		},
+		{
+			// A point without a GPS fix and the last one sent 20 s later
+			// with a compressed timestamp.
+			file: "run.fit",
+			want: &Activity{
+				Sport:         "running",
+				StartedAt:     time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC),
+				Duration:      2*time.Minute + 20*time.Second,
+				Distance:      600,
+				ElevationGain: 11,
+				HeartRates:    []int{120, 130, 140, 150},
+			},
+			averageHR:  135,
+			maxHR:      150,
+			guessSport: "running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			got := parseFile(t, tt.file)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse =\n%+v\nwant\n%+v", got, tt.want)
+			}
+			if rate := got.AverageHeartRate(); rate != tt.averageHR {
+				t.Errorf("AverageHeartRate = %d, want %d", rate, tt.averageHR)
+			}
+			if rate := got.MaxHeartRate(); rate != tt.maxHR {
+				t.Errorf("MaxHeartRate = %d, want %d", rate, tt.maxHR)
+			}
+			if sport := GuessSport(got); sport != tt.guessSport {
+				t.Errorf("GuessSport = %q, want %q", sport, tt.guessSport)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	onePoint := `<gpx><trk><trkseg>
+		<trkpt lat="55.75" lon="37.61"><time>2026-03-02T07:00:00Z</time></trkpt>
+	</trkseg></trk></gpx>`
+	backwards := `<gpx><trk><trkseg>
+		<trkpt lat="55.75" lon="37.61"><time>2026-03-02T07:01:00Z</time></trkpt>
+		<trkpt lat="55.76" lon="37.61"><time>2026-03-02T07:00:00Z</time></trkpt>
+	</trkseg></trk></gpx>`
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    error
+	}{
+		{"unknown extension", "run.csv", "Date,Distance\n", ErrUnsupported},
+		{"broken xml", "run.gpx", "<gpx><trk>", ErrInvalid},
+		{"one point", "run.gpx", onePoint, ErrEmpty},
+		{"time runs backwards", "run.gpx", backwards, ErrInvalid},
+		{"empty tcx", "ride.tcx", "<TrainingCenterDatabase/>", ErrEmpty},
+		{"not a fit file", "run.fit", "just some text, long enough for a header", ErrInvalid},
+		{"short fit file", "run.fit", "\x0e\x10", ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.file, strings.NewReader(tt.content)); !errors.Is(err, tt.want) {
+				t.Errorf("Parse error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []int
+		limit   int
+		want    []int
+	}{
+		{"none", nil, 3, nil},
+		{"within the limit", []int{120, 130}, 3, []int{120, 130}},
+		{"at the limit", []int{120, 130, 140}, 3, []int{120, 130, 140}},
+		{"pairs", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5, []int{2, 4, 6, 8, 10}},
+		{"uneven buckets", []int{10, 20, 30, 40, 50, 60, 70}, 3, []int{15, 35, 60}},
+		{"one bucket", []int{100, 110, 121}, 1, []int{110}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := downsample(tt.samples, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("downsample = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownsampleLongActivity(t *testing.T) {
+	rates := make([]int, 3*maxSamples+1)
+	for i := range rates {
+		rates[i] = 100 + i%50
+	}
+	got := downsample(rates, maxSamples)
+	if len(got) != maxSamples {
+		t.Fatalf("downsample kept %d samples, want %d", len(got), maxSamples)
+	}
+	for _, rate := range got {
+		if rate < 100 || rate > 149 {
+			t.Fatalf("downsample produced %d outside the samples' range", rate)
+		}
+	}
+}
+
+func TestGuessSport(t *testing.T) {
+	tests := []struct {
+		name     string
+		distance float64
+		duration time.Duration
+		want     string
+	}{
+		{"no distance", 0, time.Hour, "running"},
+		{"no duration", 5000, 0, "running"},
+		{"stroll", 4000, time.Hour, "walking"},
+		{"just under running", 6999, time.Hour, "walking"},
+		{"slow run", 7000, time.Hour, "running"},
+		{"tempo run", 12000, time.Hour, "running"},
+		{"slow ride", 16000, time.Hour, "cycling"},
+		{"fast ride", 30000, time.Hour, "cycling"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := &Activity{Distance: tt.distance, Duration: tt.duration}
+			if got := GuessSport(activity); got != tt.want {
+				t.Errorf("GuessSport = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSportSlug(t *testing.T) {
+	tests := map[string]string{
+		"Running":   "running",
+		"Biking":    "cycling",
+		"trail-run": "running",
+		" Hiking ":  "hiking",
+		"Other":     "",
+		"":          "",
+	}
+
+	for name, want := range tests {
+		if got := sportSlug(name); got != want {
+			t.Errorf("sportSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
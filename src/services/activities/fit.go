@@ -0,0 +1,236 @@
+package activities
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+)
+
+// FIT message and field numbers from the Garmin FIT profile.
+const (
+	fitSession = 18
+	fitRecord  = 20
+
+	fitTimestamp        = 253
+	fitSessionSport     = 5
+	fitRecordLat        = 0
+	fitRecordLon        = 1
+	fitRecordAltitude   = 2
+	fitRecordHeartRate  = 3
+	fitRecordDistance   = 5
+	fitEnhancedAltitude = 78
+)
+
+// fitEpoch is 1989-12-31T00:00:00Z, where FIT timestamps start.
+const fitEpoch = 631065600
+
+// fitSports maps the FIT sport enum to catalog slugs.
+var fitSports = map[uint64]string{
+	1:  "running",
+	2:  "cycling",
+	5:  "swimming",
+	11: "walking",
+	15: "rowing",
+	17: "hiking",
+}
+
+type fitField struct {
+	Number uint8
+	Size   uint8
+}
+
+type fitDefinition struct {
+	Global    uint16
+	Order     binary.ByteOrder
+	Fields    []fitField
+	Developer int
+}
+
+// parseFIT reads the records and the session sport of a binary FIT file.
+// Only the fields the summary needs are decoded; the rest are skipped.
+func parseFIT(r io.Reader) (*Activity, error) {
+	reader := bufio.NewReader(r)
+
+	headerSize, err := reader.ReadByte()
+	if err != nil || headerSize < 12 {
+		return nil, ErrInvalid
+	}
+	header := make([]byte, headerSize-1)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, ErrInvalid
+	}
+	if string(header[7:11]) != ".FIT" {
+		return nil, ErrInvalid
+	}
+	data := io.LimitReader(reader, int64(binary.LittleEndian.Uint32(header[3:7])))
+
+	definitions := make(map[uint8]*fitDefinition)
+	var points []point
+	var sport string
+	var timestamp uint32
+
+	for {
+		recordHeader, err := readByte(data)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalid
+		}
+
+		compressed := recordHeader&0x80 != 0
+		var local uint8
+		switch {
+		case compressed:
+			local = (recordHeader >> 5) & 0x03
+			offset := uint32(recordHeader & 0x1F)
+			if offset < timestamp&0x1F {
+				timestamp += 0x20
+			}
+			timestamp = timestamp&^0x1F | offset
+		case recordHeader&0x40 != 0:
+			definition, err := readDefinition(data, recordHeader&0x20 != 0)
+			if err != nil {
+				return nil, ErrInvalid
+			}
+			definitions[recordHeader&0x0F] = definition
+			continue
+		default:
+			local = recordHeader & 0x0F
+		}
+
+		definition, ok := definitions[local]
+		if !ok {
+			return nil, ErrInvalid
+		}
+		values, err := readValues(data, definition)
+		if err != nil {
+			return nil, ErrInvalid
+		}
+		if value, ok := values[fitTimestamp]; ok {
+			timestamp = uint32(value)
+		}
+
+		switch definition.Global {
+		case fitRecord:
+			points = append(points, fitPoint(values, timestamp))
+		case fitSession:
+			if value, ok := values[fitSessionSport]; ok && sport == "" {
+				sport = fitSports[value]
+			}
+		}
+	}
+	return summarize(sport, points)
+}
+
+func fitPoint(values map[uint8]uint64, timestamp uint32) point {
+	p := point{
+		Time:     time.Unix(fitEpoch+int64(timestamp), 0).UTC(),
+		Lat:      math.NaN(),
+		Lon:      math.NaN(),
+		Altitude: math.NaN(),
+		Distance: math.NaN(),
+	}
+	// Coordinates are signed semicircles; the largest value marks a
+	// missing fix.
+	lat, latOK := values[fitRecordLat]
+	lon, lonOK := values[fitRecordLon]
+	if latOK && lonOK && lat != math.MaxInt32 && lon != math.MaxInt32 {
+		p.Lat = float64(int32(lat)) * 180 / math.Pow(2, 31)
+		p.Lon = float64(int32(lon)) * 180 / math.Pow(2, 31)
+	}
+	if altitude, ok := values[fitEnhancedAltitude]; ok {
+		p.Altitude = float64(altitude)/5 - 500
+	} else if altitude, ok := values[fitRecordAltitude]; ok {
+		p.Altitude = float64(altitude)/5 - 500
+	}
+	if distance, ok := values[fitRecordDistance]; ok {
+		p.Distance = float64(distance) / 100
+	}
+	if heartRate, ok := values[fitRecordHeartRate]; ok {
+		p.HeartRate = int(heartRate)
+	}
+	return p
+}
+
+func readDefinition(r io.Reader, developer bool) (*fitDefinition, error) {
+	fixed := make([]byte, 5)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, err
+	}
+
+	definition := &fitDefinition{Order: binary.ByteOrder(binary.LittleEndian)}
+	if fixed[1] == 1 {
+		definition.Order = binary.BigEndian
+	}
+	definition.Global = definition.Order.Uint16(fixed[2:4])
+
+	fields := make([]byte, 3*int(fixed[4]))
+	if _, err := io.ReadFull(r, fields); err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(fields); i += 3 {
+		definition.Fields = append(definition.Fields, fitField{Number: fields[i], Size: fields[i+1]})
+	}
+
+	if developer {
+		count, err := readByte(r)
+		if err != nil {
+			return nil, err
+		}
+		developerFields := make([]byte, 3*int(count))
+		if _, err := io.ReadFull(r, developerFields); err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(developerFields); i += 3 {
+			definition.Developer += int(developerFields[i+1])
+		}
+	}
+	return definition, nil
+}
+
+// readValues reads a data message. Integer fields of one, two or four
+// bytes are kept unless they hold the FIT invalid value; anything else,
+// developer fields included, is skipped.
+func readValues(r io.Reader, definition *fitDefinition) (map[uint8]uint64, error) {
+	values := make(map[uint8]uint64, len(definition.Fields))
+	for _, field := range definition.Fields {
+		raw := make([]byte, field.Size)
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return nil, err
+		}
+
+		var value, invalid uint64
+		switch field.Size {
+		case 1:
+			value, invalid = uint64(raw[0]), math.MaxUint8
+		case 2:
+			value, invalid = uint64(definition.Order.Uint16(raw)), math.MaxUint16
+		case 4:
+			value, invalid = uint64(definition.Order.Uint32(raw)), math.MaxUint32
+		default:
+			continue
+		}
+		if value == invalid {
+			continue
+		}
+		values[field.Number] = value
+	}
+
+	if definition.Developer > 0 {
+		if _, err := io.CopyN(io.Discard, r, int64(definition.Developer)); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func readByte(r io.Reader) (byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
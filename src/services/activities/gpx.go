@@ -0,0 +1,65 @@
+package activities
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"time"
+)
+
+type gpxFile struct {
+	Tracks []struct {
+		Type     string `xml:"type"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// gpxPoint reads the heart rate from the Garmin track point extension,
+// whatever prefix the file binds its namespace to.
+type gpxPoint struct {
+	Lat       float64  `xml:"lat,attr"`
+	Lon       float64  `xml:"lon,attr"`
+	Elevation *float64 `xml:"ele"`
+	Time      string   `xml:"time"`
+	HeartRate int      `xml:"extensions>TrackPointExtension>hr"`
+}
+
+func parseGPX(r io.Reader) (*Activity, error) {
+	var file gpxFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, ErrInvalid
+	}
+
+	sport := ""
+	var points []point
+	for _, track := range file.Tracks {
+		if sport == "" {
+			sport = sportSlug(track.Type)
+		}
+		for _, segment := range track.Segments {
+			for _, p := range segment.Points {
+				parsed := point{
+					Lat:       p.Lat,
+					Lon:       p.Lon,
+					Altitude:  math.NaN(),
+					Distance:  math.NaN(),
+					HeartRate: p.HeartRate,
+				}
+				if p.Elevation != nil {
+					parsed.Altitude = *p.Elevation
+				}
+				if p.Time != "" {
+					t, err := time.Parse(time.RFC3339, p.Time)
+					if err != nil {
+						return nil, ErrInvalid
+					}
+					parsed.Time = t
+				}
+				points = append(points, parsed)
+			}
+		}
+	}
+	return summarize(sport, points)
+}
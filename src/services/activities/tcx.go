@@ -0,0 +1,67 @@
+package activities
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"time"
+)
+
+type tcxFile struct {
+	Activities []struct {
+		Sport string `xml:"Sport,attr"`
+		Laps  []struct {
+			Points []tcxPoint `xml:"Track>Trackpoint"`
+		} `xml:"Lap"`
+	} `xml:"Activities>Activity"`
+}
+
+type tcxPoint struct {
+	Time      string   `xml:"Time"`
+	Lat       *float64 `xml:"Position>LatitudeDegrees"`
+	Lon       *float64 `xml:"Position>LongitudeDegrees"`
+	Altitude  *float64 `xml:"AltitudeMeters"`
+	Distance  *float64 `xml:"DistanceMeters"`
+	HeartRate int      `xml:"HeartRateBpm>Value"`
+}
+
+// parseTCX reads the first activity of a Training Center file.
+func parseTCX(r io.Reader) (*Activity, error) {
+	var file tcxFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, ErrInvalid
+	}
+	if len(file.Activities) == 0 {
+		return nil, ErrEmpty
+	}
+
+	activity := file.Activities[0]
+	var points []point
+	for _, lap := range activity.Laps {
+		for _, p := range lap.Points {
+			parsed := point{
+				Lat:       optional(p.Lat),
+				Lon:       optional(p.Lon),
+				Altitude:  optional(p.Altitude),
+				Distance:  optional(p.Distance),
+				HeartRate: p.HeartRate,
+			}
+			if p.Time != "" {
+				t, err := time.Parse(time.RFC3339, p.Time)
+				if err != nil {
+					return nil, ErrInvalid
+				}
+				parsed.Time = t
+			}
+			points = append(points, parsed)
+		}
+	}
+	return summarize(sportSlug(activity.Sport), points)
+}
+
+func optional(value *float64) float64 {
+	if value == nil {
+		return math.NaN()
+	}
+	return *value
+}
@@ -0,0 +1,166 @@
+package body
+
+import (
+	"time"
+	"workouts_bot/src/models"
+)
+
+const (
+	// Window is the span of the trailing moving average.
+	Window = 7 * 24 * time.Hour
+	// TrendPeriod is how far back the trend looks.
+	TrendPeriod = 28 * 24 * time.Hour
+
+	day = 24 * time.Hour
+)
+
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+	DirectionFlat = "flat"
+
+	VerdictOnTrack  = "on_track"
+	VerdictOffTrack = "off_track"
+	VerdictStalled  = "stalled"
+)
+
+// Kinds lists measurement kinds in the order they are shown.
+var Kinds = []string{
+	models.MeasurementWeight,
+	models.MeasurementBodyFat,
+	models.MeasurementWaist,
+	models.MeasurementChest,
+	models.MeasurementArms,
+	models.MeasurementThighs,
+}
+
+// Limits are the accepted values per kind.
+var Limits = map[string][2]float64{
+	models.MeasurementWeight:  {20, 400},
+	models.MeasurementBodyFat: {2, 70},
+	models.MeasurementWaist:   {30, 250},
+	models.MeasurementChest:   {30, 250},
+	models.MeasurementArms:    {10, 100},
+	models.MeasurementThighs:  {20, 150},
+}
+
+// flatRates are weekly changes too small to call a direction.
+var flatRates = map[string]float64{
+	models.MeasurementWeight:  0.1,
+	models.MeasurementBodyFat: 0.1,
+}
+
+const defaultFlatRate = 0.2
+
+// goalDirections is where each goal wants a measurement to go. Kinds not
+// listed for a goal get no verdict.
+var goalDirections = map[string]map[string]string{
+	models.GoalWeightLoss: {
+		models.MeasurementWeight:  DirectionDown,
+		models.MeasurementBodyFat: DirectionDown,
+		models.MeasurementWaist:   DirectionDown,
+	},
+	models.GoalMuscleGain: {
+		models.MeasurementWeight: DirectionUp,
+		models.MeasurementChest:  DirectionUp,
+		models.MeasurementArms:   DirectionUp,
+		models.MeasurementThighs: DirectionUp,
+	},
+}
+
+// Trend is the smoothed change of a measurement over TrendPeriod.
+type Trend struct {
+	// Average is the latest moving average.
+	Average float64
+	// WeeklyRate is the change per week of the moving average.
+	WeeklyRate float64
+	Direction  string
+	// Verdict compares the direction to the user's goal; empty when the
+	// goal does not care about the kind.
+	Verdict string
+}
+
+// Valid reports whether value is plausible for the kind.
+func Valid(kind string, value float64) bool {
+	limits, ok := Limits[kind]
+	return ok && value >= limits[0] && value <= limits[1]
+}
+
+// MovingAverage returns, for each measurement, the mean of the values in
+// the Window ending on its day. Measurements must be sorted oldest first.
+// Averaging over days rather than entries keeps irregular logging from
+// skewing the result.
+func MovingAverage(measurements []models.BodyMeasurement) []float64 {
+	averages := make([]float64, len(measurements))
+
+	start := 0
+	sum := 0.0
+	for i, measurement := range measurements {
+		sum += measurement.Value
+		for measurement.MeasuredOn.Sub(measurements[start].MeasuredOn) >= Window {
+			sum -= measurements[start].Value
+			start++
+		}
+		averages[i] = sum / float64(i-start+1)
+	}
+	return averages
+}
+
+// Calculate fits a line through the moving averages of the last
+// TrendPeriod. It returns false until the measurements span at least a week.
+func Calculate(kind string, goal string, measurements []models.BodyMeasurement) (Trend, bool) {
+	if len(measurements) < 2 {
+		return Trend{}, false
+	}
+
+	last := measurements[len(measurements)-1].MeasuredOn
+	if last.Sub(measurements[0].MeasuredOn) < Window {
+		return Trend{}, false
+	}
+
+	averages := MovingAverage(measurements)
+
+	var n, sumX, sumY, sumXY, sumXX float64
+	for i, measurement := range measurements {
+		if last.Sub(measurement.MeasuredOn) > TrendPeriod {
+			continue
+		}
+		x := measurement.MeasuredOn.Sub(last).Hours() / day.Hours()
+		y := averages[i]
+		n++
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	trend := Trend{Average: averages[len(averages)-1]}
+	if denominator := n*sumXX - sumX*sumX; n >= 2 && denominator != 0 {
+		trend.WeeklyRate = (n*sumXY - sumX*sumY) / denominator * 7
+	}
+
+	flat, ok := flatRates[kind]
+	if !ok {
+		flat = defaultFlatRate
+	}
+	switch {
+	case trend.WeeklyRate > flat:
+		trend.Direction = DirectionUp
+	case trend.WeeklyRate < -flat:
+		trend.Direction = DirectionDown
+	default:
+		trend.Direction = DirectionFlat
+	}
+
+	if wanted, ok := goalDirections[goal][kind]; ok {
+		switch trend.Direction {
+		case wanted:
+			trend.Verdict = VerdictOnTrack
+		case DirectionFlat:
+			trend.Verdict = VerdictStalled
+		default:
+			trend.Verdict = VerdictOffTrack
+		}
+	}
+	return trend, true
+}
@@ -0,0 +1,327 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	batchSize        = 100
+	workers          = 4
+	progressInterval = 5 * time.Second
+
+	// Delivery state is retried a few times before the broadcast is
+	// stopped: a delivery that stays pending would be sent again.
+	storeAttempts = 3
+	storeBackoff  = time.Second
+)
+
+// Service delivers broadcasts in the background. Delivery state is kept in
+// the database, so broadcasts interrupted by a restart are resumed.
+type Service struct {
+	bot      sender.Sender
+	database *gorm.DB
+
+	mu      sync.Mutex
+	running map[uuid.UUID]bool
+	wg      sync.WaitGroup
+}
+
+func NewService(bot sender.Sender, database *gorm.DB) *Service {
+	return &Service{
+		bot:      bot,
+		database: database,
+		running:  make(map[uuid.UUID]bool),
+	}
+}
+
+// Start queues deliveries for all users and starts sending in the background.
+func (s *Service) Start(broadcast *models.Broadcast, chatID int64, locale string) error {
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "broadcast.starting"))
+	progress, err := s.bot.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	broadcast.ProgressChatID = chatID
+	broadcast.ProgressMessageID = progress.MessageID
+	if err := database.QueueBroadcast(broadcast, s.database); err != nil {
+		return err
+	}
+
+	s.launch(broadcast.ID)
+	return nil
+}
+
+// Resume continues broadcasts that were still sending when the bot stopped.
+func (s *Service) Resume() {
+	broadcasts, err := database.GetSendingBroadcasts(s.database)
+	if err != nil {
+		return
+	}
+
+	for _, broadcast := range broadcasts {
+		logger.WithField("broadcast_id", broadcast.ID).Info("Resuming broadcast")
+		s.launch(broadcast.ID)
+	}
+}
+
+func (s *Service) launch(broadcastID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running[broadcastID] {
+		return
+	}
+	s.running[broadcastID] = true
+	s.wg.Add(1)
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, broadcastID)
+			s.mu.Unlock()
+			s.wg.Done()
+		}()
+		s.run(broadcastID)
+	}()
+}
+
+// Wait reports whether every running broadcast stopped before ctx
+// expired. Broadcasts stop once the sender is closed.
+func (s *Service) Wait(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *Service) run(broadcastID uuid.UUID) {
+	broadcast, err := database.GetBroadcast(broadcastID, s.database)
+	if err != nil {
+		return
+	}
+
+	locale := s.adminLocale(broadcast)
+	lastReport := time.Now()
+	for {
+		var deliveries []models.BroadcastDelivery
+		err := retryStore(func() error {
+			var err error
+			deliveries, err = database.GetPendingDeliveries(broadcastID, batchSize, s.database)
+			return err
+		})
+		if err != nil {
+			s.fail(locale, broadcast, err)
+			return
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		err = s.deliverBatch(broadcast, deliveries)
+		if errors.Is(err, sender.ErrClosed) {
+			logger.WithField("broadcast_id", broadcastID).Warn("Broadcast interrupted, will resume on restart")
+			return
+		}
+		if err != nil {
+			s.fail(locale, broadcast, err)
+			return
+		}
+
+		if time.Since(lastReport) >= progressInterval {
+			s.report(locale, broadcast, "broadcast.progress_title")
+			lastReport = time.Now()
+		}
+	}
+
+	s.finish(locale, broadcast, models.BroadcastStatusCompleted, "broadcast.done_title")
+
+	logger.WithFields(logrus.Fields{
+		"broadcast_id": broadcast.ID,
+		"total":        broadcast.Total,
+		"sent":         broadcast.Sent,
+		"failed":       broadcast.Failed,
+		"blocked":      broadcast.Blocked,
+	}).Info("Broadcast completed")
+}
+
+// fail stops the broadcast after its delivery state could not be read or
+// stored, rather than sending the same deliveries again.
+func (s *Service) fail(locale string, broadcast *models.Broadcast, err error) {
+	logger.WithFields(logrus.Fields{
+		"broadcast_id": broadcast.ID,
+		"error":        err,
+	}).Error("Broadcast stopped")
+
+	s.finish(locale, broadcast, models.BroadcastStatusFailed, "broadcast.failed_title")
+}
+
+// finish stores the final status before the counts are refreshed, so a
+// broadcast whose counts cannot be read is not resumed on restart.
+func (s *Service) finish(locale string, broadcast *models.Broadcast, status, titleKey string) {
+	now := time.Now()
+	broadcast.Status = status
+	broadcast.CompletedAt = &now
+	err := retryStore(func() error {
+		return database.FinishBroadcast(broadcast, s.database)
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcast.ID,
+			"status":       status,
+			"error":        err,
+		}).Error("Broadcast status not stored, it will be resumed on restart")
+	}
+
+	s.report(locale, broadcast, titleKey)
+}
+
+// deliverBatch sends the batch with a few workers; the sender enforces the
+// Telegram rate limits. It stops at the first delivery that returns an
+// error: sender.ErrClosed or a failure to store the delivery.
+func (s *Service) deliverBatch(
+	broadcast *models.Broadcast,
+	deliveries []models.BroadcastDelivery,
+) error {
+	jobs := make(chan *models.BroadcastDelivery)
+	var wg sync.WaitGroup
+	var stopped error
+	var stoppedMu sync.Mutex
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range jobs {
+				if err := s.deliver(broadcast, delivery); err != nil {
+					stoppedMu.Lock()
+					if stopped == nil {
+						stopped = err
+					}
+					stoppedMu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range deliveries {
+		stoppedMu.Lock()
+		halt := stopped != nil
+		stoppedMu.Unlock()
+		if halt {
+			break
+		}
+		jobs <- &deliveries[i]
+	}
+	close(jobs)
+	wg.Wait()
+
+	return stopped
+}
+
+func (s *Service) deliver(broadcast *models.Broadcast, delivery *models.BroadcastDelivery) error {
+	msg := tgbotapi.NewMessage(delivery.TelegramID, broadcast.Text)
+	_, err := s.bot.Send(msg)
+
+	switch {
+	case err == nil:
+		now := time.Now()
+		delivery.Status = models.DeliveryStatusSent
+		delivery.SentAt = &now
+	case errors.Is(err, sender.ErrClosed):
+		return err
+	case sender.IsBlocked(err):
+		delivery.Status = models.DeliveryStatusBlocked
+		delivery.Error = err.Error()
+		_ = database.MarkUserBlocked(delivery.UserID, s.database)
+	default:
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.Error = err.Error()
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcast.ID,
+			"telegram_id":  delivery.TelegramID,
+			"error":        err,
+		}).Warn("Failed to deliver broadcast")
+	}
+
+	return retryStore(func() error {
+		return database.UpdateDelivery(delivery, s.database)
+	})
+}
+
+// retryStore runs a delivery state query, retrying it with backoff.
+func retryStore(query func() error) error {
+	backoff := storeBackoff
+	var err error
+	for attempt := 1; attempt <= storeAttempts; attempt++ {
+		if err = query(); err == nil {
+			return nil
+		}
+		if attempt < storeAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
+
+func (s *Service) report(locale string, broadcast *models.Broadcast, titleKey string) {
+	counts, err := database.CountDeliveries(broadcast.ID, s.database)
+	if err != nil {
+		return
+	}
+
+	broadcast.Sent = counts[models.DeliveryStatusSent]
+	broadcast.Failed = counts[models.DeliveryStatusFailed]
+	broadcast.Blocked = counts[models.DeliveryStatusBlocked]
+	if err := database.UpdateBroadcast(broadcast, s.database); err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcast.ID,
+			"error":        err,
+		}).Warn("Failed to store broadcast counts")
+	}
+
+	title := i18n.T(locale, titleKey)
+	done := broadcast.Sent + broadcast.Failed + broadcast.Blocked
+	text := i18n.T(
+		locale, "broadcast.progress",
+		title, done, broadcast.Total, broadcast.Sent, broadcast.Blocked, broadcast.Failed,
+	)
+
+	editMsg := tgbotapi.NewEditMessageText(broadcast.ProgressChatID, broadcast.ProgressMessageID, text)
+	if _, err := s.bot.Send(editMsg); err != nil {
+		logger.WithFields(logrus.Fields{
+			"broadcast_id": broadcast.ID,
+			"error":        err,
+		}).Warn("Failed to update broadcast progress")
+	}
+}
+
+func (s *Service) adminLocale(broadcast *models.Broadcast) string {
+	admin, err := database.FindUserByTelegramID(broadcast.AdminTelegramID, s.database)
+	if err != nil {
+		return i18n.Default
+	}
+	return i18n.UserLocale(admin)
+}
@@ -0,0 +1,241 @@
+package calendar
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/programs"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// Path is where the service serves feeds, followed by the token and
+	// FileExtension.
+	Path          = "/api/v1/calendar/"
+	FileExtension = ".ics"
+
+	ContentType = "text/calendar; charset=utf-8"
+
+	// planDays is how far ahead program days are planned.
+	planDays = 28
+
+	// historyDays is how far back finished sessions are listed.
+	historyDays = 90
+
+	tokenBytes = 24
+	uidDomain  = "workouts-bot"
+)
+
+// NewToken makes a random URL-safe feed token.
+func NewToken() (string, error) {
+	secret := make([]byte, tokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// HashToken is the hex SHA-256 of the token, which is what is stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// URL is the address of the feed with the token on the service at
+// publicURL.
+func URL(publicURL string, token string) string {
+	return publicURL + Path + token + FileExtension
+}
+
+// PlannedDay is a program day placed on a date.
+type PlannedDay struct {
+	Date          time.Time
+	Cycle         int
+	Week          int
+	Day           int
+	Prescriptions []programs.Prescription
+}
+
+// Plan places the program days from the enrollment's position on the
+// training weekdays of the days days from from. Training maxes go up with
+// each new cycle, as they will once it is reached.
+func Plan(
+	program *programs.Program,
+	enrollment *models.ProgramEnrollment,
+	maxes map[string]float64,
+	from time.Time,
+	days int,
+) []PlannedDay {
+	weekdays := program.TrainingDays()
+	if len(weekdays) == 0 {
+		return nil
+	}
+	maxes = maps.Clone(maxes)
+
+	cycle, week, day := enrollment.Cycle, enrollment.Week, enrollment.Day
+	date := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	var planned []PlannedDay
+	for range days {
+		if slices.Contains(weekdays, date.Weekday()) {
+			planned = append(planned, PlannedDay{
+				Date:          date,
+				Cycle:         cycle,
+				Week:          week,
+				Day:           day,
+				Prescriptions: program.Prescribe(week, day, maxes),
+			})
+
+			var cycled bool
+			week, day, cycled = program.Next(week, day)
+			if cycled {
+				cycle++
+				for slug, increment := range program.Increments {
+					if maxes[slug] > 0 {
+						maxes[slug] += increment
+					}
+				}
+			}
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+	return planned
+}
+
+// Write writes the user's feed: the next program days, from tomorrow when
+// a session was already finished today, and the finished sessions of the
+// last days.
+func Write(w io.Writer, user *models.User, now time.Time, db *gorm.DB) error {
+	locale := i18n.UserLocale(user)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	sessions, err := database.ListFinishedSessions(user.ID, today.AddDate(0, 0, -historyDays), db)
+	if err != nil {
+		return err
+	}
+	events := make([]Event, 0, len(sessions))
+	from := today
+	for i := range sessions {
+		session := &sessions[i]
+		if !session.StartedAt.Before(today) {
+			from = today.AddDate(0, 0, 1)
+		}
+		events = append(events, sessionEvent(locale, session))
+	}
+
+	planned, err := plannedEvents(locale, user, from, db)
+	if err != nil {
+		return err
+	}
+	events = append(events, planned...)
+
+	return writeICS(w, i18n.T(locale, "calendar.name"), now, events)
+}
+
+func plannedEvents(locale string, user *models.User, from time.Time, db *gorm.DB) ([]Event, error) {
+	enrollment, err := database.FindProgramEnrollment(user.ID, db)
+	if err != nil || enrollment == nil {
+		return nil, err
+	}
+	program, ok := programs.Find(enrollment.Program)
+	if !ok {
+		return nil, nil
+	}
+
+	trainingMaxes, err := database.ListTrainingMaxes(user.ID, db)
+	if err != nil {
+		return nil, err
+	}
+	maxes := make(map[string]float64, len(trainingMaxes))
+	for _, trainingMax := range trainingMaxes {
+		maxes[trainingMax.Exercise.Slug] = trainingMax.Weight
+	}
+
+	days := Plan(program, enrollment, maxes, from, planDays)
+	var slugs []string
+	for _, day := range days {
+		for _, prescription := range day.Prescriptions {
+			slugs = append(slugs, prescription.Exercise)
+		}
+	}
+	exercises, err := database.ListExercisesBySlugs(slugs, db)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(days))
+	for _, day := range days {
+		summary := i18n.T(
+			locale, "programs.session_name",
+			programName(locale, program.Slug),
+			programDay(locale, program.Days[day.Day%len(program.Days)].Name),
+		)
+		if program.Weeks[day.Week%len(program.Weeks)].Deload {
+			summary += i18n.T(locale, "programs.deload")
+		}
+
+		var description strings.Builder
+		for _, prescription := range day.Prescriptions {
+			exercise, ok := exercises[prescription.Exercise]
+			if !ok || len(prescription.Sets) == 0 {
+				continue
+			}
+			description.WriteString(i18n.T(
+				locale, "programs.lift_line",
+				exerciseName(locale, &exercise), plannedSets(locale, prescription.Sets),
+			))
+		}
+
+		events = append(events, Event{
+			// Days are keyed by their place in the program, so a calendar
+			// moves an event when the plan shifts instead of duplicating it.
+			UID: fmt.Sprintf(
+				"%s-%d-%d-%d@%s", enrollment.ID, day.Cycle, day.Week, day.Day, uidDomain,
+			),
+			Start:       day.Date,
+			End:         day.Date.AddDate(0, 0, 1),
+			AllDay:      true,
+			Summary:     summary,
+			Description: strings.TrimSpace(description.String()),
+		})
+	}
+	return events, nil
+}
+
+func sessionEvent(locale string, session *models.WorkoutSession) Event {
+	var description strings.Builder
+	for i := range session.Exercises {
+		exercise := &session.Exercises[i]
+		var sets []models.PlannedSet
+		for _, set := range exercise.WorkSets() {
+			if !set.Skipped && set.ParentID == nil && (set.Reps > 0 || set.Weight > 0) {
+				sets = append(sets, models.PlannedSet{Reps: set.Reps, Weight: set.Weight})
+			}
+		}
+		if len(sets) == 0 {
+			continue
+		}
+		description.WriteString(i18n.T(
+			locale, "programs.lift_line",
+			exerciseName(locale, &exercise.Exercise), plannedSets(locale, sets),
+		))
+	}
+
+	return Event{
+		UID:         session.ID.String() + "@" + uidDomain,
+		Start:       session.StartedAt,
+		End:         *session.FinishedAt,
+		Summary:     "✅ " + session.Name,
+		Description: strings.TrimSpace(description.String()),
+	}
+}
@@ -0,0 +1,90 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// lineLimit is the longest content line RFC 5545 allows, in octets.
+	lineLimit = 75
+
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+)
+
+// Event is a calendar entry. All-day events take only the date of Start
+// and End, End being the day after the last.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Summary     string
+	Description string
+}
+
+// writeICS writes the events as an iCalendar document named name.
+func writeICS(w io.Writer, name string, stamp time.Time, events []Event) error {
+	out := bufio.NewWriter(w)
+	line := func(name string, value string) {
+		writeLine(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//workouts_bot//calendar//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escape(name))
+	for _, event := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("DTSTAMP", stamp.UTC().Format(dateTimeLayout))
+		if event.AllDay {
+			line("DTSTART;VALUE=DATE", event.Start.Format(dateLayout))
+			line("DTEND;VALUE=DATE", event.End.Format(dateLayout))
+		} else {
+			line("DTSTART", event.Start.UTC().Format(dateTimeLayout))
+			line("DTEND", event.End.UTC().Format(dateTimeLayout))
+		}
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return out.Flush()
+}
+
+// writeLine ends the line with CRLF, folding it so no line is longer than
+// the limit without splitting a UTF-8 sequence.
+func writeLine(out *bufio.Writer, text string) {
+	limit := lineLimit
+	for len(text) > limit {
+		cut := limit
+		for cut > 0 && !startsRune(text[cut]) {
+			cut--
+		}
+		fmt.Fprintf(out, "%s\r\n ", text[:cut])
+		text = text[cut:]
+		// Continuation lines lose one octet to the leading space.
+		limit = lineLimit - 1
+	}
+	fmt.Fprintf(out, "%s\r\n", text)
+}
+
+func startsRune(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "")
+
+// escape escapes a TEXT value.
+func escape(text string) string {
+	return escaper.Replace(text)
+}
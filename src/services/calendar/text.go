@@ -0,0 +1,51 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"workouts_bot/src/i18n"
+	"workouts_bot/src/models"
+)
+
+// The feed is served outside the bot, so it words events itself with the
+// same texts the bot's views use.
+
+func programName(locale string, slug string) string {
+	return i18n.T(locale, "programs.name_"+slug)
+}
+
+func programDay(locale string, day string) string {
+	return i18n.T(locale, "programs.day_"+day)
+}
+
+func exerciseName(locale string, exercise *models.Exercise) string {
+	if locale == i18n.EN && exercise.NameEN != "" {
+		return exercise.NameEN
+	}
+	return exercise.NameRU
+}
+
+// plannedSets lists the sets with repeats folded: 3 × 5 × 100 kg.
+func plannedSets(locale string, sets []models.PlannedSet) string {
+	var parts []string
+	for i := 0; i < len(sets); {
+		count := 1
+		for i+count < len(sets) && sets[i+count] == sets[i] {
+			count++
+		}
+
+		set := sets[i]
+		reps := i18n.T(locale, "programs.reps", set.Reps)
+		if set.AMRAP {
+			reps = i18n.T(locale, "programs.reps_amrap", set.Reps)
+		}
+		if set.Weight > 0 {
+			weight := strconv.FormatFloat(set.Weight, 'f', -1, 64)
+			parts = append(parts, i18n.T(locale, "programs.sets_weight", count, reps, weight))
+		} else {
+			parts = append(parts, i18n.T(locale, "programs.sets", count, reps))
+		}
+		i += count
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,26 @@
+package coaching
+
+import (
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ClientCoach returns the client's link to a coach who still holds the
+// role, with both users loaded, or nil when nobody is to be told about
+// the client's training.
+func ClientCoach(clientID uuid.UUID, cfg *config.Config, db *gorm.DB) (*models.CoachClient, error) {
+	link, err := database.FindClientCoach(clientID, db)
+	if err != nil || link == nil || !cfg.IsCoach(link.Coach.TelegramID) {
+		return nil, err
+	}
+	client, err := database.GetUserByID(clientID, db)
+	if err != nil {
+		return nil, err
+	}
+	link.Client = *client
+	return link, nil
+}
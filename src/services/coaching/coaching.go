@@ -0,0 +1,79 @@
+package coaching
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+	"workouts_bot/src/services/training"
+)
+
+// InvitePrefix starts the /start payload of a coach's invite link.
+const InvitePrefix = "coach_"
+
+// Weeks is how many finished weeks adherence covers.
+const Weeks = 4
+
+// tokenBytes is the size of an invite secret; encoded it stays well within
+// the 64 characters of a /start payload.
+const tokenBytes = 16
+
+func NewToken() (string, error) {
+	secret := make([]byte, tokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// InviteLink is the deep link that opens the bot with the invite token.
+func InviteLink(botName string, token string) string {
+	return "https://t.me/" + botName + "?start=" + InvitePrefix + token
+}
+
+// InviteToken returns the token of a /start payload, if it is an invite.
+func InviteToken(payload string) (string, bool) {
+	token, found := strings.CutPrefix(payload, InvitePrefix)
+	return token, found && token != ""
+}
+
+// Adherence compares a client's sessions with the plan: PerWeek sessions
+// a week, the program's training days or a default. Done and Planned
+// cover the last finished weeks; a week counts for at most PerWeek
+// sessions. Week is the sessions of the current week so far.
+type Adherence struct {
+	PerWeek int
+	Week    int
+	Done    int
+	Planned int
+}
+
+// Measure works out adherence from when the client's sessions started.
+func Measure(starts []time.Time, perWeek int, now time.Time) Adherence {
+	current := training.WeekStart(now)
+	from := current.AddDate(0, 0, -7*Weeks)
+	weeks := make([]int, Weeks)
+
+	adherence := Adherence{PerWeek: perWeek, Planned: perWeek * Weeks}
+	for _, start := range starts {
+		day := start.In(now.Location())
+		switch {
+		case !day.Before(current):
+			adherence.Week++
+		case !day.Before(from):
+			weeks[int(day.Sub(from).Hours()/24)/7]++
+		}
+	}
+	for _, done := range weeks {
+		adherence.Done += min(done, perWeek)
+	}
+	return adherence
+}
+
+// Percent is the share of planned sessions done in the finished weeks.
+func (a Adherence) Percent() int {
+	if a.Planned == 0 {
+		return 0
+	}
+	return a.Done * 100 / a.Planned
+}
@@ -0,0 +1,129 @@
+package confirmation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const defaultTTL = 10 * time.Minute
+
+var (
+	ErrNotFound      = errors.New("pending action not found")
+	ErrExpired       = errors.New("pending action expired")
+	ErrForbidden     = errors.New("pending action belongs to another user")
+	ErrUnknownAction = errors.New("unknown action")
+)
+
+// Action is a destructive operation that runs only after the user presses
+// "yes" on the confirmation keyboard. Execute returns the text, in the given
+// locale, shown to the user in place of the question. Cancel is optional.
+type Action struct {
+	Execute func(pending *models.PendingAction, chatID int64, locale string) (string, error)
+	Cancel  func(pending *models.PendingAction) error
+}
+
+type Service struct {
+	database *gorm.DB
+	ttl      time.Duration
+	actions  map[string]Action
+}
+
+func NewService(database *gorm.DB) *Service {
+	return &Service{
+		database: database,
+		ttl:      defaultTTL,
+		actions:  make(map[string]Action),
+	}
+}
+
+func (s *Service) Register(name string, action Action) {
+	s.actions[name] = action
+}
+
+// Request records an action waiting for confirmation. The returned record
+// ID is what goes into the confirmation keyboard.
+func (s *Service) Request(telegramID int64, action string, payload string) (*models.PendingAction, error) {
+	if _, ok := s.actions[action]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAction, action)
+	}
+
+	_ = database.DeleteExpiredPendingActions(s.database)
+
+	pending := &models.PendingAction{
+		ID:         uuid.New(),
+		TelegramID: telegramID,
+		Action:     action,
+		Payload:    payload,
+		ExpiresAt:  time.Now().Add(s.ttl),
+	}
+	if err := database.CreatePendingAction(pending, s.database); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// Resolve executes or cancels the pending action on behalf of the user who
+// requested it. The record is removed in both cases.
+func (s *Service) Resolve(
+	actionID uuid.UUID,
+	telegramID int64,
+	chatID int64,
+	locale string,
+	confirmed bool,
+) (string, error) {
+	pending, err := database.GetPendingAction(actionID, s.database)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrNotFound
+	} else if err != nil {
+		return "", err
+	}
+
+	if pending.TelegramID != telegramID {
+		return "", ErrForbidden
+	}
+
+	taken, err := database.TakePendingAction(actionID, s.database)
+	if err != nil {
+		return "", err
+	}
+	if !taken {
+		return "", ErrNotFound
+	}
+
+	action, ok := s.actions[pending.Action]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownAction, pending.Action)
+	}
+
+	if time.Now().After(pending.ExpiresAt) {
+		if action.Cancel != nil {
+			_ = action.Cancel(pending)
+		}
+		return "", ErrExpired
+	}
+
+	logger.WithFields(logrus.Fields{
+		"telegram_id": telegramID,
+		"action":      pending.Action,
+		"confirmed":   confirmed,
+	}).Info("Resolving pending action")
+
+	if !confirmed {
+		if action.Cancel != nil {
+			if err := action.Cancel(pending); err != nil {
+				return "", err
+			}
+		}
+		return "", nil
+	}
+
+	return action.Execute(pending, chatID, locale)
+}
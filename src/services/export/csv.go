@@ -0,0 +1,63 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"io"
+	"time"
+)
+
+// csvWriter writes each table as its own CSV file in a zip archive.
+type csvWriter struct {
+	archive *zip.Writer
+	table   *csv.Writer
+	row     []string
+}
+
+func newCSVWriter(w io.Writer) tableWriter {
+	return &csvWriter{archive: zip.NewWriter(w)}
+}
+
+func (w *csvWriter) Table(name string, columns []string) error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	file, err := createFile(w.archive, name+".csv")
+	if err != nil {
+		return err
+	}
+	w.table = csv.NewWriter(file)
+	return w.table.Write(columns)
+}
+
+func (w *csvWriter) Row(values ...any) error {
+	w.row = w.row[:0]
+	for _, value := range values {
+		w.row = append(w.row, text(value))
+	}
+	return w.table.Write(w.row)
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	return w.archive.Close()
+}
+
+func (w *csvWriter) flush() error {
+	if w.table == nil {
+		return nil
+	}
+	w.table.Flush()
+	return w.table.Error()
+}
+
+// createFile adds a compressed file dated now to the archive.
+func createFile(archive *zip.Writer, name string) (io.Writer, error) {
+	return archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+}
@@ -0,0 +1,337 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+
+	"gorm.io/gorm"
+)
+
+// Export formats.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatXLSX = "xlsx"
+)
+
+// batchSize is how many sessions or activities are read at a time.
+const batchSize = 200
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Formats lists the export formats in the order they are offered.
+var Formats = []string{FormatCSV, FormatJSON, FormatXLSX}
+
+// tableWriter writes the export as named tables of rows. Values are
+// strings, numbers, booleans, times or nil; every format writes them as it
+// goes, so memory does not grow with the history.
+type tableWriter interface {
+	Table(name string, columns []string) error
+	Row(values ...any) error
+	Close() error
+}
+
+var writers = map[string]func(io.Writer) tableWriter{
+	FormatCSV:  newCSVWriter,
+	FormatJSON: newJSONWriter,
+	FormatXLSX: newXLSXWriter,
+}
+
+// FileName is the name of the user's export file in the format.
+func FileName(format string, now time.Time) string {
+	extension := format
+	if format == FormatCSV {
+		extension = "zip"
+	}
+	return fmt.Sprintf("workouts-%s.%s", now.Format("2006-01-02"), extension)
+}
+
+// ContentType is the MIME type of the export file in the format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "application/zip"
+	case FormatJSON:
+		return "application/json"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// Write exports all of the user's data in the format: the profile,
+// templates, sessions, sets, activities, body measurements, personal
+// records, training maxes and achievements.
+func Write(w io.Writer, format string, user *models.User, db *gorm.DB) error {
+	newWriter, ok := writers[format]
+	if !ok {
+		return ErrUnknownFormat
+	}
+	out := newWriter(w)
+
+	steps := []func(tableWriter, *models.User, *gorm.DB) error{
+		writeProfile,
+		writeTemplates,
+		writeSessions,
+		writeCardio,
+		writeMeasurements,
+		writeTrainingMaxes,
+		writeAchievements,
+	}
+	for _, step := range steps {
+		if err := step(out, user, db); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+func writeProfile(out tableWriter, user *models.User, _ *gorm.DB) error {
+	err := out.Table("profile", []string{
+		"telegram_id", "username", "first_name", "last_name", "language",
+		"goal", "experience", "progression", "plate_unit", "plates", "warmups", "created_at",
+	})
+	if err != nil {
+		return err
+	}
+	return out.Row(
+		user.TelegramID, user.Username, user.FirstName, user.LastName, user.Language,
+		user.Goal, user.Experience, user.Progression, user.PlateUnit, user.Plates, user.Warmups, user.CreatedAt,
+	)
+}
+
+func writeTemplates(out tableWriter, user *models.User, db *gorm.DB) error {
+	templates, err := database.ListTemplatesWithExercises(user.ID, db)
+	if err != nil {
+		return err
+	}
+
+	err = out.Table("templates", []string{
+		"template_id", "template", "type", "position", "exercise",
+		"sets", "reps", "weight_kg", "rest_seconds", "work_seconds", "linked",
+	})
+	if err != nil {
+		return err
+	}
+	for _, template := range templates {
+		for _, item := range template.Exercises {
+			err := out.Row(
+				template.ID.String(), template.Name, template.Type, item.Position, item.Exercise.Slug,
+				item.TargetSets, item.TargetReps, item.TargetWeight, item.RestSeconds, item.WorkSeconds, item.Linked,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// record is the best an exercise was done: the heaviest completed set and
+// the highest estimated one-rep max.
+type record struct {
+	exercise   string
+	weight     float64
+	reps       int
+	weightOn   time.Time
+	oneRepMax  float64
+	estimateOn time.Time
+}
+
+// writeSessions writes the sessions and then their sets, reading the
+// history twice rather than keeping either in memory. Personal records are
+// collected from the sets on the way.
+func writeSessions(out tableWriter, user *models.User, db *gorm.DB) error {
+	err := out.Table("sessions", []string{
+		"session_id", "name", "started_at", "finished_at", "exercises", "volume_kg",
+	})
+	if err != nil {
+		return err
+	}
+	err = database.EachSession(user.ID, batchSize, func(sessions []models.WorkoutSession) error {
+		for _, session := range sessions {
+			err := out.Row(
+				session.ID.String(), session.Name, session.StartedAt, session.FinishedAt,
+				len(session.Exercises), session.Volume(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}, db)
+	if err != nil {
+		return err
+	}
+
+	err = out.Table("sets", []string{
+		"session_id", "started_at", "exercise", "set", "kind", "warmup", "skipped",
+		"reps", "weight_kg", "rpe", "seconds",
+	})
+	if err != nil {
+		return err
+	}
+	records := make(map[string]*record)
+	var order []string
+	err = database.EachSession(user.ID, batchSize, func(sessions []models.WorkoutSession) error {
+		for _, session := range sessions {
+			for i := range session.Exercises {
+				exercise := &session.Exercises[i]
+				slug := exercise.Exercise.Slug
+				for _, set := range exercise.Sets {
+					err := out.Row(
+						session.ID.String(), session.StartedAt, slug, set.Number, set.Kind, set.Warmup, set.Skipped,
+						set.Reps, set.Weight, set.RPE, set.Seconds,
+					)
+					if err != nil {
+						return err
+					}
+				}
+
+				if session.FinishedAt == nil {
+					continue
+				}
+				best, ok := records[slug]
+				if !ok {
+					best = &record{exercise: slug}
+					records[slug] = best
+					order = append(order, slug)
+				}
+				for _, set := range exercise.WorkSets() {
+					if !set.Skipped && set.Reps > 0 && set.Weight > best.weight {
+						best.weight, best.reps, best.weightOn = set.Weight, set.Reps, session.StartedAt
+					}
+				}
+				if estimate, ok := training.BestEstimate(exercise); ok && estimate > best.oneRepMax {
+					best.oneRepMax, best.estimateOn = estimate, session.StartedAt
+				}
+			}
+		}
+		return nil
+	}, db)
+	if err != nil {
+		return err
+	}
+
+	err = out.Table("records", []string{
+		"exercise", "best_weight_kg", "best_weight_reps", "best_weight_on",
+		"estimated_1rm_kg", "estimated_1rm_on",
+	})
+	if err != nil {
+		return err
+	}
+	for _, slug := range order {
+		best := records[slug]
+		if best.weight == 0 && best.oneRepMax == 0 {
+			continue
+		}
+		err := out.Row(
+			best.exercise, best.weight, best.reps, optionalTime(best.weightOn),
+			roundTenth(best.oneRepMax), optionalTime(best.estimateOn),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCardio(out tableWriter, user *models.User, db *gorm.DB) error {
+	err := out.Table("cardio", []string{
+		"started_at", "exercise", "duration_seconds", "distance_m", "heart_rate",
+		"max_heart_rate", "elevation_gain_m", "effort", "source", "session_id",
+	})
+	if err != nil {
+		return err
+	}
+	return database.EachCardioEntry(user.ID, batchSize, func(entries []models.CardioEntry) error {
+		for _, entry := range entries {
+			var sessionID any
+			if entry.SessionID != nil {
+				sessionID = entry.SessionID.String()
+			}
+			err := out.Row(
+				entry.StartedAt, entry.Exercise.Slug, entry.DurationSeconds, entry.DistanceMeters, entry.HeartRate,
+				entry.MaxHeartRate, entry.ElevationGain, entry.Effort, entry.Source, sessionID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}, db)
+}
+
+func writeMeasurements(out tableWriter, user *models.User, db *gorm.DB) error {
+	measurements, err := database.ListAllBodyMeasurements(user.ID, db)
+	if err != nil {
+		return err
+	}
+
+	if err := out.Table("measurements", []string{"measured_on", "kind", "value"}); err != nil {
+		return err
+	}
+	for _, measurement := range measurements {
+		if err := out.Row(measurement.MeasuredOn.Format("2006-01-02"), measurement.Kind, measurement.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTrainingMaxes(out tableWriter, user *models.User, db *gorm.DB) error {
+	maxes, err := database.ListTrainingMaxes(user.ID, db)
+	if err != nil {
+		return err
+	}
+
+	err = out.Table("training_maxes", []string{
+		"exercise", "training_max_kg", "one_rep_max_kg", "source", "updated_at",
+	})
+	if err != nil {
+		return err
+	}
+	for _, trainingMax := range maxes {
+		err := out.Row(
+			trainingMax.Exercise.Slug, trainingMax.Weight, trainingMax.OneRepMax,
+			trainingMax.Source, trainingMax.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeAchievements(out tableWriter, user *models.User, db *gorm.DB) error {
+	achievements, err := database.ListAchievements(user.ID, db)
+	if err != nil {
+		return err
+	}
+
+	if err := out.Table("achievements", []string{"achievement", "earned_at"}); err != nil {
+		return err
+	}
+	for _, achievement := range achievements {
+		if err := out.Row(achievement.Slug, achievement.EarnedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func optionalTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func roundTenth(value float64) float64 {
+	return float64(int(value*10+0.5)) / 10
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// jsonWriter writes one object with an array of row objects per table:
+// {"profile": [{...}], "sessions": [...], ...}.
+type jsonWriter struct {
+	out     *bufio.Writer
+	columns []string
+	tables  int
+	rows    int
+}
+
+func newJSONWriter(w io.Writer) tableWriter {
+	return &jsonWriter{out: bufio.NewWriter(w)}
+}
+
+func (w *jsonWriter) Table(name string, columns []string) error {
+	opening := "{"
+	if w.tables > 0 {
+		opening = "],"
+	}
+	key, err := json.Marshal(name)
+	if err != nil {
+		return err
+	}
+	w.out.WriteString(opening + "\n")
+	w.out.Write(key)
+	_, err = w.out.WriteString(": [")
+	w.columns, w.rows = columns, 0
+	w.tables++
+	return err
+}
+
+func (w *jsonWriter) Row(values ...any) error {
+	if w.rows > 0 {
+		w.out.WriteString(",")
+	}
+	w.out.WriteString("\n  {")
+	for i, column := range w.columns {
+		if i > 0 {
+			w.out.WriteString(", ")
+		}
+		key, _ := json.Marshal(column)
+		w.out.Write(key)
+		w.out.WriteString(": ")
+
+		var value any
+		if i < len(values) {
+			value = normalize(values[i])
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		w.out.Write(encoded)
+	}
+	w.rows++
+	_, err := w.out.WriteString("}")
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	closing := "{}\n"
+	if w.tables > 0 {
+		closing = "\n]}\n"
+	}
+	w.out.WriteString(closing)
+	return w.out.Flush()
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// normalize turns a row value into nil, a string, an integer, a float or a
+// boolean. Times become RFC 3339 in UTC.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.UTC().Format(time.RFC3339)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case int:
+		return int64(v)
+	case int64, float64, bool, string, nil:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// text is the value as a cell of text.
+func text(value any) string {
+	switch v := normalize(value).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
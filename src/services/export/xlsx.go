@@ -0,0 +1,154 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	spreadsheetNS   = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	relationshipsNS = "http://schemas.openxmlformats.org/package/2006/relationships"
+	officeRelsNS    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xmlHeader       = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+)
+
+// xlsxWriter writes a workbook with a sheet per table. Sheets go into the
+// archive row by row with inline strings, so no shared string table has to
+// be kept; the workbook parts listing them are written on Close.
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	sheets  []string
+	rows    int
+}
+
+func newXLSXWriter(w io.Writer) tableWriter {
+	return &xlsxWriter{archive: zip.NewWriter(w)}
+}
+
+func (w *xlsxWriter) Table(name string, columns []string) error {
+	if err := w.endSheet(); err != nil {
+		return err
+	}
+	w.sheets = append(w.sheets, name)
+	file, err := createFile(w.archive, fmt.Sprintf("xl/worksheets/sheet%d.xml", len(w.sheets)))
+	if err != nil {
+		return err
+	}
+	w.sheet = bufio.NewWriter(file)
+	w.rows = 0
+	w.sheet.WriteString(xmlHeader + `<worksheet xmlns="` + spreadsheetNS + `"><sheetData>`)
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	return w.Row(header...)
+}
+
+func (w *xlsxWriter) Row(values ...any) error {
+	w.rows++
+	fmt.Fprintf(w.sheet, `<row r="%d">`, w.rows)
+	for _, value := range values {
+		switch v := normalize(value).(type) {
+		case nil:
+			w.sheet.WriteString(`<c/>`)
+		case int64:
+			fmt.Fprintf(w.sheet, `<c><v>%d</v></c>`, v)
+		case float64:
+			fmt.Fprintf(w.sheet, `<c><v>%s</v></c>`, strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			b := 0
+			if v {
+				b = 1
+			}
+			fmt.Fprintf(w.sheet, `<c t="b"><v>%d</v></c>`, b)
+		default:
+			w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			if err := xml.EscapeText(w.sheet, []byte(text(v))); err != nil {
+				return err
+			}
+			w.sheet.WriteString(`</t></is></c>`)
+		}
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	if err := w.endSheet(); err != nil {
+		return err
+	}
+
+	parts := []struct {
+		name    string
+		content func(io.Writer)
+	}{
+		{"[Content_Types].xml", w.contentTypes},
+		{"_rels/.rels", rootRelationships},
+		{"xl/workbook.xml", w.workbook},
+		{"xl/_rels/workbook.xml.rels", w.workbookRelationships},
+	}
+	for _, part := range parts {
+		file, err := createFile(w.archive, part.name)
+		if err != nil {
+			return err
+		}
+		// The buffer keeps the first write error for Flush to report.
+		buffer := bufio.NewWriter(file)
+		part.content(buffer)
+		if err := buffer.Flush(); err != nil {
+			return err
+		}
+	}
+	return w.archive.Close()
+}
+
+func (w *xlsxWriter) endSheet() error {
+	if w.sheet == nil {
+		return nil
+	}
+	w.sheet.WriteString(`</sheetData></worksheet>`)
+	err := w.sheet.Flush()
+	w.sheet = nil
+	return err
+}
+
+func (w *xlsxWriter) contentTypes(out io.Writer) {
+	io.WriteString(out, xmlHeader+`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`+
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`+
+		`<Default Extension="xml" ContentType="application/xml"/>`+
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	for i := range w.sheets {
+		fmt.Fprintf(out, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	io.WriteString(out, `</Types>`)
+}
+
+func rootRelationships(out io.Writer) {
+	io.WriteString(out, xmlHeader+`<Relationships xmlns="`+relationshipsNS+`">`+
+		`<Relationship Id="rId1" Type="`+officeRelsNS+`/officeDocument" Target="xl/workbook.xml"/>`+
+		`</Relationships>`)
+}
+
+func (w *xlsxWriter) workbook(out io.Writer) {
+	io.WriteString(out, xmlHeader+`<workbook xmlns="`+spreadsheetNS+`" xmlns:r="`+officeRelsNS+`"><sheets>`)
+	for i, name := range w.sheets {
+		io.WriteString(out, `<sheet name="`)
+		xml.EscapeText(out, []byte(name))
+		fmt.Fprintf(out, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	io.WriteString(out, `</sheets></workbook>`)
+}
+
+func (w *xlsxWriter) workbookRelationships(out io.Writer) {
+	io.WriteString(out, xmlHeader+`<Relationships xmlns="`+relationshipsNS+`">`)
+	for i := range w.sheets {
+		fmt.Fprintf(out, `<Relationship Id="rId%d" Type="`+officeRelsNS+`/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	io.WriteString(out, `</Relationships>`)
+}
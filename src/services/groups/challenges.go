@@ -0,0 +1,120 @@
+package groups
+
+import (
+	"cmp"
+	"slices"
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+)
+
+// Challenge is a kind of group challenge: Goal reps of the exercise, or
+// seconds when Timed, every day for Days days.
+type Challenge struct {
+	Kind     string
+	Badge    string
+	Exercise string
+	Days     int
+	Goal     int
+	Timed    bool
+}
+
+// Challenges are the challenges a group can start, in the order they are
+// offered. Kinds are stored with started challenges, so they must not
+// change.
+var Challenges = []Challenge{
+	{Kind: "push_up_30", Badge: "💪", Exercise: "push_up", Days: 30, Goal: 50},
+	{Kind: "pull_up_30", Badge: "🧗", Exercise: "pull_up", Days: 30, Goal: 20},
+	{Kind: "burpee_30", Badge: "🔥", Exercise: "burpee", Days: 30, Goal: 30},
+	{Kind: "plank_30", Badge: "🧱", Exercise: "plank", Days: 30, Goal: 120, Timed: true},
+	{Kind: "crunch_14", Badge: "🌀", Exercise: "crunch", Days: 14, Goal: 60},
+}
+
+func Find(kind string) (*Challenge, bool) {
+	for i := range Challenges {
+		if Challenges[i].Kind == kind {
+			return &Challenges[i], true
+		}
+	}
+	return nil, false
+}
+
+// Amount is how much of the exercise a day's sets count for.
+func (c *Challenge) Amount(day *database.ExerciseDay) int {
+	if c.Timed {
+		return day.Seconds
+	}
+	return day.Reps
+}
+
+// Start returns a challenge of this kind for the group starting on the
+// given day.
+func (c *Challenge) Start(groupID uuid.UUID, day time.Time) models.Challenge {
+	return models.Challenge{
+		GroupID:  groupID,
+		Kind:     c.Kind,
+		StartsOn: day,
+		EndsOn:   day.AddDate(0, 0, c.Days-1),
+	}
+}
+
+// Score is a participant's progress on a challenge through a day: the
+// amount done that day, in total, and on how many days the goal was met.
+type Score struct {
+	User    *models.User
+	Today   int
+	Total   int
+	DaysMet int
+}
+
+// Scores totals the participants' exercise days through the given day,
+// best first: most days the goal was met, then the largest total.
+func (c *Challenge) Scores(
+	participants []models.ChallengeParticipant,
+	days []database.ExerciseDay,
+	through time.Time,
+) []Score {
+	scores := make([]Score, len(participants))
+	index := make(map[string]int, len(participants))
+	for i := range participants {
+		scores[i].User = &participants[i].User
+		index[participants[i].UserID.String()] = i
+	}
+
+	last := dateKey(through)
+	for i := range days {
+		at, ok := index[days[i].UserID.String()]
+		key := dateKey(days[i].Day)
+		if !ok || key > last {
+			continue
+		}
+		amount := c.Amount(&days[i])
+		scores[at].Total += amount
+		if amount >= c.Goal {
+			scores[at].DaysMet++
+		}
+		if key == last {
+			scores[at].Today += amount
+		}
+	}
+
+	slices.SortStableFunc(scores, func(a, b Score) int {
+		return cmp.Or(cmp.Compare(b.DaysMet, a.DaysMet), cmp.Compare(b.Total, a.Total))
+	})
+	return scores
+}
+
+// DayNumber is which day of the challenge the given day is, from one.
+func DayNumber(challenge *models.Challenge, day time.Time) int {
+	return int(dateOf(day).Sub(dateOf(challenge.StartsOn)).Hours()/24) + 1
+}
+
+func dateKey(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,68 @@
+package groups
+
+import (
+	"cmp"
+	"slices"
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/achievements"
+	"workouts_bot/src/services/training"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Standing is a member's line on the weekly leaderboard.
+type Standing struct {
+	User     *models.User
+	Sessions int
+	Volume   float64
+	Streak   int
+}
+
+// Leaderboard ranks the group's members by this week's sessions, then
+// volume, then week streak. Members who hide their volume are ranked as if
+// they lifted nothing.
+func Leaderboard(groupID uuid.UUID, now time.Time, db *gorm.DB) ([]Standing, error) {
+	members, err := database.ListGroupMembers(groupID, db)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, len(members))
+	for i := range members {
+		userIDs[i] = members[i].UserID
+	}
+	totals, err := database.WeeklyTotals(userIDs, training.WeekStart(now), db)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := database.ListSessionStartsByUser(userIDs, db)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]Standing, len(members))
+	for i := range members {
+		week := totals[members[i].UserID]
+		if members[i].User.GroupPrivacy == models.GroupPrivacyHideVolume {
+			week.Volume = 0
+		}
+		standings[i] = Standing{
+			User:     &members[i].User,
+			Sessions: week.Sessions,
+			Volume:   week.Volume,
+			Streak:   achievements.WeekStreak(starts[members[i].UserID], achievements.WeeklySessions, now),
+		}
+	}
+
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		return cmp.Or(
+			cmp.Compare(b.Sessions, a.Sessions),
+			cmp.Compare(b.Volume, a.Volume),
+			cmp.Compare(b.Streak, a.Streak),
+		)
+	})
+	return standings, nil
+}
@@ -0,0 +1,73 @@
+package imports
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+)
+
+// fitNotesHour places FitNotes workouts, which only have a date, at midday
+// so they stay on their day in any time zone.
+const fitNotesHour = 12 * time.Hour
+
+// parseFitNotes reads a FitNotes export: one row per set, grouped into one
+// workout per day. The weight header names the unit, "Weight (kgs)" or
+// "Weight (lbs)"; distances have a unit column and times are h:mm:ss.
+func parseFitNotes(reader *csv.Reader, rows sheet, unit string) ([]Workout, error) {
+	weightColumn := rows.first("weight (kgs)", "weight (lbs)", "weight")
+	weightIn := unit
+	switch weightColumn {
+	case "weight (kgs)":
+		weightIn = models.UnitKg
+	case "weight (lbs)":
+		weightIn = models.UnitLb
+	}
+
+	var workouts collector
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalid
+		}
+		rows.record = record
+
+		date := rows.text("date")
+		day, err := parseTime(date, "2006-01-02")
+		if err != nil {
+			return nil, err
+		}
+		workout := workouts.workout(date, func() Workout {
+			return Workout{StartedAt: day.Add(fitNotesHour)}
+		})
+
+		weight, err := rows.number(weightColumn)
+		if err != nil {
+			return nil, err
+		}
+		reps, err := rows.number("reps")
+		if err != nil {
+			return nil, err
+		}
+		distance, err := rows.number("distance")
+		if err != nil {
+			return nil, err
+		}
+
+		set := Set{
+			Reps:     int(reps),
+			Weight:   training.ToKg(weight, weightUnit(rows.text("weight unit"), weightIn)),
+			Distance: meters(distance, rows.text("distance unit"), weightIn),
+			Seconds:  int(parseDuration(rows.text("time")).Seconds()),
+		}
+		if !set.empty() {
+			workout.add(strings.TrimSpace(rows.text("exercise")), set)
+		}
+	}
+	return workouts.workouts, nil
+}
@@ -0,0 +1,90 @@
+package imports
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+)
+
+var hevyLayouts = []string{"2 Jan 2006, 15:04", "2006-01-02 15:04:05", time.RFC3339}
+
+// parseHevy reads a Hevy export: one row per set with the workout's start
+// and end time. The weight and distance columns carry the unit in their
+// names; set_type tells warm-up and drop sets.
+func parseHevy(reader *csv.Reader, rows sheet, unit string) ([]Workout, error) {
+	weightColumn := rows.first("weight_kg", "weight_lbs")
+	weightIn := unit
+	switch weightColumn {
+	case "weight_kg":
+		weightIn = models.UnitKg
+	case "weight_lbs":
+		weightIn = models.UnitLb
+	}
+	distanceColumn := rows.first("distance_km", "distance_miles", "distance_meters")
+	distanceIn := strings.TrimPrefix(distanceColumn, "distance_")
+
+	var workouts collector
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalid
+		}
+		rows.record = record
+
+		start := rows.text("start_time")
+		started, err := parseTime(start, hevyLayouts...)
+		if err != nil {
+			return nil, err
+		}
+		name := rows.text("title")
+		workout := workouts.workout(start+"|"+name, func() Workout {
+			workout := Workout{Name: name, StartedAt: started}
+			if finished, err := parseTime(rows.text("end_time"), hevyLayouts...); err == nil && finished.After(started) {
+				workout.Duration = finished.Sub(started)
+			}
+			return workout
+		})
+
+		weight, err := rows.number(weightColumn)
+		if err != nil {
+			return nil, err
+		}
+		reps, err := rows.number("reps")
+		if err != nil {
+			return nil, err
+		}
+		distance, err := rows.number(distanceColumn)
+		if err != nil {
+			return nil, err
+		}
+		seconds, err := rows.number("duration_seconds")
+		if err != nil {
+			return nil, err
+		}
+		rpe, err := rows.number("rpe")
+		if err != nil {
+			return nil, err
+		}
+
+		kind := strings.ToLower(rows.text("set_type"))
+		set := Set{
+			Warmup:   kind == "warmup",
+			Drop:     kind == "dropset",
+			Reps:     int(reps),
+			Weight:   training.ToKg(weight, weightIn),
+			Distance: meters(distance, distanceIn, weightIn),
+			Seconds:  int(seconds),
+			RPE:      rpe,
+		}
+		if !set.empty() {
+			workout.add(rows.text("exercise_title"), set)
+		}
+	}
+	return workouts.workouts, nil
+}
@@ -0,0 +1,424 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"workouts_bot/src/models"
+)
+
+// Export formats of the apps histories are imported from.
+const (
+	FormatStrong   = "strong"
+	FormatHevy     = "hevy"
+	FormatFitNotes = "fitnotes"
+)
+
+const (
+	// MaxFileSize bounds the exports accepted for import.
+	MaxFileSize = 20 << 20
+
+	kmPerMile = 1.609344
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown export format")
+	ErrInvalid       = errors.New("invalid export file")
+	ErrEmpty         = errors.New("export has no workouts")
+)
+
+// Set is a set as the app logged it, converted to kilograms, meters and
+// seconds. Drop sets extend the set before them.
+type Set struct {
+	Warmup   bool
+	Drop     bool
+	Reps     int
+	Weight   float64
+	Distance float64
+	Seconds  int
+	RPE      float64
+}
+
+// Exercise is an exercise of an imported workout under the app's name.
+type Exercise struct {
+	Name string
+	Sets []Set
+}
+
+// Workout is an imported workout. Key identifies it within the app's
+// exports, so importing the same file twice finds the workouts done.
+type Workout struct {
+	Key       string
+	Name      string
+	StartedAt time.Time
+	Duration  time.Duration
+	Exercises []Exercise
+}
+
+// History is the content of an export.
+type History struct {
+	Format   string
+	Workouts []Workout
+}
+
+// Names returns the exercise names used in the history, in order of first
+// appearance.
+func (h *History) Names() []string {
+	var names []string
+	for _, workout := range h.Workouts {
+		for _, exercise := range workout.Exercises {
+			if !slices.Contains(names, exercise.Name) {
+				names = append(names, exercise.Name)
+			}
+		}
+	}
+	return names
+}
+
+// Supported reports whether the file name looks like a CSV export.
+func Supported(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".csv")
+}
+
+// Parse reads a Strong, Hevy or FitNotes CSV export, telling them apart by
+// the header. unit is what weights are in when the export does not say.
+func Parse(r io.Reader, unit string) (*History, error) {
+	reader := bufio.NewReader(io.LimitReader(r, MaxFileSize))
+	first, err := reader.Peek(min(reader.Size(), 4096))
+	if err != nil && err != io.EOF && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, ErrInvalid
+	}
+
+	// Apps writing in a locale with decimal commas separate with semicolons.
+	line, _, _ := bytes.Cut(first, []byte("\n"))
+	csvReader := csv.NewReader(reader)
+	if bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		csvReader.Comma = ';'
+	}
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	rows := newSheet(header)
+
+	var parse func(*csv.Reader, sheet, string) ([]Workout, error)
+	var format string
+	switch {
+	case rows.has("exercise_title", "start_time"):
+		format, parse = FormatHevy, parseHevy
+	case rows.has("exercise name", "set order"):
+		format, parse = FormatStrong, parseStrong
+	case rows.has("exercise", "category", "date"):
+		format, parse = FormatFitNotes, parseFitNotes
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	workouts, err := parse(csvReader, rows, unit)
+	if err != nil {
+		return nil, err
+	}
+	if len(workouts) == 0 {
+		return nil, ErrEmpty
+	}
+	return &History{Format: format, Workouts: workouts}, nil
+}
+
+// sheet finds columns by their lower-cased header names.
+type sheet struct {
+	columns map[string]int
+	record  []string
+}
+
+func newSheet(header []string) sheet {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		columns[name] = i
+	}
+	return sheet{columns: columns}
+}
+
+func (r sheet) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := r.columns[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// first returns the first of the columns the header has, or "".
+func (r sheet) first(names ...string) string {
+	for _, name := range names {
+		if r.has(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+func (r sheet) text(name string) string {
+	i, ok := r.columns[name]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// number reads a decimal with a point or a comma; empty is zero.
+func (r sheet) number(name string) (float64, error) {
+	text := strings.ReplaceAll(r.text(name), ",", ".")
+	if text == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		return 0, ErrInvalid
+	}
+	return value, nil
+}
+
+// collector groups rows into workouts and exercises in file order.
+type collector struct {
+	workouts []Workout
+	byKey    map[string]int
+}
+
+func (c *collector) workout(key string, build func() Workout) *Workout {
+	if c.byKey == nil {
+		c.byKey = make(map[string]int)
+	}
+	i, ok := c.byKey[key]
+	if !ok {
+		workout := build()
+		workout.Key = key
+		c.workouts = append(c.workouts, workout)
+		i = len(c.workouts) - 1
+		c.byKey[key] = i
+	}
+	return &c.workouts[i]
+}
+
+// add appends the set to the workout's last exercise when it has the name,
+// so an exercise done twice apart stays two entries.
+func (w *Workout) add(name string, set Set) {
+	if n := len(w.Exercises); n == 0 || w.Exercises[n-1].Name != name {
+		w.Exercises = append(w.Exercises, Exercise{Name: name})
+	}
+	exercise := &w.Exercises[len(w.Exercises)-1]
+	exercise.Sets = append(exercise.Sets, set)
+}
+
+func (s *Set) empty() bool {
+	return s.Reps == 0 && s.Weight == 0 && s.Distance == 0 && s.Seconds == 0
+}
+
+// weightUnit reads "kg", "kgs", "lb" or "lbs", falling back to unit.
+func weightUnit(text string, unit string) string {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "lb", "lbs":
+		return models.UnitLb
+	case "kg", "kgs":
+		return models.UnitKg
+	}
+	return unit
+}
+
+// meters converts a distance in km, mi, m, ft or yd. Without a unit it is
+// km for kilogram users and miles for pound users.
+func meters(distance float64, unit string, weight string) float64 {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "m", "meters":
+		return distance
+	case "km", "kilometers":
+		return distance * 1000
+	case "mi", "miles":
+		return distance * kmPerMile * 1000
+	case "ft", "feet":
+		return distance * 0.3048
+	case "yd", "yards":
+		return distance * 0.9144
+	}
+	if weight == models.UnitLb {
+		return distance * kmPerMile * 1000
+	}
+	return distance * 1000
+}
+
+// parseTime reads a local time without a zone in the first layout that
+// fits; exports carry none, so it is taken as UTC.
+func parseTime(text string, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(text)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrInvalid
+}
+
+var durationPart = regexp.MustCompile(`(\d+)\s*([hms])`)
+
+// parseDuration reads "1h 5m", "45m 30s", "h:mm:ss", "mm:ss" or seconds.
+func parseDuration(text string) time.Duration {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(text); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if strings.Contains(text, ":") {
+		var seconds int
+		for _, part := range strings.Split(text, ":") {
+			value, err := strconv.Atoi(part)
+			if err != nil {
+				return 0
+			}
+			seconds = seconds*60 + value
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	var duration time.Duration
+	for _, match := range durationPart.FindAllStringSubmatch(text, -1) {
+		value, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "h":
+			duration += time.Duration(value) * time.Hour
+		case "m":
+			duration += time.Duration(value) * time.Minute
+		case "s":
+			duration += time.Duration(value) * time.Second
+		}
+	}
+	return duration
+}
+
+// Match finds the catalog exercise an app calls name. Names match when they
+// have the same words, in any order, in English, Russian or as the slug.
+// Equipment in brackets, as in "Deadlift (Barbell)", may be left out but
+// must then be the exercise's. Ambiguous names match nothing.
+func Match(name string, catalog []models.Exercise) *models.Exercise {
+	full := words(name)
+	bare := words(brackets.ReplaceAllString(name, " "))
+	var equipment []string
+	for _, word := range words(strings.Join(brackets.FindAllString(name, -1), " ")) {
+		if equipmentWords[word] {
+			equipment = append(equipment, word)
+		}
+	}
+
+	for pass, wanted := range [][]string{full, bare} {
+		var found *models.Exercise
+		for i := range catalog {
+			exercise := &catalog[i]
+			if !sameWords(wanted, exercise) {
+				continue
+			}
+			if pass > 0 && len(equipment) > 0 && !slices.Contains(equipment, exercise.Equipment) {
+				continue
+			}
+			if found != nil {
+				return nil
+			}
+			found = exercise
+		}
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Candidates ranks the catalog by how many words it shares with the name
+// and returns the best limit with at least one in common.
+func Candidates(name string, catalog []models.Exercise, limit int) []models.Exercise {
+	wanted := words(name)
+	type scored struct {
+		exercise models.Exercise
+		score    int
+	}
+	var ranked []scored
+	for _, exercise := range catalog {
+		score := 0
+		for _, known := range [][]string{words(exercise.NameEN), words(exercise.NameRU)} {
+			common := 0
+			for _, word := range wanted {
+				if slices.Contains(known, word) {
+					common++
+				}
+			}
+			score = max(score, common)
+		}
+		if score > 0 {
+			ranked = append(ranked, scored{exercise, score})
+		}
+	}
+	slices.SortStableFunc(ranked, func(a, b scored) int {
+		return b.score - a.score
+	})
+
+	result := make([]models.Exercise, 0, min(limit, len(ranked)))
+	for _, item := range ranked[:min(limit, len(ranked))] {
+		result = append(result, item.exercise)
+	}
+	return result
+}
+
+var brackets = regexp.MustCompile(`\([^)]*\)`)
+
+// equipmentWords are the words apps put in brackets to name the equipment.
+var equipmentWords = map[string]bool{
+	"barbell":    true,
+	"dumbbell":   true,
+	"cable":      true,
+	"machine":    true,
+	"kettlebell": true,
+	"bodyweight": true,
+	"band":       true,
+	"smith":      true,
+}
+
+// words splits a name into lower-cased words, with plural "s" dropped from
+// longer English words so "Dips" and "Dip" agree.
+func words(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len(field) > 3 && strings.HasSuffix(field, "s") && !strings.HasSuffix(field, "ss") {
+			field = strings.TrimSuffix(field, "s")
+		}
+		if !slices.Contains(result, field) {
+			result = append(result, field)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+func sameWords(wanted []string, exercise *models.Exercise) bool {
+	if len(wanted) == 0 {
+		return false
+	}
+	for _, name := range []string{exercise.NameEN, exercise.NameRU, exercise.Slug} {
+		if slices.Equal(wanted, words(name)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+package imports
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"workouts_bot/src/models"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func parseFile(t *testing.T, name string, unit string) *History {
+	t.Helper()
+	file, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	history, err := Parse(file, unit)
+	if err != nil {
+		t.Fatalf("Parse %s: %v", name, err)
+	}
+	return history
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		file string
+		unit string
+		want *History
+	}{
+		{
+			file: "strong.csv",
+			unit: models.UnitKg,
+			want: &History{Format: FormatStrong, Workouts: []Workout{
+				{
+					Key:       "2026-03-02 18:30:00|Push",
+					Name:      "Push",
+					StartedAt: at("2026-03-02 18:30"),
+					Duration:  time.Hour + 5*time.Minute,
+					Exercises: []Exercise{
+						{Name: "Bench Press (Barbell)", Sets: []Set{
+							{Warmup: true, Reps: 10, Weight: 40},
+							{Reps: 8, Weight: 80, RPE: 8},
+							{Drop: true, Reps: 6, Weight: 60},
+						}},
+						// Without a unit column distances are in km for
+						// kilogram users.
+						{Name: "Running", Sets: []Set{{Distance: 5000, Seconds: 1500}}},
+					},
+				},
+				{
+					Key:       "2026-03-04 19:00:00|Legs",
+					Name:      "Legs",
+					StartedAt: at("2026-03-04 19:00"),
+					Duration:  45*time.Minute + 30*time.Second,
+					Exercises: []Exercise{
+						{Name: "Squat (Barbell)", Sets: []Set{{Reps: 5, Weight: 100}}},
+					},
+				},
+			}},
+		},
+		{
+			// Semicolons, decimal commas and the units in their own columns.
+			file: "strong_units.csv",
+			unit: models.UnitLb,
+			want: &History{Format: FormatStrong, Workouts: []Workout{{
+				Key:       "2026-03-02 07:15:00|Morning",
+				Name:      "Morning",
+				StartedAt: at("2026-03-02 07:15"),
+				Duration:  30 * time.Minute,
+				Exercises: []Exercise{
+					{Name: "Deadlift (Barbell)", Sets: []Set{{Reps: 5, Weight: 102.1}}},
+					{Name: "Dumbbell Curl", Sets: []Set{{Reps: 12, Weight: 22.5}}},
+					{Name: "Rowing (Machine)", Sets: []Set{{Distance: 2000, Seconds: 480}}},
+				},
+			}}},
+		},
+		{
+			file: "hevy.csv",
+			unit: models.UnitLb,
+			want: &History{Format: FormatHevy, Workouts: []Workout{{
+				Key:       "2 Mar 2026, 18:30|Upper",
+				Name:      "Upper",
+				StartedAt: at("2026-03-02 18:30"),
+				Duration:  time.Hour + 5*time.Minute,
+				Exercises: []Exercise{
+					{Name: "Bench Press (Barbell)", Sets: []Set{
+						{Warmup: true, Reps: 10, Weight: 40},
+						{Reps: 8, Weight: 80, RPE: 8.5},
+						{Drop: true, Reps: 6, Weight: 60},
+						{Reps: 5, Weight: 80, RPE: 10},
+					}},
+					{Name: "Treadmill", Sets: []Set{{Distance: 2500, Seconds: 900}}},
+				},
+			}}},
+		},
+		{
+			file: "hevy_lbs.csv",
+			unit: models.UnitKg,
+			want: &History{Format: FormatHevy, Workouts: []Workout{{
+				Key:       "2026-03-04 19:00:00|Legs",
+				Name:      "Legs",
+				StartedAt: at("2026-03-04 19:00"),
+				Duration:  45 * time.Minute,
+				Exercises: []Exercise{
+					{Name: "Squat (Barbell)", Sets: []Set{{Reps: 5, Weight: 102.1}}},
+					{Name: "Running", Sets: []Set{{Distance: kmPerMile * 1000, Seconds: 600}}},
+				},
+			}}},
+		},
+		{
+			file: "fitnotes.csv",
+			unit: models.UnitLb,
+			want: &History{Format: FormatFitNotes, Workouts: []Workout{
+				{
+					Key:       "2026-03-02",
+					StartedAt: at("2026-03-02 12:00"),
+					Exercises: []Exercise{
+						{Name: "Flat Barbell Bench Press", Sets: []Set{{Reps: 8, Weight: 80}, {Reps: 7, Weight: 80}}},
+						{Name: "Running (Outdoor)", Sets: []Set{{Distance: 5000, Seconds: 1530}}},
+					},
+				},
+				{
+					Key:       "2026-03-04",
+					StartedAt: at("2026-03-04 12:00"),
+					Exercises: []Exercise{
+						{Name: "Barbell Squat", Sets: []Set{{Reps: 5, Weight: 100}}},
+					},
+				},
+			}},
+		},
+		{
+			file: "fitnotes_lbs.csv",
+			unit: models.UnitKg,
+			want: &History{Format: FormatFitNotes, Workouts: []Workout{{
+				Key:       "2026-03-02",
+				StartedAt: at("2026-03-02 12:00"),
+				Exercises: []Exercise{
+					{Name: "Deadlift", Sets: []Set{{Reps: 3, Weight: 142.9}}},
+					{Name: "Cycling", Sets: []Set{{Distance: 10 * kmPerMile * 1000, Seconds: 3600}}},
+				},
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			got := parseFile(t, tt.file, tt.unit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    error
+	}{
+		{"empty file", "", ErrInvalid},
+		{"unknown header", "Date,Activity,Calories\n2026-03-02,Walk,200\n", ErrUnknownFormat},
+		{"header only", "Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps\n", ErrEmpty},
+		{"bad weight", "Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps\n2026-03-02 18:30:00,Push,1h,Bench,1,heavy,8\n", ErrInvalid},
+		{"negative reps", "Date,Exercise,Category,Weight (kgs),Reps\n2026-03-02,Bench,Chest,80,-8\n", ErrInvalid},
+		{"bad date", "Date,Exercise,Category,Weight (kgs),Reps\n02/03/2026,Bench,Chest,80,8\n", ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.content), models.UnitKg); !errors.Is(err, tt.want) {
+				t.Errorf("Parse error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		text string
+		want time.Duration
+	}{
+		{"", 0},
+		{"90", 90 * time.Second},
+		{"1h 5m", time.Hour + 5*time.Minute},
+		{"45m 30s", 45*time.Minute + 30*time.Second},
+		{"2h", 2 * time.Hour},
+		{"1:02:03", time.Hour + 2*time.Minute + 3*time.Second},
+		{"25:30", 25*time.Minute + 30*time.Second},
+		{"1:xx", 0},
+		{"soon", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseDuration(tt.text); got != tt.want {
+			t.Errorf("parseDuration(%q) = %s, want %s", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMeters(t *testing.T) {
+	tests := []struct {
+		distance float64
+		unit     string
+		weight   string
+		want     float64
+	}{
+		{400, "m", models.UnitKg, 400},
+		{5, "km", models.UnitLb, 5000},
+		{2, "mi", models.UnitKg, 2 * kmPerMile * 1000},
+		{100, "ft", models.UnitKg, 30.48},
+		{100, "yd", models.UnitKg, 91.44},
+		{3, " Miles ", models.UnitKg, 3 * kmPerMile * 1000},
+		{5, "", models.UnitKg, 5000},
+		{5, "", models.UnitLb, 5 * kmPerMile * 1000},
+	}
+
+	for _, tt := range tests {
+		if got := meters(tt.distance, tt.unit, tt.weight); got != tt.want {
+			t.Errorf("meters(%v, %q, %s) = %v, want %v", tt.distance, tt.unit, tt.weight, got, tt.want)
+		}
+	}
+}
+
+var catalog = []models.Exercise{
+	{Slug: "bench_press", NameEN: "Bench Press", NameRU: "Жим лёжа", Equipment: "barbell"},
+	{Slug: "dumbbell_bench_press", NameEN: "Dumbbell Bench Press", NameRU: "Жим гантелей лёжа", Equipment: "dumbbell"},
+	{Slug: "squat", NameEN: "Barbell Squat", NameRU: "Приседания со штангой", Equipment: "barbell"},
+	{Slug: "dips", NameEN: "Dips", NameRU: "Отжимания на брусьях", Equipment: "bodyweight"},
+	{Slug: "cable_row", NameEN: "Seated Row", NameRU: "Тяга в блоке сидя", Equipment: "cable"},
+	{Slug: "machine_row", NameEN: "Seated Row", NameRU: "Тяга в тренажёре сидя", Equipment: "machine"},
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Bench Press", "bench_press"},
+		{"bench press (barbell)", "bench_press"},
+		{"Press, Bench", "bench_press"},
+		{"Dumbbell Bench Press", "dumbbell_bench_press"},
+		{"Squat (Barbell)", "squat"},
+		{"Barbell Squat", "squat"},
+		{"Приседания со штангой", "squat"},
+		{"squat", "squat"},
+		{"Dip", "dips"},
+		{"Seated Row (Cable)", "cable_row"},
+		{"Seated Row (Machine)", "machine_row"},
+		{"Seated Row", ""},
+		{"Seated Row (Band)", ""},
+		{"Bench Press (Dumbbell)", "dumbbell_bench_press"},
+		{"Dips (Band)", ""},
+		{"Bench Press (Paused)", "bench_press"},
+		{"Curl", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		got := ""
+		if exercise := Match(tt.name, catalog); exercise != nil {
+			got = exercise.Slug
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package imports
+
+import (
+	"workouts_bot/src/database"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Resolution is how the exercise names of an export map to the catalog.
+// Exercises holds the resolved names, skipped ones mapping to nil; Unknown
+// the names nothing resolves, in order.
+type Resolution struct {
+	Exercises map[string]*models.Exercise
+	Unknown   []string
+	Catalog   []models.Exercise
+}
+
+// Resolve maps the history's exercise names to catalog exercises, the
+// user's earlier answers first.
+func Resolve(userID uuid.UUID, history *History, db *gorm.DB) (*Resolution, error) {
+	catalog, err := database.ListExercises(db)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := database.ListImportAliases(userID, db)
+	if err != nil {
+		return nil, err
+	}
+
+	exercises, unknown := resolveNames(history.Names(), catalog, aliases)
+	return &Resolution{Exercises: exercises, Unknown: unknown, Catalog: catalog}, nil
+}
+
+// Save stores the history's workouts as finished sessions, naming those
+// without a name name. Workouts imported before are skipped; it returns
+// how many were imported and how many existed.
+func Save(
+	user *models.User,
+	history *History,
+	exercises map[string]*models.Exercise,
+	name string,
+	db *gorm.DB,
+) (int, int, error) {
+	imported, existing := 0, 0
+	for i := range history.Workouts {
+		session, entries := importedSession(user, history.Format, &history.Workouts[i], exercises, name)
+		if len(session.Exercises) == 0 {
+			continue
+		}
+		saved, err := database.ImportSession(session, entries, db)
+		if err != nil {
+			return imported, existing, err
+		}
+		if saved {
+			imported++
+		} else {
+			existing++
+		}
+	}
+	return imported, existing, nil
+}
+
+// resolveNames maps the names to catalog exercises, the user's choices
+// first. Skipped names map to nil; names nothing resolves are returned in
+// order.
+func resolveNames(
+	names []string,
+	catalog []models.Exercise,
+	aliases map[string]*uuid.UUID,
+) (map[string]*models.Exercise, []string) {
+	byID := make(map[uuid.UUID]*models.Exercise, len(catalog))
+	for i := range catalog {
+		byID[catalog[i].ID] = &catalog[i]
+	}
+
+	exercises := make(map[string]*models.Exercise, len(names))
+	var unknown []string
+	for _, name := range names {
+		if id, ok := aliases[name]; ok {
+			if id != nil {
+				exercises[name] = byID[*id]
+			}
+			continue
+		}
+		if exercise := Match(name, catalog); exercise != nil {
+			exercises[name] = exercise
+			continue
+		}
+		unknown = append(unknown, name)
+	}
+	return exercises, unknown
+}
+
+// importedSession builds a finished session from an imported workout,
+// named name when the workout has no name. Exercises the user skipped are
+// left out; cardio exercises also get an activity per set, as when logged
+// in a session.
+func importedSession(
+	user *models.User,
+	format string,
+	workout *Workout,
+	exercises map[string]*models.Exercise,
+	name string,
+) (*models.WorkoutSession, []models.CardioEntry) {
+	key := format + ":" + workout.Key
+	finished := workout.StartedAt.Add(workout.Duration)
+	session := &models.WorkoutSession{
+		UserID:     user.ID,
+		Name:       workout.Name,
+		StartedAt:  workout.StartedAt,
+		FinishedAt: &finished,
+		ImportKey:  &key,
+	}
+	if session.Name == "" {
+		session.Name = name
+	}
+
+	var entries []models.CardioEntry
+	for _, item := range workout.Exercises {
+		exercise := exercises[item.Name]
+		if exercise == nil {
+			continue
+		}
+
+		sessionExercise := models.SessionExercise{
+			ExerciseID: exercise.ID,
+			Position:   len(session.Exercises) + 1,
+		}
+		// Drop sets extend the last working set before them.
+		var parent models.SessionSet
+		for _, set := range item.Sets {
+			logged := models.SessionSet{
+				ID:      uuid.New(),
+				Reps:    set.Reps,
+				Weight:  set.Weight,
+				Warmup:  set.Warmup,
+				Seconds: set.Seconds,
+			}
+			if set.RPE >= 6 && set.RPE <= 10 {
+				logged.RPE = set.RPE
+			}
+
+			switch {
+			case set.Drop && parent.ID != uuid.Nil:
+				logged.Kind = models.SetKindDrop
+				logged.ParentID = &parent.ID
+				logged.Number = parent.Number
+			case set.Warmup:
+				logged.Number = sessionExercise.Logged() + 1
+				sessionExercise.Warmup = append(sessionExercise.Warmup, models.PlannedSet{
+					Reps: set.Reps, Weight: set.Weight, Warmup: true,
+				})
+			default:
+				logged.Number = sessionExercise.Logged() + 1
+				sessionExercise.TargetSets++
+				if sessionExercise.TargetSets == 1 {
+					sessionExercise.TargetReps = set.Reps
+					sessionExercise.TargetWeight = set.Weight
+				}
+			}
+			sessionExercise.Sets = append(sessionExercise.Sets, logged)
+			if logged.ParentID == nil && !logged.Warmup {
+				parent = logged
+			}
+
+			if exercise.Cardio() && set.Seconds > 0 {
+				entries = append(entries, models.CardioEntry{
+					UserID:          user.ID,
+					ExerciseID:      exercise.ID,
+					StartedAt:       workout.StartedAt,
+					DurationSeconds: set.Seconds,
+					DistanceMeters:  set.Distance,
+					Source:          format,
+				})
+			}
+		}
+		session.Exercises = append(session.Exercises, sessionExercise)
+	}
+	return session, entries
+}
@@ -0,0 +1,181 @@
+package imports
+
+import (
+	"testing"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const uuidDefault = `DEFAULT (lower(
+	hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || hex(randomblob(2)) || '-' ||
+	hex(randomblob(2)) || '-' || hex(randomblob(6))
+))`
+
+// sessionsDatabase is an in-memory database with the tables an import
+// writes to.
+func sessionsDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	logger.InitSimple("panic")
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The attached schema lives in the one connection.
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	for _, statement := range []string{
+		"ATTACH DATABASE ':memory:' AS workouts",
+		`CREATE TABLE workouts.workout_sessions (
+			id TEXT PRIMARY KEY ` + uuidDefault + `,
+			user_id TEXT NOT NULL,
+			template_id TEXT,
+			enrollment_id TEXT,
+			import_key TEXT,
+			name TEXT NOT NULL,
+			started_at DATETIME NOT NULL,
+			finished_at DATETIME,
+			created_at DATETIME,
+			updated_at DATETIME
+		)`,
+		`CREATE UNIQUE INDEX workouts.idx_workout_sessions_import_key
+			ON workout_sessions (user_id, import_key)
+			WHERE import_key IS NOT NULL`,
+		`CREATE TABLE workouts.session_exercises (
+			id TEXT PRIMARY KEY ` + uuidDefault + `,
+			session_id TEXT NOT NULL,
+			exercise_id TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			target_sets INTEGER NOT NULL DEFAULT 3,
+			target_reps INTEGER NOT NULL DEFAULT 10,
+			target_weight REAL NOT NULL DEFAULT 0,
+			rest_seconds INTEGER NOT NULL DEFAULT 90,
+			work_seconds INTEGER NOT NULL DEFAULT 0,
+			linked BOOLEAN NOT NULL DEFAULT FALSE,
+			plan TEXT,
+			warmup TEXT,
+			created_at DATETIME,
+			updated_at DATETIME
+		)`,
+		`CREATE TABLE workouts.session_sets (
+			id TEXT PRIMARY KEY ` + uuidDefault + `,
+			session_exercise_id TEXT NOT NULL,
+			number INTEGER NOT NULL,
+			reps INTEGER NOT NULL DEFAULT 0,
+			weight REAL NOT NULL DEFAULT 0,
+			skipped BOOLEAN NOT NULL DEFAULT FALSE,
+			rpe REAL NOT NULL DEFAULT 0,
+			warmup BOOLEAN NOT NULL DEFAULT FALSE,
+			kind TEXT NOT NULL DEFAULT '',
+			parent_id TEXT,
+			seconds INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME
+		)`,
+		`CREATE TABLE workouts.cardio_entries (
+			id TEXT PRIMARY KEY ` + uuidDefault + `,
+			user_id TEXT NOT NULL,
+			exercise_id TEXT NOT NULL,
+			session_id TEXT,
+			started_at DATETIME NOT NULL,
+			duration_seconds INTEGER NOT NULL,
+			distance_meters REAL NOT NULL DEFAULT 0,
+			heart_rate INTEGER NOT NULL DEFAULT 0,
+			effort INTEGER NOT NULL DEFAULT 0,
+			source TEXT NOT NULL DEFAULT '',
+			elevation_gain REAL NOT NULL DEFAULT 0,
+			max_heart_rate INTEGER NOT NULL DEFAULT 0,
+			heart_rates TEXT,
+			created_at DATETIME
+		)`,
+	} {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+// rowCounts counts the rows of every table an import writes to.
+func rowCounts(t *testing.T, db *gorm.DB) map[string]int64 {
+	t.Helper()
+	counts := make(map[string]int64)
+	for _, table := range []string{
+		"workouts.workout_sessions",
+		"workouts.session_exercises",
+		"workouts.session_sets",
+		"workouts.cardio_entries",
+	} {
+		var count int64
+		if err := db.Table(table).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		counts[table] = count
+	}
+	return counts
+}
+
+func TestSaveTwice(t *testing.T) {
+	db := sessionsDatabase(t)
+	user := &models.User{ID: uuid.New()}
+	bench := &models.Exercise{ID: uuid.New(), Slug: "bench_press", Category: models.CategoryCompound}
+	running := &models.Exercise{ID: uuid.New(), Slug: "running", Category: models.CategoryCardio}
+	exercises := map[string]*models.Exercise{
+		"Bench Press (Barbell)": bench,
+		"Running":               running,
+		"Squat (Barbell)":       nil,
+	}
+
+	imported, existing, err := Save(user, parseFile(t, "strong.csv", models.UnitKg), exercises, "Imported", db)
+	if err != nil {
+		t.Fatalf("first Save: %v", err)
+	}
+	// Legs only has the skipped squat, so it is left out.
+	if imported != 1 || existing != 0 {
+		t.Errorf("first Save = %d imported, %d existing, want 1, 0", imported, existing)
+	}
+	want := map[string]int64{
+		"workouts.workout_sessions":  1,
+		"workouts.session_exercises": 2,
+		"workouts.session_sets":      4,
+		"workouts.cardio_entries":    1,
+	}
+	counts := rowCounts(t, db)
+	for table, count := range want {
+		if counts[table] != count {
+			t.Errorf("%s has %d rows after the first import, want %d", table, counts[table], count)
+		}
+	}
+
+	// The same file again, parsed anew as when it is sent a second time.
+	imported, existing, err = Save(user, parseFile(t, "strong.csv", models.UnitKg), exercises, "Imported", db)
+	if err != nil {
+		t.Fatalf("second Save: %v", err)
+	}
+	if imported != 0 || existing != 1 {
+		t.Errorf("second Save = %d imported, %d existing, want 0, 1", imported, existing)
+	}
+	counts = rowCounts(t, db)
+	for table, count := range want {
+		if counts[table] != count {
+			t.Errorf("%s has %d rows after importing again, want %d", table, counts[table], count)
+		}
+	}
+
+	// Another user importing the same file gets their own sessions.
+	other := &models.User{ID: uuid.New()}
+	if imported, _, err := Save(other, parseFile(t, "strong.csv", models.UnitKg), exercises, "Imported", db); err != nil || imported != 1 {
+		t.Errorf("Save for another user = %d, %v, want 1 imported", imported, err)
+	}
+}
@@ -0,0 +1,90 @@
+package imports
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"workouts_bot/src/services/training"
+)
+
+// parseStrong reads a Strong export: one row per set with the workout's
+// start time, name and duration. Set Order is the set number, or W, D and
+// F for warm-up, drop and failure sets; rest timer rows are skipped.
+// Older exports name the weight and distance units in their own columns.
+func parseStrong(reader *csv.Reader, rows sheet, unit string) ([]Workout, error) {
+	var workouts collector
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalid
+		}
+		rows.record = record
+
+		order := strings.ToUpper(rows.text("set order"))
+		if _, err := strconv.Atoi(order); err != nil && order != "W" && order != "D" && order != "F" {
+			continue
+		}
+
+		date := rows.text("date")
+		started, err := parseTime(date, "2006-01-02 15:04:05", "2006-01-02 15:04", time.RFC3339)
+		if err != nil {
+			return nil, err
+		}
+		name := rows.text("workout name")
+		workout := workouts.workout(date+"|"+name, func() Workout {
+			return Workout{
+				Name:      name,
+				StartedAt: started,
+				Duration:  parseDuration(rows.text("duration")),
+			}
+		})
+
+		set, err := strongSet(rows, unit)
+		if err != nil {
+			return nil, err
+		}
+		set.Warmup = order == "W"
+		set.Drop = order == "D"
+		if !set.empty() {
+			workout.add(rows.text("exercise name"), set)
+		}
+	}
+	return workouts.workouts, nil
+}
+
+func strongSet(rows sheet, unit string) (Set, error) {
+	weight, err := rows.number("weight")
+	if err != nil {
+		return Set{}, err
+	}
+	reps, err := rows.number("reps")
+	if err != nil {
+		return Set{}, err
+	}
+	distance, err := rows.number("distance")
+	if err != nil {
+		return Set{}, err
+	}
+	seconds, err := rows.number("seconds")
+	if err != nil {
+		return Set{}, err
+	}
+	rpe, err := rows.number("rpe")
+	if err != nil {
+		return Set{}, err
+	}
+
+	weightIn := weightUnit(rows.text("weight unit"), unit)
+	return Set{
+		Reps:     int(reps),
+		Weight:   training.ToKg(weight, weightIn),
+		Distance: meters(distance, rows.text("distance unit"), weightIn),
+		Seconds:  int(seconds),
+		RPE:      rpe,
+	}, nil
+}
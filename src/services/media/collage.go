@@ -0,0 +1,63 @@
+package media
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+const (
+	collageMaxHeight = 1280
+	collageGap       = 16
+)
+
+// Collage scales both images to a common height and places them side by
+// side on a white background.
+func Collage(left image.Image, right image.Image) *image.RGBA {
+	height := min(left.Bounds().Dy(), right.Bounds().Dy(), collageMaxHeight)
+	leftScaled := scaleToHeight(left, height)
+	rightScaled := scaleToHeight(right, height)
+
+	width := leftScaled.Bounds().Dx() + collageGap + rightScaled.Bounds().Dx()
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(canvas, leftScaled.Bounds(), leftScaled, image.Point{}, draw.Src)
+
+	offset := image.Pt(leftScaled.Bounds().Dx()+collageGap, 0)
+	draw.Draw(canvas, rightScaled.Bounds().Add(offset), rightScaled, image.Point{}, draw.Src)
+	return canvas
+}
+
+// scaleToHeight resizes src keeping its aspect ratio. Each target pixel
+// averages the source pixels it covers, which is enough for photos that are
+// only ever scaled down.
+func scaleToHeight(src image.Image, height int) *image.RGBA {
+	bounds := src.Bounds()
+	width := max(1, bounds.Dx()*height/max(1, bounds.Dy()))
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := range height {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := range width {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+pr, g+pg, b+pb, a+pa
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
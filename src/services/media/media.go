@@ -0,0 +1,281 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"workouts_bot/src/bot/sender"
+	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	exercisePrefix   = "exercises"
+	userPrefix       = "users"
+	presignedURLTTL  = 15 * time.Minute
+	downloadTimeout  = time.Minute
+	defaultExtension = ".bin"
+)
+
+var ErrStorageDisabled = errors.New("media storage is not configured")
+
+// Storage is the part of the S3 client the media service needs. It is
+// satisfied by *s3.Client from github.com/aranoy15/go-s3 and by any
+// S3-compatible stand-in such as MinIO behind the same client.
+type Storage interface {
+	UploadFile(ctx context.Context, objectID string, key string, body io.Reader, contentType string) (string, error)
+	DeleteFile(ctx context.Context, key string) error
+	GetPresignedURL(ctx context.Context, key string, expiration time.Duration) (string, error)
+	// GetObjects returns presigned URLs of the objects under the prefix.
+	GetObjects(ctx context.Context, prefix string) ([]string, error)
+}
+
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"video/mp4":  ".mp4",
+}
+
+// Service keeps exercise media in S3 and sends it to chats. Sends reuse the
+// cached Telegram file ID and fall back to a presigned S3 URL, caching the
+// file ID Telegram returns.
+type Service struct {
+	bot      sender.Sender
+	database *gorm.DB
+	storage  Storage
+	token    string
+	client   *http.Client
+}
+
+// NewService creates the service. storage may be nil, in which case media
+// with cached file IDs is still served but nothing can be uploaded.
+func NewService(bot sender.Sender, database *gorm.DB, storage Storage, token string) *Service {
+	return &Service{
+		bot:      bot,
+		database: database,
+		storage:  storage,
+		token:    token,
+		client:   &http.Client{Timeout: downloadTimeout},
+	}
+}
+
+func (s *Service) Enabled() bool {
+	return s.storage != nil
+}
+
+// Store copies a file the user sent to the bot into the bucket under the
+// exercise's prefix and records it. The Telegram file ID is cached right away.
+func (s *Service) Store(
+	ctx context.Context,
+	exercise *models.Exercise,
+	kind string,
+	fileID string,
+	contentType string,
+) (*models.ExerciseMedia, error) {
+	if s.storage == nil {
+		return nil, ErrStorageDisabled
+	}
+
+	body, err := s.downloadAll(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	extension, ok := extensions[contentType]
+	if !ok {
+		extension = defaultExtension
+	}
+	objectID := exercisePrefix + "/" + exercise.Slug
+	name := uuid.NewString() + extension
+
+	if _, err := s.storage.UploadFile(ctx, objectID, name, body, contentType); err != nil {
+		logger.WithFields(logrus.Fields{
+			"exercise_id": exercise.ID,
+			"error":       err,
+		}).Error("Failed to upload exercise media")
+		return nil, err
+	}
+
+	media := &models.ExerciseMedia{
+		ExerciseID:     exercise.ID,
+		Kind:           kind,
+		ObjectKey:      objectID + "/" + name,
+		ContentType:    contentType,
+		TelegramFileID: fileID,
+	}
+	if err := database.CreateExerciseMedia(media, s.database); err != nil {
+		_ = s.storage.DeleteFile(ctx, media.ObjectKey)
+		return nil, err
+	}
+	return media, nil
+}
+
+// Send delivers the media to the chat with an optional caption.
+func (s *Service) Send(ctx context.Context, chatID int64, media *models.ExerciseMedia, caption string) error {
+	fileID, err := s.send(ctx, chatID, media.Kind, media.TelegramFileID, media.ObjectKey, caption)
+	if err != nil {
+		return err
+	}
+	if fileID != "" && fileID != media.TelegramFileID {
+		_ = database.UpdateExerciseMediaFileID(media, fileID, s.database)
+	}
+	return nil
+}
+
+// send tries the cached file ID first and falls back to a presigned URL for
+// the object. It returns the file ID Telegram assigned on the fallback path.
+func (s *Service) send(
+	ctx context.Context,
+	chatID int64,
+	kind string,
+	fileID string,
+	objectKey string,
+	caption string,
+) (string, error) {
+	if fileID != "" {
+		_, err := s.bot.Send(message(chatID, kind, tgbotapi.FileID(fileID), caption))
+		if err == nil || errors.Is(err, sender.ErrClosed) {
+			return fileID, err
+		}
+		logger.WithFields(logrus.Fields{
+			"object_key": objectKey,
+			"error":      err,
+		}).Warn("Cached file ID rejected, sending from storage")
+	}
+
+	if s.storage == nil {
+		return "", ErrStorageDisabled
+	}
+
+	url, err := s.storage.GetPresignedURL(ctx, objectKey, presignedURLTTL)
+	if err != nil {
+		return "", err
+	}
+
+	sent, err := s.bot.Send(message(chatID, kind, tgbotapi.FileURL(url), caption))
+	if err != nil {
+		return "", err
+	}
+	return fileIDOf(kind, sent), nil
+}
+
+// Open downloads a file the user sent to the bot.
+func (s *Service) Open(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	return s.download(ctx, fileID)
+}
+
+func (s *Service) download(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	response, err := s.bot.Request(tgbotapi.FileConfig{FileID: fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	var file tgbotapi.File
+	if err := json.Unmarshal(response.Result, &file); err != nil {
+		return nil, err
+	}
+	return s.get(ctx, file.Link(s.token))
+}
+
+// downloadAll reads a file the user sent into memory: the S3 client needs a
+// seekable body to sign the upload. Telegram serves bots files of up to 20 MB.
+func (s *Service) downloadAll(ctx context.Context, fileID string) (*bytes.Reader, error) {
+	body, err := s.download(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// objectKey returns the key a presigned URL of an object under the prefix
+// points at. The client addresses objects by path, after the bucket.
+func objectKey(presigned string, prefix string) (string, error) {
+	parsed, err := url.Parse(presigned)
+	if err != nil {
+		return "", err
+	}
+	i := strings.Index(parsed.Path, "/"+prefix)
+	if i < 0 {
+		return "", fmt.Errorf("presigned URL outside %s", prefix)
+	}
+	return parsed.Path[i+1:], nil
+}
+
+// fetch reads an object back from the bucket through a presigned URL.
+func (s *Service) fetch(ctx context.Context, key string) (io.ReadCloser, error) {
+	if s.storage == nil {
+		return nil, ErrStorageDisabled
+	}
+
+	url, err := s.storage.GetPresignedURL(ctx, key, presignedURLTTL)
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, url)
+}
+
+func (s *Service) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		// The URL carries the bot token or a signature, so it is not logged.
+		return nil, fmt.Errorf("download: status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func message(chatID int64, kind string, file tgbotapi.RequestFileData, caption string) tgbotapi.Chattable {
+	switch kind {
+	case models.MediaKindAnimation:
+		animation := tgbotapi.NewAnimation(chatID, file)
+		animation.Caption = caption
+		return animation
+	case models.MediaKindVideo:
+		video := tgbotapi.NewVideo(chatID, file)
+		video.Caption = caption
+		return video
+	default:
+		photo := tgbotapi.NewPhoto(chatID, file)
+		photo.Caption = caption
+		return photo
+	}
+}
+
+func fileIDOf(kind string, message tgbotapi.Message) string {
+	switch {
+	case kind == models.MediaKindAnimation && message.Animation != nil:
+		return message.Animation.FileID
+	case kind == models.MediaKindVideo && message.Video != nil:
+		return message.Video.FileID
+	case len(message.Photo) > 0:
+		return message.Photo[len(message.Photo)-1].FileID
+	}
+	return ""
+}
@@ -0,0 +1,560 @@
+package media
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+	"workouts_bot/src/clients"
+	"workouts_bot/src/config"
+	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	photoBytes = "jpeg bytes"
+	uploadedID = "uploaded-file-id"
+	testBucket = "media"
+)
+
+// testStorage is the S3 client the bot uses, talking to an in-memory S3
+// server whose objects the tests look at directly.
+type testStorage struct {
+	Storage
+	backend *s3mem.Backend
+	url     string
+}
+
+func newTestStorage(t *testing.T) *testStorage {
+	t.Helper()
+	backend := s3mem.New()
+	if err := backend.CreateBucket(testBucket); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(gofakes3.New(backend).Server())
+	t.Cleanup(server.Close)
+
+	client, err := clients.NewS3Client(&config.S3Config{
+		Endpoint:        server.URL,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		BucketName:      testBucket,
+		Region:          "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testStorage{Storage: client, backend: backend, url: server.URL + "/" + testBucket + "/"}
+}
+
+func (s *testStorage) put(t *testing.T, key, body string) {
+	t.Helper()
+	meta := map[string]string{"Content-Type": "image/jpeg"}
+	if _, err := s.backend.PutObject(testBucket, key, meta, strings.NewReader(body), int64(len(body))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// object returns the stored object's body and content type.
+func (s *testStorage) object(t *testing.T, key string) (string, string) {
+	t.Helper()
+	object, err := s.backend.GetObject(testBucket, key, nil)
+	if err != nil {
+		t.Fatalf("object %s: %v", key, err)
+	}
+	defer object.Contents.Close()
+	body, err := io.ReadAll(object.Contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body), object.Metadata["Content-Type"]
+}
+
+func (s *testStorage) keys(t *testing.T) []string {
+	t.Helper()
+	list, err := s.backend.ListBucket(testBucket, nil, gofakes3.ListBucketPage{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, object := range list.Contents {
+		keys = append(keys, object.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// key returns the object key a presigned URL of the storage points at.
+func (s *testStorage) key(url string) string {
+	key, _, _ := strings.Cut(strings.TrimPrefix(url, s.url), "?")
+	return key
+}
+
+// fakeTelegram answers getFile requests and rejects sends of the file IDs
+// in rejected. Sends by URL come back with uploadedID.
+type fakeTelegram struct {
+	rejected map[string]bool
+	sent     []tgbotapi.RequestFileData
+}
+
+func (t *fakeTelegram) Send(chattable tgbotapi.Chattable) (tgbotapi.Message, error) {
+	return t.SendContext(context.Background(), chattable)
+}
+
+func (t *fakeTelegram) Request(chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	return t.RequestContext(context.Background(), chattable)
+}
+
+func (t *fakeTelegram) SendContext(_ context.Context, chattable tgbotapi.Chattable) (tgbotapi.Message, error) {
+	var file tgbotapi.RequestFileData
+	switch config := chattable.(type) {
+	case tgbotapi.PhotoConfig:
+		file = config.File
+	case tgbotapi.VideoConfig:
+		file = config.File
+	case tgbotapi.AnimationConfig:
+		file = config.File
+	default:
+		return tgbotapi.Message{}, errors.New("unexpected chattable")
+	}
+	t.sent = append(t.sent, file)
+
+	if id, ok := file.(tgbotapi.FileID); ok && t.rejected[string(id)] {
+		return tgbotapi.Message{}, errors.New("Bad Request: wrong file identifier")
+	}
+	return tgbotapi.Message{
+		Photo:     []tgbotapi.PhotoSize{{FileID: "thumbnail"}, {FileID: uploadedID}},
+		Video:     &tgbotapi.Video{FileID: uploadedID},
+		Animation: &tgbotapi.Animation{FileID: uploadedID},
+	}, nil
+}
+
+func (t *fakeTelegram) RequestContext(_ context.Context, chattable tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	config, ok := chattable.(tgbotapi.FileConfig)
+	if !ok {
+		return nil, errors.New("unexpected request")
+	}
+	result, _ := json.Marshal(tgbotapi.File{FileID: config.FileID, FilePath: "photos/" + config.FileID + ".jpg"})
+	return &tgbotapi.APIResponse{Ok: true, Result: result}, nil
+}
+
+// roundTripper serves Telegram file downloads without a network and
+// passes other requests, such as those to the test S3 server, through.
+type roundTripper func(*http.Request) *http.Response
+
+func (f roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !strings.HasPrefix(request.URL.Path, "/file/bot") {
+		return http.DefaultTransport.RoundTrip(request)
+	}
+	return f(request), nil
+}
+
+func telegramFiles(request *http.Request) *http.Response {
+	status, body := http.StatusNotFound, ""
+	if strings.HasPrefix(request.URL.Path, "/file/bottoken/photos/") {
+		status, body = http.StatusOK, photoBytes
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    request,
+	}
+}
+
+// mediaDatabase is an in-memory database with the exercise_media and
+// progress_photos tables.
+func mediaDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	logger.InitSimple("panic")
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The attached schema lives in the one connection.
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	for _, statement := range []string{
+		"ATTACH DATABASE ':memory:' AS workouts",
+		`CREATE TABLE workouts.exercise_media (
+			id TEXT PRIMARY KEY DEFAULT (lower(
+				hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || hex(randomblob(2)) || '-' ||
+				hex(randomblob(2)) || '-' || hex(randomblob(6))
+			)),
+			exercise_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			object_key TEXT NOT NULL UNIQUE,
+			content_type TEXT NOT NULL,
+			telegram_file_id TEXT,
+			created_at DATETIME,
+			updated_at DATETIME
+		)`,
+		`CREATE TABLE workouts.progress_photos (
+			id TEXT PRIMARY KEY DEFAULT (lower(
+				hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || hex(randomblob(2)) || '-' ||
+				hex(randomblob(2)) || '-' || hex(randomblob(6))
+			)),
+			user_id TEXT NOT NULL,
+			pose TEXT NOT NULL,
+			object_key TEXT NOT NULL UNIQUE,
+			telegram_file_id TEXT,
+			taken_on DATE NOT NULL,
+			created_at DATETIME,
+			updated_at DATETIME
+		)`,
+	} {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func newTestService(t *testing.T, db *gorm.DB, storage Storage) (*Service, *fakeTelegram) {
+	t.Helper()
+	bot := &fakeTelegram{rejected: map[string]bool{}}
+	service := NewService(bot, db, storage, "token")
+	service.client = &http.Client{Transport: roundTripper(telegramFiles)}
+	return service, bot
+}
+
+func TestStore(t *testing.T) {
+	db := mediaDatabase(t)
+	storage := newTestStorage(t)
+	service, _ := newTestService(t, db, storage)
+	exercise := &models.Exercise{ID: uuid.New(), Slug: "bench-press"}
+
+	media, err := service.Store(context.Background(), exercise, models.MediaKindImage, "sent-file", "image/jpeg")
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if !strings.HasPrefix(media.ObjectKey, "exercises/bench-press/") || !strings.HasSuffix(media.ObjectKey, ".jpg") {
+		t.Errorf("ObjectKey = %q, want exercises/bench-press/<name>.jpg", media.ObjectKey)
+	}
+	body, contentType := storage.object(t, media.ObjectKey)
+	if body != photoBytes {
+		t.Errorf("uploaded %q, want %q", body, photoBytes)
+	}
+	if contentType != "image/jpeg" {
+		t.Errorf("content type = %q, want image/jpeg", contentType)
+	}
+
+	stored, err := database.ListExerciseMedia(exercise.ID, []string{models.MediaKindImage}, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || stored[0].TelegramFileID != "sent-file" {
+		t.Errorf("stored media = %+v, want one with the sent file ID cached", stored)
+	}
+}
+
+func TestStoreDeletesUploadWhenRecordFails(t *testing.T) {
+	db := mediaDatabase(t)
+	if err := db.Exec("DROP TABLE workouts.exercise_media").Error; err != nil {
+		t.Fatal(err)
+	}
+	storage := newTestStorage(t)
+	service, _ := newTestService(t, db, storage)
+	exercise := &models.Exercise{ID: uuid.New(), Slug: "squat"}
+
+	if _, err := service.Store(context.Background(), exercise, models.MediaKindImage, "sent-file", "image/png"); err == nil {
+		t.Fatal("Store succeeded without a media table")
+	}
+	if keys := storage.keys(t); len(keys) != 0 {
+		t.Errorf("objects left in storage: %v", keys)
+	}
+}
+
+func TestStoreWithoutStorage(t *testing.T) {
+	service, _ := newTestService(t, mediaDatabase(t), nil)
+
+	_, err := service.Store(context.Background(), &models.Exercise{}, models.MediaKindImage, "sent-file", "image/jpeg")
+	if !errors.Is(err, ErrStorageDisabled) {
+		t.Errorf("Store error = %v, want ErrStorageDisabled", err)
+	}
+}
+
+func TestSend(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      string
+		cached    string
+		rejected  bool
+		storage   bool
+		wantSent  []tgbotapi.RequestFileData
+		wantCache string
+		wantErr   error
+	}{
+		{
+			name:      "cached file ID",
+			kind:      models.MediaKindImage,
+			cached:    "cached-id",
+			storage:   true,
+			wantSent:  []tgbotapi.RequestFileData{tgbotapi.FileID("cached-id")},
+			wantCache: "cached-id",
+		},
+		{
+			name:      "cached file ID without storage",
+			kind:      models.MediaKindVideo,
+			cached:    "cached-id",
+			wantSent:  []tgbotapi.RequestFileData{tgbotapi.FileID("cached-id")},
+			wantCache: "cached-id",
+		},
+		{
+			name:     "first send from storage",
+			kind:     models.MediaKindAnimation,
+			storage:  true,
+			wantSent: []tgbotapi.RequestFileData{tgbotapi.FileURL("exercises/squat/a.gif")},
+			// The file ID Telegram returns is cached for the next send.
+			wantCache: uploadedID,
+		},
+		{
+			name:     "rejected file ID falls back to storage",
+			kind:     models.MediaKindImage,
+			cached:   "stale-id",
+			rejected: true,
+			storage:  true,
+			wantSent: []tgbotapi.RequestFileData{
+				tgbotapi.FileID("stale-id"),
+				tgbotapi.FileURL("exercises/squat/a.gif"),
+			},
+			wantCache: uploadedID,
+		},
+		{
+			name:    "nothing to send from",
+			kind:    models.MediaKindImage,
+			wantErr: ErrStorageDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mediaDatabase(t)
+			var storage Storage
+			s3 := newTestStorage(t)
+			if tt.storage {
+				s3.put(t, "exercises/squat/a.gif", photoBytes)
+				storage = s3
+			}
+			service, bot := newTestService(t, db, storage)
+			bot.rejected[tt.cached] = tt.rejected
+
+			media := &models.ExerciseMedia{
+				ExerciseID:     uuid.New(),
+				Kind:           tt.kind,
+				ObjectKey:      "exercises/squat/a.gif",
+				ContentType:    "image/gif",
+				TelegramFileID: tt.cached,
+			}
+			if err := database.CreateExerciseMedia(media, db); err != nil {
+				t.Fatal(err)
+			}
+
+			err := service.Send(context.Background(), 1, media, "caption")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Send error = %v, want %v", err, tt.wantErr)
+			}
+			if len(bot.sent) != len(tt.wantSent) {
+				t.Fatalf("sent %v, want %v", bot.sent, tt.wantSent)
+			}
+			for i := range tt.wantSent {
+				// URLs are compared by the key they are presigned for.
+				if url, ok := bot.sent[i].(tgbotapi.FileURL); ok {
+					bot.sent[i] = tgbotapi.FileURL(s3.key(string(url)))
+				}
+				if bot.sent[i] != tt.wantSent[i] {
+					t.Errorf("send %d = %v, want %v", i, bot.sent[i], tt.wantSent[i])
+				}
+			}
+
+			stored, err := database.ListExerciseMedia(media.ExerciseID, []string{tt.kind}, db)
+			if err != nil || len(stored) != 1 {
+				t.Fatalf("ListExerciseMedia = %v, %v", stored, err)
+			}
+			if stored[0].TelegramFileID != tt.wantCache {
+				t.Errorf("cached file ID = %q, want %q", stored[0].TelegramFileID, tt.wantCache)
+			}
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	storage := newTestStorage(t)
+	storage.put(t, "users/1/photo.jpg", photoBytes)
+	service, _ := newTestService(t, mediaDatabase(t), storage)
+
+	body, err := service.fetch(context.Background(), "users/1/photo.jpg")
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	if string(data) != photoBytes {
+		t.Errorf("fetched %q, want %q", data, photoBytes)
+	}
+
+	if _, err := service.fetch(context.Background(), "users/1/missing.jpg"); err == nil {
+		t.Error("fetched a missing object")
+	}
+}
+
+func TestWriteProgressPhotos(t *testing.T) {
+	db := mediaDatabase(t)
+	storage := newTestStorage(t)
+	service, _ := newTestService(t, db, storage)
+	userID := uuid.New()
+
+	for _, pose := range []string{models.PoseFront, models.PoseBack} {
+		photo := &models.ProgressPhoto{
+			UserID:    userID,
+			Pose:      pose,
+			ObjectKey: "users/1/progress/" + pose + ".jpg",
+			TakenOn:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		}
+		if err := database.CreateProgressPhoto(photo, db); err != nil {
+			t.Fatal(err)
+		}
+		storage.put(t, photo.ObjectKey, photoBytes+" "+pose)
+	}
+
+	var buffer bytes.Buffer
+	count, err := service.WriteProgressPhotos(context.Background(), &buffer, userID)
+	if err != nil {
+		t.Fatalf("WriteProgressPhotos: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archive.File) != 2 {
+		t.Fatalf("archive has %d files, want 2", len(archive.File))
+	}
+	for _, file := range archive.File {
+		if !strings.HasPrefix(file.Name, "2026-03-01-") {
+			t.Errorf("file name %q does not start with the day", file.Name)
+		}
+		body, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(body)
+		body.Close()
+		if !strings.HasPrefix(string(data), photoBytes) {
+			t.Errorf("%s holds %q, want the stored photo", file.Name, data)
+		}
+	}
+}
+
+// failingStorage fails to delete one key.
+type failingStorage struct {
+	*testStorage
+	failing string
+}
+
+var errDeleteFailed = errors.New("delete failed")
+
+func (s failingStorage) DeleteFile(ctx context.Context, key string) error {
+	if key == s.failing {
+		return errDeleteFailed
+	}
+	return s.testStorage.DeleteFile(ctx, key)
+}
+
+func TestDeleteProgressPhotoObjects(t *testing.T) {
+	userID := uuid.New()
+	prefix := "users/" + userID.String() + "/progress/"
+	other := "users/" + uuid.NewString() + "/progress/c.jpg"
+
+	tests := []struct {
+		name     string
+		failing  string
+		wantLeft []string
+		wantErr  error
+	}{
+		{
+			name: "photos and later uploads",
+			// The rest of the bucket is kept.
+			wantLeft: []string{other},
+		},
+		{
+			name:     "keeps going past a failed delete",
+			failing:  prefix + "a.jpg",
+			wantLeft: []string{prefix + "a.jpg", other},
+			wantErr:  errDeleteFailed,
+		},
+		{
+			name:     "sweeps past a failed delete",
+			failing:  prefix + "late.jpg",
+			wantLeft: []string{prefix + "late.jpg", other},
+			wantErr:  errDeleteFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+			for _, key := range []string{prefix + "a.jpg", prefix + "b.jpg", prefix + "late.jpg", other} {
+				storage.put(t, key, photoBytes)
+			}
+			service, _ := newTestService(t, mediaDatabase(t), failingStorage{storage, tt.failing})
+
+			// The rows are gone with the account; only the objects are left.
+			// late.jpg was uploaded after the photos were listed.
+			photos := []models.ProgressPhoto{
+				{ObjectKey: prefix + "a.jpg"},
+				{ObjectKey: prefix + "b.jpg"},
+			}
+			err := service.DeleteProgressPhotoObjects(context.Background(), userID, photos)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteProgressPhotoObjects error = %v, want %v", err, tt.wantErr)
+			}
+			sort.Strings(tt.wantLeft)
+			if keys := storage.keys(t); !reflect.DeepEqual(keys, tt.wantLeft) {
+				t.Errorf("objects left %v, want %v", keys, tt.wantLeft)
+			}
+		})
+	}
+}
+
+func TestDeleteProgressPhotoObjectsWithoutStorage(t *testing.T) {
+	service, _ := newTestService(t, mediaDatabase(t), nil)
+	photos := []models.ProgressPhoto{{ObjectKey: "users/1/progress/a.jpg"}}
+
+	err := service.DeleteProgressPhotoObjects(context.Background(), uuid.New(), photos)
+	if !errors.Is(err, ErrStorageDisabled) {
+		t.Errorf("with photos error = %v, want ErrStorageDisabled", err)
+	}
+	if err := service.DeleteProgressPhotoObjects(context.Background(), uuid.New(), nil); err != nil {
+		t.Errorf("without photos error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,310 @@
+package media
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"time"
+	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	progressPrefix     = "progress"
+	progressMIMEType   = "image/jpeg"
+	retentionInterval  = 24 * time.Hour
+	retentionBatchSize = 100
+	collageQuality     = 85
+)
+
+// StoreProgressPhoto copies a photo the user sent into their private prefix
+// and records it as taken today.
+func (s *Service) StoreProgressPhoto(
+	ctx context.Context,
+	user *models.User,
+	pose string,
+	fileID string,
+) (*models.ProgressPhoto, error) {
+	if s.storage == nil {
+		return nil, ErrStorageDisabled
+	}
+
+	body, err := s.downloadAll(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	objectID := progressObjectID(user.ID)
+	name := uuid.NewString() + extensions[progressMIMEType]
+
+	if _, err := s.storage.UploadFile(ctx, objectID, name, body, progressMIMEType); err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"error":   err,
+		}).Error("Failed to upload progress photo")
+		return nil, err
+	}
+
+	photo := &models.ProgressPhoto{
+		UserID:         user.ID,
+		Pose:           pose,
+		ObjectKey:      objectID + "/" + name,
+		TelegramFileID: fileID,
+		TakenOn:        database.Today(),
+	}
+	if err := database.CreateProgressPhoto(photo, s.database); err != nil {
+		_ = s.storage.DeleteFile(ctx, photo.ObjectKey)
+		return nil, err
+	}
+	return photo, nil
+}
+
+// SendProgressPhoto delivers one of the user's photos back to them.
+func (s *Service) SendProgressPhoto(
+	ctx context.Context,
+	chatID int64,
+	photo *models.ProgressPhoto,
+	caption string,
+) error {
+	fileID, err := s.send(ctx, chatID, models.MediaKindImage, photo.TelegramFileID, photo.ObjectKey, caption)
+	if err != nil {
+		return err
+	}
+	if fileID != "" && fileID != photo.TelegramFileID {
+		_ = database.UpdateProgressPhotoFileID(photo, fileID, s.database)
+	}
+	return nil
+}
+
+// SendProgressCollage puts two photos side by side, earlier one on the left.
+func (s *Service) SendProgressCollage(
+	ctx context.Context,
+	chatID int64,
+	before *models.ProgressPhoto,
+	after *models.ProgressPhoto,
+	caption string,
+) error {
+	left, err := s.decode(ctx, before)
+	if err != nil {
+		return err
+	}
+	right, err := s.decode(ctx, after)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, Collage(left, right), &jpeg.Options{Quality: collageQuality}); err != nil {
+		return err
+	}
+
+	photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileBytes{Name: "collage.jpg", Bytes: buffer.Bytes()})
+	photo.Caption = caption
+	_, err = s.bot.Send(photo)
+	return err
+}
+
+// WriteProgressPhotos writes all of the user's photos to w as a zip
+// archive, one file per photo named by day and pose, and returns how many
+// it wrote.
+func (s *Service) WriteProgressPhotos(ctx context.Context, w io.Writer, userID uuid.UUID) (int, error) {
+	photos, err := database.ListUserProgressPhotos(userID, s.database)
+	if err != nil {
+		return 0, err
+	}
+	if len(photos) > 0 && s.storage == nil {
+		return 0, ErrStorageDisabled
+	}
+
+	archive := zip.NewWriter(w)
+	for i := range photos {
+		if err := s.archiveProgressPhoto(ctx, archive, &photos[i]); err != nil {
+			return 0, err
+		}
+	}
+	return len(photos), archive.Close()
+}
+
+// DeleteProgressPhotoObjects removes the photos from the bucket together
+// with anything else under the user's prefix, such as a photo uploaded
+// while the account was being deleted. It is the last step of deleting an
+// account, when the rows are already gone, so it keeps going past objects
+// that fail to delete and returns all the errors.
+func (s *Service) DeleteProgressPhotoObjects(
+	ctx context.Context,
+	userID uuid.UUID,
+	photos []models.ProgressPhoto,
+) error {
+	if s.storage == nil {
+		if len(photos) > 0 {
+			return ErrStorageDisabled
+		}
+		return nil
+	}
+
+	var errs []error
+	for i := range photos {
+		if err := s.deleteProgressObject(ctx, &photos[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := s.deletePrefix(ctx, progressObjectID(userID)+"/"); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// PurgeProgressPhotos deletes photos taken before the day and returns how
+// many were removed.
+func (s *Service) PurgeProgressPhotos(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	for {
+		photos, err := database.ListProgressPhotosBefore(before, retentionBatchSize, s.database)
+		if err != nil {
+			return purged, err
+		}
+		if len(photos) == 0 {
+			return purged, nil
+		}
+		if err := s.deleteProgressPhotos(ctx, photos); err != nil {
+			return purged, err
+		}
+		purged += len(photos)
+	}
+}
+
+// RunRetention purges photos older than retention once a day until ctx is
+// done. A zero retention keeps photos forever.
+func (s *Service) RunRetention(ctx context.Context, retention time.Duration) {
+	if retention <= 0 || s.storage == nil {
+		return
+	}
+
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.PurgeProgressPhotos(ctx, database.Today().Add(-retention))
+		if err != nil {
+			logger.WithField("error", err).Error("Failed to purge progress photos")
+		} else if purged > 0 {
+			logger.WithField("count", purged).Info("Expired progress photos purged")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) deleteProgressPhotos(ctx context.Context, photos []models.ProgressPhoto) error {
+	if len(photos) > 0 && s.storage == nil {
+		return ErrStorageDisabled
+	}
+
+	for i := range photos {
+		if err := s.deleteProgressObject(ctx, &photos[i]); err != nil {
+			return err
+		}
+		if err := database.DeleteProgressPhoto(&photos[i], s.database); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deletePrefix deletes every object under the prefix. A listing holds at
+// most a thousand objects, so it lists again until a round deletes nothing.
+func (s *Service) deletePrefix(ctx context.Context, prefix string) error {
+	var errs []error
+	for {
+		urls, err := s.storage.GetObjects(ctx, prefix)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		deleted := 0
+		for _, presigned := range urls {
+			key, err := objectKey(presigned, prefix)
+			if err == nil {
+				err = s.storage.DeleteFile(ctx, key)
+			}
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"prefix": prefix,
+					"error":  err,
+				}).Error("Failed to delete object")
+				errs = append(errs, err)
+				continue
+			}
+			deleted++
+		}
+		if deleted == 0 {
+			return errors.Join(errs...)
+		}
+	}
+}
+
+func (s *Service) deleteProgressObject(ctx context.Context, photo *models.ProgressPhoto) error {
+	if err := s.storage.DeleteFile(ctx, photo.ObjectKey); err != nil {
+		logger.WithFields(logrus.Fields{
+			"photo_id":   photo.ID,
+			"object_key": photo.ObjectKey,
+			"error":      err,
+		}).Error("Failed to delete progress photo object")
+		return err
+	}
+	return nil
+}
+
+// archiveProgressPhoto copies the photo into the archive as it is; JPEG
+// does not compress further.
+func (s *Service) archiveProgressPhoto(ctx context.Context, archive *zip.Writer, photo *models.ProgressPhoto) error {
+	body, err := s.fetch(ctx, photo.ObjectKey)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	file, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     fmt.Sprintf("%s-%s-%s.jpg", photo.TakenOn.Format("2006-01-02"), photo.Pose, photo.ID.String()[:8]),
+		Method:   zip.Store,
+		Modified: photo.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, body)
+	return err
+}
+
+func (s *Service) decode(ctx context.Context, photo *models.ProgressPhoto) (image.Image, error) {
+	body, err := s.fetch(ctx, photo.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	img, _, err := image.Decode(body)
+	if err != nil {
+		return nil, errors.Join(errors.New("decode progress photo"), err)
+	}
+	return img, nil
+}
+
+// progressObjectID is the prefix the user's progress photos are kept under.
+func progressObjectID(userID uuid.UUID) string {
+	return userPrefix + "/" + userID.String() + "/" + progressPrefix
+}
@@ -0,0 +1,56 @@
+package programs
+
+import (
+	"errors"
+	"workouts_bot/src/database"
+	"workouts_bot/src/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrUnknown = errors.New("unknown program")
+
+// Advance moves the program on to the next day once a session of it is
+// finished, raising training maxes at the end of a cycle. It returns a nil
+// enrollment when the session does not belong to the program the user
+// follows now.
+func Advance(
+	session *models.WorkoutSession,
+	db *gorm.DB,
+) (*Program, *models.ProgramEnrollment, bool, error) {
+	if session.EnrollmentID == nil {
+		return nil, nil, false, nil
+	}
+
+	enrollment, err := database.FindProgramEnrollment(session.UserID, db)
+	if err != nil || enrollment == nil || enrollment.ID != *session.EnrollmentID {
+		return nil, nil, false, err
+	}
+	program, ok := Find(enrollment.Program)
+	if !ok {
+		return nil, nil, false, ErrUnknown
+	}
+
+	week, day, cycled := program.Next(enrollment.Week, enrollment.Day)
+
+	raises := make(map[uuid.UUID]float64)
+	if cycled {
+		slugs := make([]string, 0, len(program.Increments))
+		for slug := range program.Increments {
+			slugs = append(slugs, slug)
+		}
+		exercises, err := database.ListExercisesBySlugs(slugs, db)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		for slug, exercise := range exercises {
+			raises[exercise.ID] = program.Increments[slug]
+		}
+	}
+
+	if err := database.AdvanceEnrollment(enrollment, week, day, cycled, raises, db); err != nil {
+		return nil, nil, false, err
+	}
+	return program, enrollment, cycled, nil
+}
@@ -0,0 +1,81 @@
+package programs
+
+import (
+	"time"
+	"workouts_bot/src/models"
+	"workouts_bot/src/services/training"
+)
+
+// Rounding is the plate step prescribed weights are rounded to.
+const Rounding = 2.5
+
+// Prescription is what the program asks for one exercise on a day.
+type Prescription struct {
+	Exercise string
+	Sets     []models.PlannedSet
+	Rest     int
+}
+
+// Prescribe computes the sets for day of week from the training maxes,
+// keyed by exercise slug. Lifts without a training max get no weight.
+func (p *Program) Prescribe(week int, day int, maxes map[string]float64) []Prescription {
+	current := p.Weeks[week%len(p.Weeks)]
+
+	var prescriptions []Prescription
+	for _, lift := range p.Days[day%len(p.Days)].Lifts {
+		prescription := Prescription{Exercise: lift.Exercise, Rest: lift.Rest}
+		if lift.Scheme == "" {
+			for range lift.Sets {
+				prescription.Sets = append(prescription.Sets, models.PlannedSet{Reps: lift.Reps})
+			}
+			prescriptions = append(prescriptions, prescription)
+			continue
+		}
+
+		scheme, ok := current.Schemes[lift.Scheme]
+		if !ok {
+			continue
+		}
+		for _, set := range scheme {
+			prescription.Sets = append(prescription.Sets, models.PlannedSet{
+				Reps:   set.Reps,
+				Weight: training.RoundWeight(maxes[lift.Exercise]*set.Percent, Rounding),
+				AMRAP:  set.AMRAP,
+			})
+		}
+		prescriptions = append(prescriptions, prescription)
+	}
+	return prescriptions
+}
+
+// Next returns the position after day of week; cycled reports that the
+// last day of the last week was done and training maxes should go up.
+func (p *Program) Next(week int, day int) (nextWeek int, nextDay int, cycled bool) {
+	nextWeek, nextDay = week%len(p.Weeks), day+1
+	if nextDay < len(p.Days) {
+		return nextWeek, nextDay, false
+	}
+	nextWeek++
+	if nextWeek < len(p.Weeks) {
+		return nextWeek, 0, false
+	}
+	return 0, 0, true
+}
+
+// trainingDays are the weekdays program days fall on, by how many days a
+// week the program has.
+var trainingDays = map[int][]time.Weekday{
+	1: {time.Monday},
+	2: {time.Monday, time.Thursday},
+	3: {time.Monday, time.Wednesday, time.Friday},
+	4: {time.Monday, time.Tuesday, time.Thursday, time.Friday},
+	5: {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	6: {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+	7: {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday},
+}
+
+// TrainingDays are the weekdays the program is planned on, spread over the
+// week by how many days it has.
+func (p *Program) TrainingDays() []time.Weekday {
+	return trainingDays[len(p.Days)]
+}
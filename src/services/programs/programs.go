@@ -0,0 +1,268 @@
+package programs
+
+// Set is one prescribed set: a share of the training max and the reps.
+// AMRAP sets ask for as many reps as possible, at least Reps.
+type Set struct {
+	Percent float64
+	Reps    int
+	AMRAP   bool
+}
+
+// Lift is an exercise on a program day, by catalog slug. Lifts with a
+// scheme are loaded from the exercise's training max and are skipped in
+// weeks without that scheme; accessories have only sets and reps and the
+// weight is left to the user.
+type Lift struct {
+	Exercise string
+	Scheme   string
+	Sets     int
+	Reps     int
+	Rest     int
+}
+
+type Day struct {
+	Name  string
+	Lifts []Lift
+}
+
+// Week holds the sets of each scheme for one week of the cycle.
+type Week struct {
+	Deload  bool
+	Schemes map[string][]Set
+}
+
+// Program is a periodized program. Every day is done once per week; after
+// the last week the cycle starts over with training maxes raised by
+// Increments, keyed by exercise slug.
+type Program struct {
+	Slug       string
+	Weeks      []Week
+	Days       []Day
+	Increments map[string]float64
+}
+
+const (
+	mainRest      = 180
+	secondaryRest = 120
+	accessoryRest = 90
+)
+
+// Library is every program users can enroll in, in display order.
+var Library = []Program{
+	fiveThreeOne,
+	gzclp,
+	pushPullLegs,
+	startingStrength,
+}
+
+// Find returns the program with the slug.
+func Find(slug string) (*Program, bool) {
+	for i := range Library {
+		if Library[i].Slug == slug {
+			return &Library[i], true
+		}
+	}
+	return nil, false
+}
+
+// Maxes lists the slugs of exercises the program needs training maxes for,
+// in the order they first appear.
+func (p *Program) Maxes() []string {
+	var slugs []string
+	seen := make(map[string]bool)
+	for _, day := range p.Days {
+		for _, lift := range day.Lifts {
+			if lift.Scheme != "" && !seen[lift.Exercise] {
+				seen[lift.Exercise] = true
+				slugs = append(slugs, lift.Exercise)
+			}
+		}
+	}
+	return slugs
+}
+
+// sets builds count sets of reps at percent of the training max.
+func sets(count int, reps int, percent float64) []Set {
+	result := make([]Set, count)
+	for i := range result {
+		result[i] = Set{Percent: percent, Reps: reps}
+	}
+	return result
+}
+
+// ramp builds one set of reps per percent; the last one is AMRAP when amrap
+// is set.
+func ramp(reps int, amrap bool, percents ...float64) []Set {
+	result := make([]Set, len(percents))
+	for i, percent := range percents {
+		result[i] = Set{Percent: percent, Reps: reps}
+	}
+	result[len(result)-1].AMRAP = amrap
+	return result
+}
+
+func mainLift(exercise string) Lift {
+	return Lift{Exercise: exercise, Scheme: "main", Rest: mainRest}
+}
+
+func accessory(exercise string, count int, reps int) Lift {
+	return Lift{Exercise: exercise, Sets: count, Reps: reps, Rest: accessoryRest}
+}
+
+// fiveThreeOne is Wendler's 5/3/1 with Boring But Big assistance: a four
+// week wave ending in a deload, one main lift a day.
+var fiveThreeOne = Program{
+	Slug: "531",
+	Weeks: []Week{
+		{Schemes: map[string][]Set{"main": ramp(5, true, 0.65, 0.75, 0.85), "bbb": sets(5, 10, 0.5)}},
+		{Schemes: map[string][]Set{"main": ramp(3, true, 0.70, 0.80, 0.90), "bbb": sets(5, 10, 0.5)}},
+		{Schemes: map[string][]Set{"main": {
+			{Percent: 0.75, Reps: 5},
+			{Percent: 0.85, Reps: 3},
+			{Percent: 0.95, Reps: 1, AMRAP: true},
+		}, "bbb": sets(5, 10, 0.5)}},
+		{Deload: true, Schemes: map[string][]Set{"main": ramp(5, false, 0.40, 0.50, 0.60)}},
+	},
+	Days: []Day{
+		{Name: "press", Lifts: []Lift{
+			mainLift("overhead_press"),
+			{Exercise: "overhead_press", Scheme: "bbb", Rest: secondaryRest},
+			accessory("pull_up", 5, 10),
+		}},
+		{Name: "deadlift", Lifts: []Lift{
+			mainLift("deadlift"),
+			{Exercise: "deadlift", Scheme: "bbb", Rest: secondaryRest},
+			accessory("hanging_leg_raise", 5, 15),
+		}},
+		{Name: "bench", Lifts: []Lift{
+			mainLift("bench_press"),
+			{Exercise: "bench_press", Scheme: "bbb", Rest: secondaryRest},
+			accessory("barbell_row", 5, 10),
+		}},
+		{Name: "squat", Lifts: []Lift{
+			mainLift("squat"),
+			{Exercise: "squat", Scheme: "bbb", Rest: secondaryRest},
+			accessory("leg_curl", 5, 10),
+		}},
+	},
+	Increments: map[string]float64{
+		"overhead_press": 2.5,
+		"bench_press":    2.5,
+		"squat":          5,
+		"deadlift":       5,
+	},
+}
+
+// gzclp is Cody Lefever's GZCL linear progression: heavy T1 triples,
+// volume T2 tens and light T3 accessories, rotating over four days.
+var gzclp = Program{
+	Slug: "gzclp",
+	Weeks: []Week{
+		{Schemes: map[string][]Set{
+			"t1": append(sets(4, 3, 0.85), Set{Percent: 0.85, Reps: 3, AMRAP: true}),
+			"t2": sets(3, 10, 0.65),
+		}},
+	},
+	Days: []Day{
+		{Name: "a1", Lifts: []Lift{
+			{Exercise: "squat", Scheme: "t1", Rest: mainRest},
+			{Exercise: "bench_press", Scheme: "t2", Rest: secondaryRest},
+			accessory("lat_pulldown", 3, 15),
+		}},
+		{Name: "b1", Lifts: []Lift{
+			{Exercise: "overhead_press", Scheme: "t1", Rest: mainRest},
+			{Exercise: "deadlift", Scheme: "t2", Rest: secondaryRest},
+			accessory("seated_cable_row", 3, 15),
+		}},
+		{Name: "a2", Lifts: []Lift{
+			{Exercise: "bench_press", Scheme: "t1", Rest: mainRest},
+			{Exercise: "squat", Scheme: "t2", Rest: secondaryRest},
+			accessory("lat_pulldown", 3, 15),
+		}},
+		{Name: "b2", Lifts: []Lift{
+			{Exercise: "deadlift", Scheme: "t1", Rest: mainRest},
+			{Exercise: "overhead_press", Scheme: "t2", Rest: secondaryRest},
+			accessory("seated_cable_row", 3, 15),
+		}},
+	},
+	Increments: map[string]float64{
+		"squat":          5,
+		"deadlift":       5,
+		"bench_press":    2.5,
+		"overhead_press": 2.5,
+	},
+}
+
+// pushPullLegs runs a three week wave on the main lifts, heavier each week
+// with an AMRAP set in the third, followed by a deload week.
+var pushPullLegs = Program{
+	Slug: "ppl",
+	Weeks: []Week{
+		{Schemes: map[string][]Set{"main": sets(4, 8, 0.70), "secondary": sets(3, 10, 0.60)}},
+		{Schemes: map[string][]Set{"main": sets(4, 6, 0.75), "secondary": sets(3, 10, 0.60)}},
+		{Schemes: map[string][]Set{
+			"main":      append(sets(3, 5, 0.80), Set{Percent: 0.80, Reps: 5, AMRAP: true}),
+			"secondary": sets(3, 10, 0.60),
+		}},
+		{Deload: true, Schemes: map[string][]Set{"main": sets(3, 8, 0.60), "secondary": sets(2, 10, 0.50)}},
+	},
+	Days: []Day{
+		{Name: "push", Lifts: []Lift{
+			mainLift("bench_press"),
+			{Exercise: "overhead_press", Scheme: "secondary", Rest: secondaryRest},
+			accessory("incline_dumbbell_press", 3, 10),
+			accessory("lateral_raise", 3, 15),
+			accessory("triceps_pushdown", 3, 12),
+		}},
+		{Name: "pull", Lifts: []Lift{
+			mainLift("deadlift"),
+			{Exercise: "barbell_row", Scheme: "secondary", Rest: secondaryRest},
+			accessory("lat_pulldown", 3, 10),
+			accessory("face_pull", 3, 15),
+			accessory("barbell_curl", 3, 12),
+		}},
+		{Name: "legs", Lifts: []Lift{
+			mainLift("squat"),
+			{Exercise: "romanian_deadlift", Scheme: "secondary", Rest: secondaryRest},
+			accessory("leg_press", 3, 12),
+			accessory("leg_curl", 3, 12),
+			accessory("calf_raise", 3, 15),
+		}},
+	},
+	Increments: map[string]float64{
+		"bench_press":       2.5,
+		"overhead_press":    2.5,
+		"deadlift":          5,
+		"barbell_row":       2.5,
+		"squat":             5,
+		"romanian_deadlift": 5,
+	},
+}
+
+// startingStrength is Rippetoe's novice program: workouts A and B in
+// turn, everything at the working weight. The training max is the working
+// weight itself.
+var startingStrength = Program{
+	Slug: "starting_strength",
+	Weeks: []Week{
+		{Schemes: map[string][]Set{"work": sets(3, 5, 1), "deadlift": sets(1, 5, 1)}},
+	},
+	Days: []Day{
+		{Name: "a", Lifts: []Lift{
+			{Exercise: "squat", Scheme: "work", Rest: mainRest},
+			{Exercise: "bench_press", Scheme: "work", Rest: mainRest},
+			{Exercise: "deadlift", Scheme: "deadlift", Rest: mainRest},
+		}},
+		{Name: "b", Lifts: []Lift{
+			{Exercise: "squat", Scheme: "work", Rest: mainRest},
+			{Exercise: "overhead_press", Scheme: "work", Rest: mainRest},
+			{Exercise: "deadlift", Scheme: "deadlift", Rest: mainRest},
+		}},
+	},
+	Increments: map[string]float64{
+		"squat":          5,
+		"bench_press":    2.5,
+		"overhead_press": 2.5,
+		"deadlift":       5,
+	},
+}
@@ -0,0 +1,42 @@
+package training
+
+import (
+	"time"
+	"workouts_bot/src/models"
+)
+
+// paceMeters is the distance pace is given for when it is not a
+// kilometre: per 500 m on the rower and per 100 m in the pool. Zero means
+// the activity is measured by speed.
+var paceMeters = map[string]float64{
+	"rowing":   500,
+	"swimming": 100,
+	"cycling":  0,
+}
+
+// PaceMeters is the distance pace is given for, or zero for activities
+// measured by speed.
+func PaceMeters(exercise *models.Exercise) float64 {
+	if meters, ok := paceMeters[exercise.Slug]; ok {
+		return meters
+	}
+	return 1000
+}
+
+// Pace is the time it took to cover meters at the entry's average pace;
+// zero without a distance.
+func Pace(entry *models.CardioEntry, meters float64) time.Duration {
+	if entry.DistanceMeters <= 0 || meters <= 0 {
+		return 0
+	}
+	seconds := float64(entry.DurationSeconds) * meters / entry.DistanceMeters
+	return (time.Duration(seconds) * time.Second).Round(time.Second)
+}
+
+// Speed is the average speed in km/h; zero without a distance.
+func Speed(entry *models.CardioEntry) float64 {
+	if entry.DistanceMeters <= 0 || entry.DurationSeconds <= 0 {
+		return 0
+	}
+	return entry.DistanceMeters / 1000 / (float64(entry.DurationSeconds) / 3600)
+}
@@ -0,0 +1,132 @@
+package training
+
+import (
+	"math"
+	"workouts_bot/src/models"
+)
+
+// One-rep max formulas.
+const (
+	FormulaEpley    = "epley"
+	FormulaBrzycki  = "brzycki"
+	FormulaLombardi = "lombardi"
+	FormulaRPE      = "rpe"
+)
+
+// Formulas lists the formulas in the order estimates are shown.
+var Formulas = []string{FormulaEpley, FormulaBrzycki, FormulaLombardi, FormulaRPE}
+
+const (
+	// MaxEstimateReps is the most reps a set can have to estimate a 1RM
+	// from; the formulas drift apart quickly above it.
+	MaxEstimateReps = 12
+
+	// TrainingMaxShare is the part of the one-rep max taken as training max.
+	TrainingMaxShare = 0.9
+
+	minRPE = 6.0
+	maxRPE = 10.0
+)
+
+// rpePercents is the RTS chart: the share of the 1RM that can be lifted
+// for a number of reps at RPE 10, in half-rep steps from 1 to 16. Each RPE
+// below 10 counts as one more rep in reserve.
+var rpePercents = []float64{
+	1.000, 0.978, 0.955, 0.939, 0.922, 0.907, 0.892, 0.878,
+	0.863, 0.850, 0.837, 0.824, 0.811, 0.799, 0.786, 0.774,
+	0.762, 0.751, 0.739, 0.723, 0.707, 0.694, 0.680, 0.667,
+	0.653, 0.640, 0.626, 0.613, 0.599, 0.586, 0.573,
+}
+
+// ValidSet reports whether a 1RM can be estimated from the set. rpe is 0
+// when the set was not rated.
+func ValidSet(weight float64, reps int, rpe float64) bool {
+	return weight > 0 && reps >= 1 && reps <= MaxEstimateReps &&
+		(rpe == 0 || rpe >= minRPE && rpe <= maxRPE)
+}
+
+// Estimate is the one-rep max by the formula. Unrated sets count as RPE 10
+// for the RPE table.
+func Estimate(formula string, weight float64, reps int, rpe float64) float64 {
+	if reps == 1 && (rpe == 0 || rpe == maxRPE) {
+		return weight
+	}
+
+	r := float64(reps)
+	switch formula {
+	case FormulaEpley:
+		return weight * (1 + r/30)
+	case FormulaBrzycki:
+		return weight * 36 / (37 - r)
+	case FormulaLombardi:
+		return weight * math.Pow(r, 0.1)
+	case FormulaRPE:
+		return weight / RPEPercent(reps, rpe)
+	default:
+		return 0
+	}
+}
+
+// EstimateAll returns the estimate of every formula, keyed by formula.
+func EstimateAll(weight float64, reps int, rpe float64) map[string]float64 {
+	estimates := make(map[string]float64, len(Formulas))
+	for _, formula := range Formulas {
+		estimates[formula] = Estimate(formula, weight, reps, rpe)
+	}
+	return estimates
+}
+
+// Average is the mean of the estimates.
+func Average(estimates map[string]float64) float64 {
+	if len(estimates) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, estimate := range estimates {
+		sum += estimate
+	}
+	return sum / float64(len(estimates))
+}
+
+// RPEPercent is the share of the 1RM that can be lifted for reps at rpe,
+// by the RTS chart. Unrated sets count as RPE 10.
+func RPEPercent(reps int, rpe float64) float64 {
+	if rpe == 0 {
+		rpe = maxRPE
+	}
+	steps := int(math.Round((float64(reps) - 1 + maxRPE - rpe) * 2))
+	return rpePercents[min(max(steps, 0), len(rpePercents)-1)]
+}
+
+// RepsAt is about how many reps can be done at percent of the 1RM, by
+// Epley's formula solved for reps.
+func RepsAt(percent float64) int {
+	if percent >= 1 {
+		return 1
+	}
+	return max(1, int(math.Round(30*(1/percent-1))))
+}
+
+// BestEstimate is the highest one-rep max estimated from the exercise's
+// completed sets; false when none of them qualify. Rated sets use the RPE
+// table, the rest Epley's formula.
+func BestEstimate(exercise *models.SessionExercise) (float64, bool) {
+	var best float64
+	for _, set := range exercise.WorkSets() {
+		if set.Skipped || !ValidSet(set.Weight, set.Reps, set.RPE) {
+			continue
+		}
+		formula := FormulaEpley
+		if set.RPE > 0 {
+			formula = FormulaRPE
+		}
+		best = max(best, Estimate(formula, set.Weight, set.Reps, set.RPE))
+	}
+	return best, best > 0
+}
+
+// TrainingMax is the training max for the one-rep max, rounded to half a
+// kilogram.
+func TrainingMax(oneRepMax float64) float64 {
+	return RoundWeight(oneRepMax*TrainingMaxShare, 0.5)
+}
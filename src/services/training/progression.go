@@ -0,0 +1,297 @@
+package training
+
+import (
+	"math"
+	"workouts_bot/src/models"
+)
+
+// Progression actions, from easiest to hardest next session.
+const (
+	ActionDeload    = "deload"
+	ActionHold      = "hold"
+	ActionAddReps   = "add_reps"
+	ActionAddWeight = "add_weight"
+)
+
+// Recommendation is the suggested target for the next session of an
+// exercise, together with what was done last time.
+type Recommendation struct {
+	Scheme     string
+	Action     string
+	Sets       int
+	Reps       int
+	Weight     float64
+	LastReps   []int
+	LastWeight float64
+}
+
+// Matches reports whether the exercise already has the recommended targets.
+func (r Recommendation) Matches(exercise *models.SessionExercise) bool {
+	return r.Sets == exercise.TargetSets &&
+		r.Reps == exercise.TargetReps &&
+		r.Weight == exercise.TargetWeight
+}
+
+// RepRange is the reps window double progression works within.
+type RepRange struct {
+	Low  int
+	High int
+}
+
+var goalRepRanges = map[string]RepRange{
+	models.GoalStrength:   {Low: 3, High: 6},
+	models.GoalMuscleGain: {Low: 8, High: 12},
+	models.GoalEndurance:  {Low: 12, High: 20},
+	models.GoalWeightLoss: {Low: 10, High: 15},
+}
+
+var defaultRepRange = RepRange{Low: 8, High: 12}
+
+// weightSteps is the smallest load change for the equipment in kg.
+var weightSteps = map[string]float64{
+	"barbell":    2.5,
+	"dumbbell":   2,
+	"kettlebell": 4,
+	"cable":      2.5,
+	"machine":    2.5,
+}
+
+const (
+	// HistoryDepth is how many past sessions Recommend looks at.
+	HistoryDepth = 3
+
+	deloadAfterFailures = 3
+	deloadFactor        = 0.9
+
+	targetRPE = 8.0
+	easyRPE   = 7.0
+	hardRPE   = 9.5
+)
+
+func RepRangeFor(goal string) RepRange {
+	if reps, ok := goalRepRanges[goal]; ok {
+		return reps
+	}
+	return defaultRepRange
+}
+
+// WeightStep is the smallest load change for the exercise; 0 for
+// bodyweight and cardio, which progress by reps only.
+func WeightStep(exercise *models.Exercise) float64 {
+	return weightSteps[exercise.Equipment]
+}
+
+// WeightIncrement is how much load to add after a successful session.
+// Barbell lower body lifts move twice as fast as upper body ones.
+func WeightIncrement(exercise *models.Exercise) float64 {
+	step := WeightStep(exercise)
+	if exercise.Equipment == "barbell" &&
+		(exercise.MuscleGroup == "legs" || exercise.MuscleGroup == "glutes") {
+		return step * 2
+	}
+	return step
+}
+
+// Recommend picks the next targets for current from the exercise's history
+// in finished sessions, newest first. It returns false when there is no
+// history or the exercise does not progress by sets and reps.
+func Recommend(
+	scheme string,
+	goal string,
+	current *models.SessionExercise,
+	history []models.SessionExercise,
+) (Recommendation, bool) {
+	switch current.Exercise.Category {
+	case models.CategoryCardio, models.CategoryEndurance:
+		return Recommendation{}, false
+	}
+	if current.Intervals() {
+		return Recommendation{}, false
+	}
+	if len(history) == 0 {
+		return Recommendation{}, false
+	}
+
+	last := &history[0]
+	recommendation := Recommendation{
+		Scheme:     scheme,
+		Action:     ActionHold,
+		Sets:       current.TargetSets,
+		Reps:       last.TargetReps,
+		Weight:     workingWeight(last),
+		LastWeight: workingWeight(last),
+	}
+	for _, set := range last.WorkSets() {
+		if !set.Skipped {
+			recommendation.LastReps = append(recommendation.LastReps, set.Reps)
+		}
+	}
+
+	exercise := &current.Exercise
+	if recommendation.Weight == 0 || WeightStep(exercise) == 0 {
+		return progressReps(recommendation, last), true
+	}
+
+	switch scheme {
+	case models.ProgressionLinear:
+		return linear(recommendation, exercise, history), true
+	case models.ProgressionRPE:
+		if rpe, ok := averageRPE(last); ok {
+			return byRPE(recommendation, exercise, history, rpe), true
+		}
+	}
+	return double(recommendation, exercise, history, RepRangeFor(goal)), true
+}
+
+// linear adds load after every fully completed session and deloads after
+// repeated misses at the same weight.
+func linear(r Recommendation, exercise *models.Exercise, history []models.SessionExercise) Recommendation {
+	switch {
+	case completed(&history[0]):
+		r.Action = ActionAddWeight
+		r.Weight += WeightIncrement(exercise)
+	case failures(history) >= deloadAfterFailures:
+		r.Action = ActionDeload
+		r.Weight = RoundWeight(r.Weight*deloadFactor, WeightStep(exercise))
+	}
+	return r
+}
+
+// double adds reps up to the top of the range, then adds load and starts
+// again from the bottom.
+func double(
+	r Recommendation,
+	exercise *models.Exercise,
+	history []models.SessionExercise,
+	reps RepRange,
+) Recommendation {
+	r.Reps = max(reps.Low, min(r.Reps, reps.High))
+	last := &history[0]
+
+	switch {
+	case completed(last) && minReps(last) >= reps.High:
+		r.Action = ActionAddWeight
+		r.Weight += WeightIncrement(exercise)
+		r.Reps = reps.Low
+	case completed(last):
+		r.Action = ActionAddReps
+		r.Reps = min(r.Reps+1, reps.High)
+	case failures(history) >= deloadAfterFailures:
+		r.Action = ActionDeload
+		r.Weight = RoundWeight(r.Weight*deloadFactor, WeightStep(exercise))
+		r.Reps = reps.Low
+	}
+	return r
+}
+
+// byRPE adds load when the last session felt easy and backs off when it
+// was at or near failure.
+func byRPE(
+	r Recommendation,
+	exercise *models.Exercise,
+	history []models.SessionExercise,
+	rpe float64,
+) Recommendation {
+	switch {
+	case rpe >= hardRPE || failures(history) >= deloadAfterFailures-1:
+		r.Action = ActionDeload
+		r.Weight = RoundWeight(r.Weight*deloadFactor, WeightStep(exercise))
+	case rpe <= easyRPE && completed(&history[0]):
+		r.Action = ActionAddWeight
+		r.Weight += WeightIncrement(exercise)
+		// Well under the target effort: a double step is still safe.
+		if rpe <= easyRPE-1 {
+			r.Weight += WeightIncrement(exercise)
+		}
+	case rpe < targetRPE && completed(&history[0]):
+		r.Action = ActionAddReps
+		r.Reps++
+	}
+	return r
+}
+
+// progressReps handles exercises without external load.
+func progressReps(r Recommendation, last *models.SessionExercise) Recommendation {
+	if completed(last) {
+		r.Action = ActionAddReps
+		r.Reps++
+	}
+	return r
+}
+
+// completed reports whether every target set was done for the target reps.
+// Drop and rest-pause sets do not count.
+func completed(exercise *models.SessionExercise) bool {
+	done := 0
+	for _, set := range exercise.WorkSets() {
+		if !set.Skipped && set.ParentID == nil && set.Reps >= exercise.TargetReps {
+			done++
+		}
+	}
+	return done >= exercise.TargetSets
+}
+
+// failures counts the latest sessions in a row that missed their targets
+// at the latest working weight.
+func failures(history []models.SessionExercise) int {
+	weight := workingWeight(&history[0])
+	count := 0
+	for i := range history {
+		if completed(&history[i]) || workingWeight(&history[i]) != weight {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// workingWeight is the heaviest completed set, or the target if no set was
+// completed.
+func workingWeight(exercise *models.SessionExercise) float64 {
+	weight := 0.0
+	for _, set := range exercise.WorkSets() {
+		if !set.Skipped {
+			weight = max(weight, set.Weight)
+		}
+	}
+	if weight == 0 {
+		return exercise.TargetWeight
+	}
+	return weight
+}
+
+func minReps(exercise *models.SessionExercise) int {
+	reps := math.MaxInt
+	for _, set := range exercise.WorkSets() {
+		if !set.Skipped && set.ParentID == nil {
+			reps = min(reps, set.Reps)
+		}
+	}
+	if reps == math.MaxInt {
+		return 0
+	}
+	return reps
+}
+
+func averageRPE(exercise *models.SessionExercise) (float64, bool) {
+	var sum float64
+	var count int
+	for _, set := range exercise.WorkSets() {
+		if !set.Skipped && set.RPE > 0 {
+			sum += set.RPE
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// RoundWeight rounds to the nearest multiple of step.
+func RoundWeight(weight float64, step float64) float64 {
+	if step <= 0 {
+		return weight
+	}
+	return math.Round(weight/step) * step
+}
@@ -0,0 +1,96 @@
+package training
+
+import (
+	"workouts_bot/src/database"
+	"workouts_bot/src/logger"
+	"workouts_bot/src/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// PrepareWarmups plans warm-up sets for an exercise about to start, once.
+// Failing to save them only costs the warm-ups.
+func PrepareWarmups(user *models.User, exercise *models.SessionExercise, db *gorm.DB) {
+	if exercise == nil || len(exercise.Sets) > 0 || exercise.Warmup != nil || !user.Warmups {
+		return
+	}
+
+	working := exercise.Target(1).Weight
+	warmup := Warmups(&exercise.Exercise, working, LoadoutFor(user))
+	if warmup == nil {
+		warmup = []models.PlannedSet{}
+	}
+	_ = database.UpdateSessionExerciseWarmup(exercise, warmup, db)
+}
+
+// RecommendFor suggests new targets for an exercise about to start: one
+// with no sets logged yet. It returns nil when there is nothing to suggest,
+// the targets already match or a program prescribes the sets.
+func RecommendFor(user *models.User, exercise *models.SessionExercise, db *gorm.DB) *Recommendation {
+	if exercise == nil || len(exercise.Sets) > 0 || len(exercise.Plan) > 0 {
+		return nil
+	}
+
+	history, err := database.ListExerciseHistory(user.ID, exercise.ExerciseID, HistoryDepth, db)
+	if err != nil {
+		return nil
+	}
+
+	recommendation, ok := Recommend(user.Progression, user.Goal, exercise, history)
+	if !ok || recommendation.Matches(exercise) {
+		return nil
+	}
+
+	logger.WithFields(logrus.Fields{
+		"user_id":     user.TelegramID,
+		"exercise_id": exercise.ExerciseID,
+		"action":      recommendation.Action,
+	}).Debug("Progression recommended")
+	return &recommendation
+}
+
+// RecordSessionMaxes raises one-rep maxes and training maxes from the sets
+// of a finished session. It returns the ones that went up, with exercises;
+// the session must be loaded with its exercises.
+func RecordSessionMaxes(session *models.WorkoutSession, db *gorm.DB) []models.TrainingMax {
+	var raised []models.TrainingMax
+	for i := range session.Exercises {
+		exercise := &session.Exercises[i]
+		estimate, ok := BestEstimate(exercise)
+		if !ok {
+			continue
+		}
+
+		oneRepMax := RoundWeight(estimate, 0.5)
+		trainingMax, changed, err := database.RecordOneRepMax(
+			session.UserID, exercise.ExerciseID,
+			oneRepMax, TrainingMax(oneRepMax), models.TrainingMaxEstimate, db,
+		)
+		if err != nil || !changed {
+			continue
+		}
+
+		logger.WithFields(logrus.Fields{
+			"user_id":     session.UserID,
+			"exercise_id": exercise.ExerciseID,
+			"one_rep_max": oneRepMax,
+		}).Info("One-rep max raised from session")
+
+		trainingMax.Exercise = exercise.Exercise
+		raised = replaceMax(raised, *trainingMax)
+	}
+	return raised
+}
+
+// replaceMax appends the training max, dropping an earlier entry for the
+// same exercise so each shows once with its latest value.
+func replaceMax(maxes []models.TrainingMax, trainingMax models.TrainingMax) []models.TrainingMax {
+	for i := range maxes {
+		if maxes[i].ExerciseID == trainingMax.ExerciseID {
+			maxes[i] = trainingMax
+			return maxes
+		}
+	}
+	return append(maxes, trainingMax)
+}
@@ -0,0 +1,20 @@
+package training
+
+import "workouts_bot/src/models"
+
+// dropShare is how much weight a drop set takes off.
+const dropShare = 0.2
+
+// DropWeight is the suggested weight for a drop set after a set with
+// weight: about a fifth lighter, rounded to the equipment's step.
+func DropWeight(exercise *models.Exercise, weight float64) float64 {
+	step := WeightStep(exercise)
+	if step == 0 {
+		step = 1
+	}
+	drop := RoundWeight(weight*(1-dropShare), step)
+	if drop >= weight {
+		drop = weight - step
+	}
+	return max(drop, 0)
+}
@@ -0,0 +1,55 @@
+package training
+
+import "workouts_bot/src/models"
+
+// Targets are the sets, reps and rest suggested for a new exercise.
+// WorkSeconds is set for timed intervals.
+type Targets struct {
+	Sets        int
+	Reps        int
+	RestSeconds int
+	WorkSeconds int
+}
+
+var goalTargets = map[string]Targets{
+	models.GoalStrength:   {Sets: 5, Reps: 5, RestSeconds: 180},
+	models.GoalMuscleGain: {Sets: 4, Reps: 10, RestSeconds: 90},
+	models.GoalEndurance:  {Sets: 3, Reps: 15, RestSeconds: 60},
+	models.GoalWeightLoss: {Sets: 3, Reps: 12, RestSeconds: 60},
+}
+
+var defaultTargets = Targets{Sets: 3, Reps: 10, RestSeconds: 90}
+
+const (
+	beginnerMaxSets = 3
+	maxSets         = 6
+	expertLevel     = 5
+)
+
+// DefaultTargets picks targets for the exercise from the user's goal and
+// experience. Beginners get fewer sets, experts one more; isolation moves
+// are never programmed in low rep ranges. HIIT exercises become intervals.
+func DefaultTargets(user *models.User, exercise *models.Exercise) Targets {
+	targets, ok := goalTargets[user.Goal]
+	if !ok {
+		targets = defaultTargets
+	}
+
+	switch exercise.Category {
+	case models.CategoryCardio, models.CategoryEndurance:
+		return Targets{Sets: 1, Reps: 1, RestSeconds: 0}
+	case models.CategoryHIIT:
+		return Targets{Sets: 6, Reps: 15, RestSeconds: 20, WorkSeconds: 40}
+	case models.CategoryIsolation:
+		targets.Reps = max(targets.Reps, 10)
+		targets.RestSeconds = min(targets.RestSeconds, 90)
+	}
+
+	switch {
+	case user.Experience < 1:
+		targets.Sets = min(targets.Sets, beginnerMaxSets)
+	case user.Experience >= expertLevel:
+		targets.Sets = min(targets.Sets+1, maxSets)
+	}
+	return targets
+}
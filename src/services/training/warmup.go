@@ -0,0 +1,202 @@
+package training
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"workouts_bot/src/models"
+)
+
+// KgPerLb converts pounds to kilograms.
+const KgPerLb = 0.45359237
+
+const (
+	// maxPlates bounds how many plate sizes a user may list.
+	maxPlates = 10
+	maxPlate  = 50
+
+	// Lighter working weights are their own warm-up.
+	barbellWarmupFrom  = 40.0
+	dumbbellWarmupFrom = 12.0
+)
+
+var ErrInvalidPlates = errors.New("invalid plates")
+
+// Loadout is the equipment the user loads: the bar, the plate sizes and
+// the dumbbell step, all in Unit.
+type Loadout struct {
+	Unit         string
+	Bar          float64
+	Plates       []float64
+	DumbbellStep float64
+}
+
+// loadouts are the standard gym sets per unit.
+var loadouts = map[string]Loadout{
+	models.UnitKg: {
+		Unit:         models.UnitKg,
+		Bar:          20,
+		Plates:       []float64{25, 20, 15, 10, 5, 2.5, 1.25},
+		DumbbellStep: 2,
+	},
+	models.UnitLb: {
+		Unit:         models.UnitLb,
+		Bar:          45,
+		Plates:       []float64{45, 35, 25, 10, 5, 2.5},
+		DumbbellStep: 5,
+	},
+}
+
+// warmupStep is a warm-up set as a share of the working weight; zero
+// means the empty bar.
+type warmupStep struct {
+	Share float64
+	Reps  int
+}
+
+var (
+	barbellWarmup = []warmupStep{
+		{Share: 0, Reps: 10},
+		{Share: 0.4, Reps: 5},
+		{Share: 0.6, Reps: 3},
+		{Share: 0.8, Reps: 2},
+	}
+	dumbbellWarmup = []warmupStep{
+		{Share: 0.5, Reps: 8},
+		{Share: 0.75, Reps: 4},
+	}
+)
+
+// LoadoutFor is the user's loadout: the standard set for their unit with
+// their own plates, if they listed any.
+func LoadoutFor(user *models.User) Loadout {
+	loadout, ok := loadouts[user.PlateUnit]
+	if !ok {
+		loadout = loadouts[models.UnitKg]
+	}
+	if plates, err := ParsePlates(user.Plates); err == nil && len(plates) > 0 {
+		loadout.Plates = plates
+	}
+	return loadout
+}
+
+// ParsePlates reads plate sizes separated by spaces, like "20 10 5 2,5".
+// The sizes come back unique and largest first.
+func ParsePlates(text string) ([]float64, error) {
+	fields := strings.Fields(text)
+	if len(fields) > maxPlates {
+		return nil, ErrInvalidPlates
+	}
+
+	plates := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		plate, err := strconv.ParseFloat(strings.ReplaceAll(field, ",", "."), 64)
+		if err != nil || plate <= 0 || plate > maxPlate {
+			return nil, ErrInvalidPlates
+		}
+		if !slices.Contains(plates, plate) {
+			plates = append(plates, plate)
+		}
+	}
+	slices.SortFunc(plates, func(a, b float64) int {
+		switch {
+		case a > b:
+			return -1
+		case a < b:
+			return 1
+		}
+		return 0
+	})
+	return plates, nil
+}
+
+// FormatPlates is the inverse of ParsePlates.
+func FormatPlates(plates []float64) string {
+	parts := make([]string, len(plates))
+	for i, plate := range plates {
+		parts[i] = strconv.FormatFloat(plate, 'f', -1, 64)
+	}
+	return strings.Join(parts, " ")
+}
+
+// FromKg converts kilograms to the unit.
+func FromKg(weight float64, unit string) float64 {
+	if unit == models.UnitLb {
+		return weight / KgPerLb
+	}
+	return weight
+}
+
+// ToKg converts a weight in the unit to kilograms, to a tenth.
+func ToKg(weight float64, unit string) float64 {
+	if unit == models.UnitLb {
+		return math.Round(weight*KgPerLb*10) / 10
+	}
+	return weight
+}
+
+// Barbell is the heaviest weight up to the given one that loads evenly
+// on the bar with the plates, never less than the empty bar.
+func (l Loadout) Barbell(weight float64) float64 {
+	side := (weight - l.Bar) / 2
+	loaded := 0.0
+	for _, plate := range l.Plates {
+		for side+1e-9 >= plate {
+			side -= plate
+			loaded += plate
+		}
+	}
+	return l.Bar + 2*loaded
+}
+
+// Dumbbell is the weight rounded down to the dumbbell step, never less
+// than one step.
+func (l Loadout) Dumbbell(weight float64) float64 {
+	return max(l.DumbbellStep, math.Floor(weight/l.DumbbellStep+1e-9)*l.DumbbellStep)
+}
+
+// Warmups ramps up to the working weight in kilograms for heavy compound
+// barbell and dumbbell lifts. Weights are loadable with the loadout and
+// returned in kilograms, exactly, with the loadout's unit to show them in;
+// sets that would repeat a weight or reach the working weight are left out.
+func Warmups(exercise *models.Exercise, working float64, loadout Loadout) []models.PlannedSet {
+	switch exercise.Category {
+	case models.CategoryCompound, models.CategoryStrength:
+	default:
+		return nil
+	}
+
+	var steps []warmupStep
+	var load func(float64) float64
+	switch {
+	case exercise.Equipment == "barbell" && working >= barbellWarmupFrom:
+		steps, load = barbellWarmup, loadout.Barbell
+	case exercise.Equipment == "dumbbell" && working >= dumbbellWarmupFrom:
+		steps, load = dumbbellWarmup, loadout.Dumbbell
+	default:
+		return nil
+	}
+
+	target := FromKg(working, loadout.Unit)
+	var sets []models.PlannedSet
+	previous := 0.0
+	for _, step := range steps {
+		weight := load(target * step.Share)
+		if weight >= target || weight <= previous {
+			continue
+		}
+		previous = weight
+		if loadout.Unit == models.UnitLb {
+			weight *= KgPerLb
+		}
+		sets = append(sets, models.PlannedSet{
+			Reps:   step.Reps,
+			Weight: weight,
+			Unit:   loadout.Unit,
+			Warmup: true,
+		})
+	}
+	return sets
+}
@@ -0,0 +1,64 @@
+package training
+
+import (
+	"time"
+	"workouts_bot/src/models"
+)
+
+// Week sums up the training of the week starting on Start, a Monday.
+// Distance is in meters.
+type Week struct {
+	Start    time.Time
+	Sessions int
+	Volume   float64
+	Cardio   int
+	Duration time.Duration
+	Distance float64
+}
+
+// WeekStart is the Monday midnight of the week t falls in, in t's
+// location.
+func WeekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Weekly totals the finished sessions and cardio entries of the last
+// weeks, the current one included, newest first. Activity before the
+// first week is ignored.
+func Weekly(
+	sessions []models.WorkoutSession,
+	entries []models.CardioEntry,
+	now time.Time,
+	weeks int,
+) []Week {
+	totals := make([]Week, weeks)
+	current := WeekStart(now)
+	for i := range totals {
+		totals[i].Start = current.AddDate(0, 0, -7*i)
+	}
+
+	week := func(t time.Time) *Week {
+		weeksAgo := int(current.Sub(WeekStart(t.In(now.Location()))).Hours()/24+0.5) / 7
+		if weeksAgo < 0 || weeksAgo >= weeks {
+			return nil
+		}
+		return &totals[weeksAgo]
+	}
+
+	for i := range sessions {
+		if total := week(sessions[i].StartedAt); total != nil {
+			total.Sessions++
+			total.Volume += sessions[i].Volume()
+		}
+	}
+	for i := range entries {
+		if total := week(entries[i].StartedAt); total != nil {
+			total.Cardio++
+			total.Duration += entries[i].Duration()
+			total.Distance += entries[i].DistanceMeters
+		}
+	}
+	return totals
+}